@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,6 +26,35 @@ type OpenClawInstanceSpec struct {
 	// +optional
 	Config ConfigSpec `json:"config,omitempty"`
 
+	// Channels configures messaging channels (e.g. "slack", "discord") by name.
+	// Each entry is merged into the "channels" object of openclaw.json; values
+	// already set under channels.<name> in the config take precedence.
+	// +optional
+	Channels map[string]ChannelSpec `json:"channels,omitempty"`
+
+	// FeatureFlags toggles OpenClaw experimental features by name. Entries are
+	// merged into the "features" object of openclaw.json; values already set
+	// under features in the config take precedence.
+	// +optional
+	FeatureFlags map[string]bool `json:"featureFlags,omitempty"`
+
+	// ConfigHashExclude lists config hash inputs whose changes must not roll
+	// the pods, for inputs managed out-of-band. Only inputs that reach the pods
+	// solely through the ConfigMap can be excluded. The ConfigMap is still
+	// updated; running pods pick the change up on their next restart.
+	// +kubebuilder:validation:items:Enum=config;channels;featureFlags;extraConfigMapData
+	// +listType=set
+	// +optional
+	ConfigHashExclude []string `json:"configHashExclude,omitempty"`
+
+	// DisableConfigHashAnnotation omits the config-hash annotation from the
+	// pod template, for GitOps tools that report it as drift. Config changes
+	// then no longer roll the pods on their own; they pick the new config
+	// up on their next restart.
+	// +kubebuilder:default=false
+	// +optional
+	DisableConfigHashAnnotation *bool `json:"disableConfigHashAnnotation,omitempty"`
+
 	// Workspace configures initial workspace files seeded into the instance.
 	// Files are copied once on first boot and never overwritten, so agent
 	// modifications survive pod restarts.
@@ -40,6 +70,23 @@ type OpenClawInstanceSpec struct {
 	// +optional
 	Skills []string `json:"skills,omitempty"`
 
+	// SkillsInstallMode selects where skills are installed. "initContainer"
+	// installs them on every pod start. "job" installs them into the data PVC
+	// via a one-off Job that the operator waits for before updating the
+	// StatefulSet, so long installs don't block pod startup.
+	// Job mode requires persistent storage without autoscaling.
+	// +kubebuilder:validation:Enum=initContainer;job
+	// +kubebuilder:default="initContainer"
+	// +optional
+	SkillsInstallMode string `json:"skillsInstallMode,omitempty"`
+
+	// SkillsPrune uninstalls skills that were previously installed by the
+	// operator but are no longer listed in Skills. Installed skills are
+	// tracked in a manifest on the data volume.
+	// +kubebuilder:default=false
+	// +optional
+	SkillsPrune *bool `json:"skillsPrune,omitempty"`
+
 	// Plugins is a list of plugins to install via init container.
 	// Each entry is an npm package name (e.g., "@martian-engineering/lossless-claw").
 	// An optional "npm:" prefix is accepted and stripped before installation.
@@ -114,6 +161,13 @@ type OpenClawInstanceSpec struct {
 	// +optional
 	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
 
+	// ExtraConfigMapData adds entries to the operator-managed ConfigMap, e.g.
+	// config files for custom sidecars. Mount them from the "config" volume
+	// via extraVolumeMounts (with subPath). Keys must not collide with
+	// operator-managed keys such as the config file name.
+	// +optional
+	ExtraConfigMapData map[string]string `json:"extraConfigMapData,omitempty"`
+
 	// Networking specifies network-related configuration
 	// +optional
 	Networking NetworkingSpec `json:"networking,omitempty"`
@@ -131,6 +185,19 @@ type OpenClawInstanceSpec struct {
 	// +optional
 	Availability AvailabilitySpec `json:"availability,omitempty"`
 
+	// Namespace configures namespace-wide policy objects the operator can
+	// create alongside the instance.
+	// +optional
+	Namespace NamespaceSpec `json:"namespace,omitempty"`
+
+	// EnableServiceLinks injects the legacy *_SERVICE_HOST/*_SERVICE_PORT
+	// env vars for every Service in the namespace into the pod. Disabled by
+	// default because they clutter the environment and can collide with
+	// OpenClaw settings.
+	// +kubebuilder:default=false
+	// +optional
+	EnableServiceLinks *bool `json:"enableServiceLinks,omitempty"`
+
 	// Suspended scales the workload to zero replicas when true.
 	// Non-runtime resources (Service, ConfigMap, RBAC, NetworkPolicy, PVC)
 	// remain fully managed. Set to false to resume normal operation.
@@ -138,6 +205,27 @@ type OpenClawInstanceSpec struct {
 	// +optional
 	Suspended bool `json:"suspended,omitempty"`
 
+	// Mode selects the workload kind. "statefulset" runs a long-lived
+	// StatefulSet (default). "job" runs the same pod once as a batch/v1 Job
+	// with restartPolicy OnFailure, for ephemeral agent runs; the sidecars
+	// run as native sidecars so the Job completes when OpenClaw exits.
+	// Job mode is incompatible with autoscaling.
+	// +kubebuilder:validation:Enum=statefulset;job
+	// +kubebuilder:default="statefulset"
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// Schedule is a cron expression (e.g. "0 * * * *"). When set, the instance
+	// pod runs as a batch/v1 CronJob on that schedule instead of a StatefulSet
+	// or one-off Job, and spec.mode is ignored. Each run uses the job-mode pod
+	// template. Incompatible with autoscaling.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// CronJob configures the CronJob created when schedule is set.
+	// +optional
+	CronJob CronJobSpec `json:"cronJob,omitempty"`
+
 	// Backup configures periodic scheduled backups to S3-compatible storage.
 	// Requires the s3-backup-credentials Secret in the operator namespace and persistence enabled.
 	// +optional
@@ -154,6 +242,11 @@ type OpenClawInstanceSpec struct {
 	// +optional
 	RuntimeDeps RuntimeDepsSpec `json:"runtimeDeps,omitempty"`
 
+	// Maintenance runs a one-off command (e.g. a data migration) in an init
+	// container before OpenClaw starts.
+	// +optional
+	Maintenance MaintenanceSpec `json:"maintenance,omitempty"`
+
 	// Gateway configures the gateway reverse proxy and authentication token
 	// +optional
 	Gateway GatewaySpec `json:"gateway,omitempty"`
@@ -167,10 +260,51 @@ type OpenClawInstanceSpec struct {
 	// +optional
 	SelfConfigure SelfConfigureSpec `json:"selfConfigure,omitempty"`
 
+	// ContainerName is the name of the main application container. Service
+	// meshes and admission policies often key off container names.
+	// +kubebuilder:default="openclaw"
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	ContainerName string `json:"containerName,omitempty"`
+
+	// EntrypointScript is a wrapper script run as the main container's
+	// command, e.g. to source environment files before starting OpenClaw.
+	// It replaces the image entrypoint, so it must start with a shebang and
+	// exec the OpenClaw gateway itself. Stored in the operator ConfigMap and
+	// mounted executable at /operator-entrypoint/entrypoint.sh.
+	// +optional
+	EntrypointScript string `json:"entrypointScript,omitempty"`
+
+	// NamePrefix is prepended to the names of all operator-generated objects
+	// (StatefulSet, Service, ConfigMaps, Secrets, ...). Immutable after creation.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*)?$`
+	// +kubebuilder:validation:MaxLength=20
+	// +optional
+	NamePrefix string `json:"namePrefix,omitempty"`
+
+	// NameSuffix is appended to the names of all operator-generated objects.
+	// Immutable after creation.
+	// +kubebuilder:validation:Pattern=`^([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=20
+	// +optional
+	NameSuffix string `json:"nameSuffix,omitempty"`
+
 	// PodAnnotations are extra annotations merged into the pod template metadata.
 	// Operator-managed annotations (e.g. config-hash) take precedence on conflict.
 	// +optional
 	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// ExtraPodLabels are extra labels added to the pod template only. They are
+	// never part of the StatefulSet selector, so they can be changed freely.
+	// Operator-managed labels take precedence on conflict.
+	// +optional
+	ExtraPodLabels map[string]string `json:"extraPodLabels,omitempty"`
+
+	// StatefulSetAnnotations are annotations set on the StatefulSet object
+	// itself (e.g. argocd.argoproj.io/sync-options), not on its pods.
+	// +optional
+	StatefulSetAnnotations map[string]string `json:"statefulSetAnnotations,omitempty"`
 }
 
 // ImageSpec defines the container image configuration
@@ -180,8 +314,8 @@ type ImageSpec struct {
 	// +optional
 	Repository string `json:"repository,omitempty"`
 
-	// Tag is the container image tag
-	// +kubebuilder:default="latest"
+	// Tag is the container image tag. Defaults to the operator's
+	// --default-image-tag ("latest" unless configured).
 	// +optional
 	Tag string `json:"tag,omitempty"`
 
@@ -198,6 +332,18 @@ type ImageSpec struct {
 	// PullSecrets is a list of secret names for pulling from private registries
 	// +optional
 	PullSecrets []corev1.LocalObjectReference `json:"pullSecrets,omitempty"`
+
+	// Variant is appended to the tag as "<tag>-<variant>" (e.g. "arm64" for
+	// "latest-arm64"). Ignored when digest is set.
+	// +optional
+	Variant string `json:"variant,omitempty"`
+
+	// PrePull annotates the pod template with every image the instance uses
+	// (comma-separated) so an image pre-puller DaemonSet can warm node caches.
+	// The annotation key is set operator-wide with --prepull-annotation.
+	// +kubebuilder:default=false
+	// +optional
+	PrePull *bool `json:"prePull,omitempty"`
 }
 
 // ConfigSpec defines the OpenClaw configuration
@@ -206,6 +352,13 @@ type ConfigSpec struct {
 	// +optional
 	ConfigMapRef *ConfigMapKeySelector `json:"configMapRef,omitempty"`
 
+	// ConfigMapRefs references additional ConfigMap keys that are deep-merged,
+	// in order, into the operator-managed openclaw.json. Later entries win.
+	// When configMapRef is also set, it is merged first.
+	// Not supported with format "json5".
+	// +optional
+	ConfigMapRefs []ConfigMapKeySelector `json:"configMapRefs,omitempty"`
+
 	// Raw is inline openclaw.json configuration (used if ConfigMapRef is not set)
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +optional
@@ -219,6 +372,15 @@ type ConfigSpec struct {
 	// +optional
 	MergeMode string `json:"mergeMode,omitempty"`
 
+	// MergeStrategy controls how mergeMode "merge" combines configs.
+	// "deep" merges nested objects recursively. "shallow" merges top-level keys
+	// only, so operator-managed objects (e.g. mcpServers) fully replace the
+	// existing ones. Ignored unless mergeMode is "merge".
+	// +kubebuilder:validation:Enum=deep;shallow
+	// +kubebuilder:default="deep"
+	// +optional
+	MergeStrategy string `json:"mergeStrategy,omitempty"`
+
 	// Format specifies the config file format.
 	// "json" (default) expects standard JSON. "json5" accepts JSON5 (comments, trailing commas).
 	// JSON5 is converted to standard JSON by the init container using npx json5.
@@ -227,6 +389,62 @@ type ConfigSpec struct {
 	// +kubebuilder:default="json"
 	// +optional
 	Format string `json:"format,omitempty"`
+
+	// Strict fails reconciliation when the config is not valid JSON instead of
+	// passing it through unenriched. Ignored for format "json5".
+	// +kubebuilder:default=false
+	// +optional
+	Strict *bool `json:"strict,omitempty"`
+
+	// Overlays are named config fragments (e.g. "dev", "prod") that can be
+	// deep-merged over the base config. Only the overlay named by
+	// activeOverlay is applied.
+	// +optional
+	Overlays map[string]RawConfig `json:"overlays,omitempty"`
+
+	// ActiveOverlay selects the entry in overlays that is deep-merged over the
+	// enriched config. Must reference an existing overlay when set.
+	// +optional
+	ActiveOverlay string `json:"activeOverlay,omitempty"`
+
+	// FileName is the name of the config file, used as the operator-managed
+	// ConfigMap key and as the file written to ~/.openclaw/ in the pod.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9][A-Za-z0-9._-]*$`
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:default="openclaw.json"
+	// +optional
+	FileName string `json:"fileName,omitempty"`
+
+	// WritableMount makes /operator-config in the main container writable so
+	// the agent can edit its own config in place. The directory is backed by
+	// an emptyDir seeded from the ConfigMap on container start, so edits
+	// survive container restarts and are restored by the postStart hook;
+	// a new pod starts again from the ConfigMap.
+	// +kubebuilder:default=false
+	// +optional
+	WritableMount *bool `json:"writableMount,omitempty"`
+
+	// FileMode is the permission mode of the files in the config volume
+	// (the ConfigMap volume's defaultMode). Defaults to 0644; use 0600 (384)
+	// to keep the config readable by its owner only.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=511
+	// +optional
+	FileMode *int32 `json:"fileMode,omitempty"`
+
+	// Immutable marks the operator-managed config ConfigMap immutable, which
+	// prevents live edits and lets the kubelet stop watching it. The operator
+	// deletes and recreates the ConfigMap when the config changes.
+	// +kubebuilder:default=false
+	// +optional
+	Immutable *bool `json:"immutable,omitempty"`
+
+	// ValidateOnStart adds an init-validate container, after the config init,
+	// that parses the final config file and fails the pod on invalid JSON
+	// before OpenClaw starts.
+	// +kubebuilder:default=false
+	// +optional
+	ValidateOnStart *bool `json:"validateOnStart,omitempty"`
 }
 
 // ConfigMapKeySelector selects a key from a ConfigMap
@@ -246,6 +464,19 @@ type RawConfig struct {
 	runtime.RawExtension `json:",inline"`
 }
 
+// ChannelSpec configures a single messaging channel.
+type ChannelSpec struct {
+	// Enabled toggles the channel (written as channels.<name>.enabled).
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Settings are channel-specific options merged into channels.<name>
+	// (e.g. {"botToken": "${SLACK_BOT_TOKEN}"}).
+	// +optional
+	Settings *RawConfig `json:"settings,omitempty"`
+}
+
 // WorkspaceSpec configures initial workspace files for the instance.
 // Files listed in InitialFiles are seeded once (only if they don't already
 // exist on the PVC), so agent modifications survive pod restarts.
@@ -326,6 +557,12 @@ type ResourcesSpec struct {
 	// Limits describes the maximum amount of compute resources allowed
 	// +optional
 	Limits ResourceList `json:"limits,omitempty"`
+
+	// Guaranteed sets the container's limits equal to its requests, as
+	// required for the Guaranteed QoS class. A resource that sets only a
+	// limit uses it for both; setting both to different values is rejected.
+	// +optional
+	Guaranteed *bool `json:"guaranteed,omitempty"`
 }
 
 // ResourceList defines CPU and memory resources
@@ -349,6 +586,13 @@ type SecuritySpec struct {
 	// +optional
 	ContainerSecurityContext *ContainerSecurityContextSpec `json:"containerSecurityContext,omitempty"`
 
+	// InitSeccompProfile overrides the seccomp profile of the operator-managed
+	// init containers (init-config, init-skills, ...), e.g. a Localhost
+	// profile. Native sidecars and user init containers are not affected.
+	// Defaults to RuntimeDefault.
+	// +optional
+	InitSeccompProfile *corev1.SeccompProfile `json:"initSeccompProfile,omitempty"`
+
 	// NetworkPolicy configures network isolation
 	// +optional
 	NetworkPolicy NetworkPolicySpec `json:"networkPolicy,omitempty"`
@@ -361,6 +605,11 @@ type SecuritySpec struct {
 	// Use this in environments with TLS-intercepting proxies or private CAs.
 	// +optional
 	CABundle *CABundleSpec `json:"caBundle,omitempty"`
+
+	// ShareProcessNamespace puts all containers in one PID namespace so a
+	// sidecar can inspect or signal the main process (e.g. for debugging).
+	// +optional
+	ShareProcessNamespace *bool `json:"shareProcessNamespace,omitempty"`
 }
 
 // CABundleSpec configures custom CA certificate injection.
@@ -406,6 +655,13 @@ type PodSecurityContextSpec struct {
 	// +optional
 	FSGroupChangePolicy *corev1.PodFSGroupChangePolicy `json:"fsGroupChangePolicy,omitempty"`
 
+	// DisableFSGroup omits fsGroup (and fsGroupChangePolicy) from the pod
+	// security context, for CSI drivers that manage volume ownership
+	// themselves and break when fsGroup is also set.
+	// +kubebuilder:default=false
+	// +optional
+	DisableFSGroup *bool `json:"disableFSGroup,omitempty"`
+
 	// RunAsNonRoot indicates that the container must run as a non-root user
 	// +kubebuilder:default=true
 	// +optional
@@ -465,6 +721,14 @@ type NetworkPolicySpec struct {
 	// +optional
 	AllowDNS *bool `json:"allowDNS,omitempty"`
 
+	// DNSTarget scopes the DNS egress rule. "Anywhere" (default) allows port
+	// 53 to any destination; "KubeDNS" only allows the k8s-app=kube-dns pods
+	// in kube-system.
+	// +kubebuilder:validation:Enum=Anywhere;KubeDNS
+	// +kubebuilder:default="Anywhere"
+	// +optional
+	DNSTarget string `json:"dnsTarget,omitempty"`
+
 	// AdditionalEgress appends custom egress rules to the default DNS + HTTPS rules.
 	// Use this to allow traffic to cluster-internal services on non-standard ports.
 	// +optional
@@ -491,6 +755,20 @@ type RBACSpec struct {
 	// AdditionalRules adds custom RBAC rules to the generated Role
 	// +optional
 	AdditionalRules []RBACRule `json:"additionalRules,omitempty"`
+
+	// AutomountToken mounts the default service account token into the pod
+	// for in-pod tooling that talks to the Kubernetes API. Defaults to false.
+	// Self-configure and Tailscale still force the token on when they need it.
+	// +optional
+	AutomountToken *bool `json:"automountToken,omitempty"`
+
+	// ConfigMapVerbs overrides the verbs of the base Role rule on the
+	// instance's own ConfigMap. Defaults to [get, watch].
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Enum=get;list;watch
+	// +listType=set
+	// +optional
+	ConfigMapVerbs []string `json:"configMapVerbs,omitempty"`
 }
 
 // RBACRule represents a RBAC rule
@@ -508,6 +786,35 @@ type StorageSpec struct {
 	// Persistence configures the PersistentVolumeClaim
 	// +optional
 	Persistence PersistenceSpec `json:"persistence,omitempty"`
+
+	// Logs configures a dedicated logs volume, kept separate from the data
+	// volume so logs stay out of state backups.
+	// +optional
+	Logs LogsStorageSpec `json:"logs,omitempty"`
+}
+
+// LogsStorageSpec defines the logs volume mounted in the main container
+type LogsStorageSpec struct {
+	// Enabled adds the logs volume
+	// +kubebuilder:default=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Size is the size of the logs PVC (e.g., "5Gi"). When empty, the logs
+	// volume is an emptyDir and does not survive pod restarts.
+	// +optional
+	Size string `json:"size,omitempty"`
+
+	// StorageClass is the name of the StorageClass for the logs PVC.
+	// If empty, the cluster default StorageClass is used.
+	// +optional
+	StorageClass *string `json:"storageClass,omitempty"`
+
+	// MountPath is where the logs volume is mounted in the main container
+	// +kubebuilder:default="/var/log/openclaw"
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
 }
 
 // PersistenceSpec defines PVC configuration
@@ -625,6 +932,64 @@ type ChromiumSpec struct {
 	// sidecar container, merged with the operator-managed variables.
 	// +optional
 	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+
+	// CDPEnvName is the name of the main container env var holding the CDP
+	// URL, for OpenClaw forks that expect a different name.
+	// +kubebuilder:default="OPENCLAW_CHROMIUM_CDP"
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	// +optional
+	CDPEnvName string `json:"cdpEnvName,omitempty"`
+
+	// Profiles customizes the browser profiles written to the OpenClaw config.
+	// The "default" and "chrome" profiles always point at the sidecar; entries
+	// with those names change their color or cdpUrl, other names add
+	// profiles. Values set in spec.config.raw still win.
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Profiles []BrowserProfileSpec `json:"profiles,omitempty"`
+
+	// Replicas is the number of Chromium sidecars. Above 1, the containers
+	// are named chromium-0..chromium-N-1, each listens on its own CDP port
+	// (9222, 9224, ...) and gets a matching browser profile. Only the first
+	// is exposed through the CDP Service and uses the persistent profile.
+	// Values above 1 require the default Chromium image.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// TmpSizeLimit caps the chromium-tmp emptyDir mounted at /tmp (e.g. "2Gi")
+	// so Chrome cannot fill the node's ephemeral storage. Unlimited when unset.
+	// +optional
+	TmpSizeLimit string `json:"tmpSizeLimit,omitempty"`
+
+	// FSGroup is the group that owns the /chromium-data profile volume.
+	// Chromium runs as 65534, outside the pod fsGroup, which breaks on CSI
+	// drivers that ignore fsGroup. When set, an init container chowns the
+	// volume to 65534:<fsGroup> and Chromium runs with this primary group.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+}
+
+// BrowserProfileSpec configures an OpenClaw browser profile
+type BrowserProfileSpec struct {
+	// Name is the profile name under browser.profiles
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Color is the profile color shown in the UI. Defaults to "#4285F4".
+	// +kubebuilder:validation:Pattern=`^#[0-9A-Fa-f]{6}$`
+	// +optional
+	Color string `json:"color,omitempty"`
+
+	// CDPURL overrides the CDP endpoint. Defaults to the Chromium sidecar.
+	// +optional
+	CDPURL string `json:"cdpUrl,omitempty"`
 }
 
 // ChromiumPersistenceSpec configures persistent storage for Chromium browser profiles
@@ -669,6 +1034,11 @@ type ChromiumImageSpec struct {
 	// Digest is the container image digest for supply chain security
 	// +optional
 	Digest string `json:"digest,omitempty"`
+
+	// Variant is appended to the tag as "<tag>-<variant>" (e.g. "arm64" for
+	// "latest-arm64"). Ignored when digest is set.
+	// +optional
+	Variant string `json:"variant,omitempty"`
 }
 
 // TailscaleSpec configures Tailscale integration for secure tailnet access.
@@ -690,6 +1060,29 @@ type TailscaleSpec struct {
 	// +optional
 	Mode string `json:"mode,omitempty"`
 
+	// Userspace runs tailscaled with userspace networking (TS_USERSPACE=true),
+	// which needs no extra privileges. Set to false for kernel networking;
+	// the sidecar then runs as root with the NET_ADMIN capability and
+	// /dev/net/tun from the node, which the pod security admission level must allow.
+	// +kubebuilder:default=true
+	// +optional
+	Userspace *bool `json:"userspace,omitempty"`
+
+	// ServePort is the tailnet HTTPS port that Tailscale serve (or funnel)
+	// listens on and proxies to the gateway. Funnel only supports 443, 8443
+	// and 10000.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=443
+	// +optional
+	ServePort *int32 `json:"servePort,omitempty"`
+
+	// ServeCanvas additionally serves the canvas on the tailnet on port 8443
+	// (or funnels it in funnel mode). By default only the gateway is served.
+	// +kubebuilder:default=false
+	// +optional
+	ServeCanvas *bool `json:"serveCanvas,omitempty"`
+
 	// Image configures the Tailscale sidecar container image.
 	// The same image is used for the sidecar and the init container that
 	// copies the tailscale CLI binary.
@@ -720,6 +1113,24 @@ type TailscaleSpec struct {
 	// Resources specifies compute resources for the Tailscale sidecar container.
 	// +optional
 	Resources ResourcesSpec `json:"resources,omitempty"`
+
+	// Probes configures the Tailscale sidecar readiness probe. By default it
+	// checks that the tailscaled socket exists.
+	// +optional
+	Probes *TailscaleProbesSpec `json:"probes,omitempty"`
+}
+
+// TailscaleProbesSpec configures health probes for the Tailscale sidecar
+type TailscaleProbesSpec struct {
+	// Readiness configures the readiness probe
+	// +optional
+	Readiness *ProbeSpec `json:"readiness,omitempty"`
+
+	// Command replaces the default socket check, e.g.
+	// ["tailscale", "--socket=/var/run/tailscale/tailscaled.sock", "status"].
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	Command []string `json:"command,omitempty"`
 }
 
 // TailscaleImageSpec defines the Tailscale sidecar container image
@@ -737,6 +1148,11 @@ type TailscaleImageSpec struct {
 	// Digest is the container image digest for supply chain security
 	// +optional
 	Digest string `json:"digest,omitempty"`
+
+	// Variant is appended to the tag as "<tag>-<variant>" (e.g. "arm64" for
+	// "latest-arm64"). Ignored when digest is set.
+	// +optional
+	Variant string `json:"variant,omitempty"`
 }
 
 // OllamaSpec defines the Ollama sidecar configuration
@@ -746,6 +1162,24 @@ type OllamaSpec struct {
 	// +optional
 	Enabled bool `json:"enabled,omitempty"`
 
+	// External uses an Ollama server outside the pod instead of the sidecar.
+	// No sidecar, model pull or cache volume is created, and OLLAMA_HOST is
+	// not injected; point it at the server via spec.env. Only applies when
+	// enabled is true.
+	// +optional
+	External *bool `json:"external,omitempty"`
+
+	// ExternalHostCIDRs are the address ranges of the external Ollama server.
+	// The NetworkPolicy allows egress to them on externalPort.
+	// +optional
+	ExternalHostCIDRs []string `json:"externalHostCIDRs,omitempty"`
+
+	// ExternalPort is the port of the external Ollama server (default 11434).
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	ExternalPort *int32 `json:"externalPort,omitempty"`
+
 	// Image configures the Ollama container image
 	// +optional
 	Image OllamaImageSpec `json:"image,omitempty"`
@@ -755,6 +1189,22 @@ type OllamaSpec struct {
 	// +optional
 	Models []string `json:"models,omitempty"`
 
+	// InitTimeoutSeconds bounds each model pull in the init-ollama container.
+	// A pull still running after this many seconds is killed and the init
+	// container fails, so a hung download restarts instead of blocking the
+	// pod forever.
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	InitTimeoutSeconds *int32 `json:"initTimeoutSeconds,omitempty"`
+
+	// RegistryCIDRs are the address ranges of the Ollama model registry
+	// (registry.ollama.ai and its CDN). When models are set, the NetworkPolicy
+	// allows HTTPS egress to them. NetworkPolicy cannot match DNS names, so
+	// these must be kept in sync with what the registry resolves to.
+	// +optional
+	RegistryCIDRs []string `json:"registryCIDRs,omitempty"`
+
 	// Resources specifies compute resources for the Ollama container
 	// +optional
 	Resources ResourcesSpec `json:"resources,omitempty"`
@@ -767,6 +1217,29 @@ type OllamaSpec struct {
 	// +kubebuilder:validation:Minimum=0
 	// +optional
 	GPU *int32 `json:"gpu,omitempty"`
+
+	// GPUResourceName overrides the extended resource name used for the GPU
+	// request and limit (e.g. "nvidia.com/mig-1g.5gb" on MIG-partitioned nodes).
+	// The count still comes from gpu. Defaults to "nvidia.com/gpu".
+	// +optional
+	GPUResourceName string `json:"gpuResourceName,omitempty"`
+
+	// RequireGPUNode adds a required nodeAffinity term so the pod only
+	// schedules onto GPU nodes. Only applies when gpu is greater than 0.
+	// +optional
+	RequireGPUNode *bool `json:"requireGPUNode,omitempty"`
+
+	// GPUNodeLabel is the node label that must equal "true" when requireGPUNode
+	// is set. Defaults to "nvidia.com/gpu.present" (set by GPU feature discovery).
+	// +optional
+	GPUNodeLabel string `json:"gpuNodeLabel,omitempty"`
+
+	// FSGroup is the group that owns the ollama-models volume. When set, an
+	// init container chowns the volume to 0:<fsGroup> and Ollama runs with
+	// this primary group.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FSGroup *int64 `json:"fsGroup,omitempty"`
 }
 
 // OllamaImageSpec defines the Ollama container image
@@ -784,6 +1257,11 @@ type OllamaImageSpec struct {
 	// Digest is the container image digest for supply chain security
 	// +optional
 	Digest string `json:"digest,omitempty"`
+
+	// Variant is appended to the tag as "<tag>-<variant>" (e.g. "arm64" for
+	// "latest-arm64"). Ignored when digest is set.
+	// +optional
+	Variant string `json:"variant,omitempty"`
 }
 
 // OllamaStorageSpec configures the Ollama model cache volume
@@ -839,6 +1317,11 @@ type WebTerminalImageSpec struct {
 	// Digest is the container image digest for supply chain security
 	// +optional
 	Digest string `json:"digest,omitempty"`
+
+	// Variant is appended to the tag as "<tag>-<variant>" (e.g. "arm64" for
+	// "latest-arm64"). Ignored when digest is set.
+	// +optional
+	Variant string `json:"variant,omitempty"`
 }
 
 // WebTerminalCredentialSpec configures basic auth for the web terminal
@@ -856,6 +1339,33 @@ type NetworkingSpec struct {
 	// Ingress configures the Kubernetes Ingress
 	// +optional
 	Ingress IngressSpec `json:"ingress,omitempty"`
+
+	// GatewayProxy configures the nginx gateway-proxy sidecar
+	// +optional
+	GatewayProxy GatewayProxySpec `json:"gatewayProxy,omitempty"`
+}
+
+// GatewayProxySpec configures the gateway-proxy sidecar that forwards
+// external traffic to the loopback-bound gateway.
+type GatewayProxySpec struct {
+	// DrainSeconds is how long the proxy sleeps in its preStop hook so it
+	// outlives the main container and in-flight connections can finish.
+	// Set to 0 to disable. Must stay below the pod's 30s grace period.
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=25
+	// +optional
+	DrainSeconds *int32 `json:"drainSeconds,omitempty"`
+
+	// ExtraArgs are appended to the proxy's nginx command line
+	// (nginx -g "daemon off;"), e.g. ["-e", "stderr"] for the error log.
+	// +optional
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+
+	// ExtraEnv specifies additional environment variables for the
+	// gateway-proxy sidecar container.
+	// +optional
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
 }
 
 // ServiceSpec defines the Service configuration
@@ -876,6 +1386,26 @@ type ServiceSpec struct {
 	// +kubebuilder:validation:MaxItems=20
 	// +optional
 	Ports []ServicePortSpec `json:"ports,omitempty"`
+
+	// TrafficDistribution sets the Service's trafficDistribution for
+	// topology-aware routing, e.g. "PreferClose" to prefer endpoints in the
+	// client's zone. PreferSameZone and PreferSameNode need Kubernetes 1.33+.
+	// +kubebuilder:validation:Enum=PreferClose;PreferSameZone;PreferSameNode
+	// +optional
+	TrafficDistribution *string `json:"trafficDistribution,omitempty"`
+
+	// NodePort pins the node port of the "gateway" Service port. Only applied
+	// when type is NodePort; otherwise the API server picks one.
+	// +kubebuilder:validation:Minimum=30000
+	// +kubebuilder:validation:Maximum=32767
+	// +optional
+	NodePort *int32 `json:"nodePort,omitempty"`
+
+	// PublishNotReadyAddresses publishes endpoints for pods that are not yet
+	// ready, so clients can reach new pods during a rolling update.
+	// +kubebuilder:default=false
+	// +optional
+	PublishNotReadyAddresses *bool `json:"publishNotReadyAddresses,omitempty"`
 }
 
 // ServicePortSpec defines a port exposed by the Service
@@ -900,6 +1430,12 @@ type ServicePortSpec struct {
 	// +kubebuilder:default="TCP"
 	// +optional
 	Protocol corev1.Protocol `json:"protocol,omitempty"`
+
+	// AppProtocol is the application protocol hint for service meshes and
+	// load balancers, e.g. "http", "kubernetes.io/ws" or "kubernetes.io/h2c".
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	AppProtocol *string `json:"appProtocol,omitempty"`
 }
 
 // IngressSpec defines the Ingress configuration
@@ -925,6 +1461,20 @@ type IngressSpec struct {
 	// +optional
 	TLS []IngressTLS `json:"tls,omitempty"`
 
+	// DefaultPathType is the path type applied to paths that omit pathType.
+	// +kubebuilder:validation:Enum=Prefix;Exact;ImplementationSpecific
+	// +kubebuilder:default="Prefix"
+	// +optional
+	DefaultPathType string `json:"defaultPathType,omitempty"`
+
+	// PathRewrite serves OpenClaw under a sub-path (e.g. "/openclaw"). The
+	// prefix is prepended to every path and stripped before requests reach
+	// the backend. Implemented for the nginx provider only; traefik needs a
+	// StripPrefix Middleware referenced via annotations.
+	// +kubebuilder:validation:Pattern=`^/.*[^/]$`
+	// +optional
+	PathRewrite string `json:"pathRewrite,omitempty"`
+
 	// Security configures ingress security settings
 	// +optional
 	Security IngressSecuritySpec `json:"security,omitempty"`
@@ -947,9 +1497,9 @@ type IngressPath struct {
 	// +optional
 	Path string `json:"path,omitempty"`
 
-	// PathType determines how the path should be matched
+	// PathType determines how the path should be matched.
+	// Defaults to spec.networking.ingress.defaultPathType when not set.
 	// +kubebuilder:validation:Enum=Prefix;Exact;ImplementationSpecific
-	// +kubebuilder:default="Prefix"
 	// +optional
 	PathType string `json:"pathType,omitempty"`
 
@@ -991,6 +1541,13 @@ type IngressSecuritySpec struct {
 	// auto-generates a random password and stores it in a managed Secret.
 	// +optional
 	BasicAuth *IngressBasicAuthSpec `json:"basicAuth,omitempty"`
+
+	// AllowedSourceRanges restricts Ingress access to the listed client CIDRs
+	// (e.g. "203.0.113.0/24"). Bare IPs are accepted as single-host ranges.
+	// Emitted as the nginx whitelist-source-range annotation. Traefik requires
+	// an IPAllowList Middleware CRD, which the operator does not create.
+	// +optional
+	AllowedSourceRanges []string `json:"allowedSourceRanges,omitempty"`
 }
 
 // IngressBasicAuthSpec configures HTTP Basic Authentication for the Ingress.
@@ -1045,6 +1602,13 @@ type ProbesSpec struct {
 	// Startup probe configuration
 	// +optional
 	Startup *ProbeSpec `json:"startup,omitempty"`
+
+	// ExecCommand replaces the default HTTP GET handler of all three probes
+	// with an exec probe running this command (e.g. a static curl or
+	// grpc_health_probe binary on distroless images).
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	ExecCommand []string `json:"execCommand,omitempty"`
 }
 
 // ProbeSpec defines a health probe
@@ -1105,6 +1669,50 @@ type MetricsSpec struct {
 	// GrafanaDashboard configures auto-provisioned Grafana dashboard ConfigMaps
 	// +optional
 	GrafanaDashboard *GrafanaDashboardSpec `json:"grafanaDashboard,omitempty"`
+
+	// Ingress exposes /metrics through a separate Ingress, e.g. on an
+	// internal ingress class scraped by an external Prometheus
+	// +optional
+	Ingress *MetricsIngressSpec `json:"ingress,omitempty"`
+
+	// SeparateService creates a headless "<name>-metrics" Service exposing only
+	// the metrics port, e.g. as a Prometheus federation target
+	// +optional
+	SeparateService *bool `json:"separateService,omitempty"`
+
+	// AnnotationScrape adds prometheus.io/scrape, prometheus.io/port and
+	// prometheus.io/path annotations to the Service, for Prometheus setups
+	// that discover targets by annotation instead of ServiceMonitor
+	// +kubebuilder:default=false
+	// +optional
+	AnnotationScrape *bool `json:"annotationScrape,omitempty"`
+
+	// RulesAsConfigMap also publishes the PrometheusRule rule groups as a
+	// "<name>-rules" ConfigMap labeled prometheus_rule=1, for Prometheus setups
+	// that load rule files through a ConfigMap sidecar
+	// +kubebuilder:default=false
+	// +optional
+	RulesAsConfigMap *bool `json:"rulesAsConfigMap,omitempty"`
+}
+
+// MetricsIngressSpec configures the Ingress for the metrics endpoint
+type MetricsIngressSpec struct {
+	// Enabled enables metrics Ingress creation
+	// +kubebuilder:default=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ClassName is the IngressClass to use (typically an internal one)
+	// +optional
+	ClassName *string `json:"className,omitempty"`
+
+	// Host is the host /metrics is served on. Required when Enabled is true.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Annotations to add to the Ingress
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // ServiceMonitorSpec defines the ServiceMonitor configuration
@@ -1119,6 +1727,11 @@ type ServiceMonitorSpec struct {
 	// +optional
 	Interval string `json:"interval,omitempty"`
 
+	// ScrapeTimeout is the scrape timeout. It must not exceed the interval.
+	// Defaults to the Prometheus scrape timeout when empty.
+	// +optional
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+
 	// Labels to add to the ServiceMonitor
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
@@ -1139,6 +1752,18 @@ type PrometheusRuleSpec struct {
 	// +kubebuilder:default="https://openclaw.rocks/docs/runbooks"
 	// +optional
 	RunbookBaseURL string `json:"runbookBaseURL,omitempty"`
+
+	// DefaultFor is the Prometheus duration (e.g. "2m") used as the "for"
+	// field of the built-in alerts that have no duration of their own ("0m").
+	// Alerts with a built-in duration keep it.
+	// +kubebuilder:validation:Pattern=`^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$`
+	// +optional
+	DefaultFor string `json:"defaultFor,omitempty"`
+
+	// AlertFor overrides the "for" duration of individual alerts, keyed by
+	// alert name (e.g. OpenClawPodCrashLooping). Takes precedence over DefaultFor.
+	// +optional
+	AlertFor map[string]string `json:"alertFor,omitempty"`
 }
 
 // GrafanaDashboardSpec configures auto-provisioned Grafana dashboard ConfigMaps
@@ -1173,6 +1798,58 @@ type LoggingSpec struct {
 	Format string `json:"format,omitempty"`
 }
 
+// NamespaceSpec configures namespace-wide policy objects.
+type NamespaceSpec struct {
+	// LimitRange creates a LimitRange that applies the instance's resource
+	// requests and limits as defaults to every container in the namespace.
+	// +optional
+	LimitRange LimitRangeSpec `json:"limitRange,omitempty"`
+
+	// ResourceQuota creates a ResourceQuota capping the total resources of
+	// all pods in the namespace.
+	// +optional
+	ResourceQuota ResourceQuotaSpec `json:"resourceQuota,omitempty"`
+}
+
+// ResourceQuotaSpec configures the namespace ResourceQuota. Only the limits
+// that are set are enforced.
+type ResourceQuotaSpec struct {
+	// Enabled creates the ResourceQuota. It affects every pod in the
+	// namespace, so enable it on at most one instance per namespace.
+	// +kubebuilder:default=false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Requests caps the sum of CPU and memory requests (requests.cpu,
+	// requests.memory).
+	// +optional
+	Requests ResourceList `json:"requests,omitempty"`
+
+	// Limits caps the sum of CPU and memory limits (limits.cpu,
+	// limits.memory).
+	// +optional
+	Limits ResourceList `json:"limits,omitempty"`
+
+	// Pods caps the number of pods.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Pods *int32 `json:"pods,omitempty"`
+
+	// PersistentVolumeClaims caps the number of PersistentVolumeClaims.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PersistentVolumeClaims *int32 `json:"persistentVolumeClaims,omitempty"`
+}
+
+// LimitRangeSpec configures the namespace LimitRange.
+type LimitRangeSpec struct {
+	// Enabled creates the LimitRange. It affects every pod in the
+	// namespace, so enable it on at most one instance per namespace.
+	// +kubebuilder:default=false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
 // AvailabilitySpec defines high availability settings
 type AvailabilitySpec struct {
 	// PodDisruptionBudget configures the PDB
@@ -1187,6 +1864,13 @@ type AvailabilitySpec struct {
 	// +optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 
+	// OSNodeSelector adds kubernetes.io/os=linux to the pod nodeSelector so
+	// the pod never lands on Windows nodes in mixed clusters. A
+	// kubernetes.io/os entry in nodeSelector takes precedence.
+	// +kubebuilder:default=true
+	// +optional
+	OSNodeSelector *bool `json:"osNodeSelector,omitempty"`
+
 	// Tolerations are tolerations for pod scheduling
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
@@ -1199,6 +1883,14 @@ type AvailabilitySpec struct {
 	// +optional
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 
+	// SpreadAcrossNodes adds a kubernetes.io/hostname topology spread
+	// constraint (maxSkew 1, ScheduleAnyway) so replicas prefer different
+	// nodes. Only applies when more than one replica can run, and is skipped
+	// when topologySpreadConstraints already has a hostname constraint.
+	// +kubebuilder:default=true
+	// +optional
+	SpreadAcrossNodes *bool `json:"spreadAcrossNodes,omitempty"`
+
 	// RuntimeClassName refers to a RuntimeClass object in the cluster,
 	// which should be used to run this pod.
 	// If no RuntimeClass resource matches the named class, the pod will not be run.
@@ -1206,6 +1898,21 @@ type AvailabilitySpec struct {
 	// More info: https://kubernetes.io/docs/concepts/containers/runtime-class/
 	// +optional
 	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// ReadinessGates lists extra pod conditions, set by other controllers,
+	// that must be true before the pod is considered ready.
+	// +optional
+	ReadinessGates []corev1.PodReadinessGate `json:"readinessGates,omitempty"`
+
+	// Subdomain sets the pod template's subdomain so that, paired with a
+	// headless Service of the same name, the pod resolves as
+	// <hostname>.<subdomain>.<namespace>.svc. The StatefulSet controller
+	// replaces it with the StatefulSet's serviceName, so it only takes effect
+	// for Job and CronJob pods (spec.mode=job, spec.schedule).
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	Subdomain string `json:"subdomain,omitempty"`
 }
 
 // AutoScalingSpec configures horizontal pod auto-scaling via HPA
@@ -1299,6 +2006,47 @@ type RuntimeDepsSpec struct {
 	Python bool `json:"python,omitempty"`
 }
 
+// MaintenanceSpec configures the init-maintenance container, which runs after
+// config initialization and before the main container with the data volume
+// mounted. Changing it triggers a rollout, so the command runs again.
+type MaintenanceSpec struct {
+	// Enabled adds the init-maintenance container.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Command is the command to run, e.g. ["sh", "-c", "openclaw migrate"].
+	// The data directory is mounted at /home/openclaw/.openclaw.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Image is the full image reference for the container. Defaults to the
+	// OpenClaw image.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// CronJobSpec configures the CronJob that runs a scheduled instance
+type CronJobSpec struct {
+	// ConcurrencyPolicy controls overlapping runs: "Forbid" skips a run while
+	// the previous one is active, "Replace" cancels it, "Allow" runs both.
+	// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+	// +kubebuilder:default="Forbid"
+	// +optional
+	ConcurrencyPolicy batchv1.ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// HistoryLimit is the number of successful runs to retain.
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// FailedHistoryLimit is the number of failed runs to retain.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailedHistoryLimit *int32 `json:"failedHistoryLimit,omitempty"`
+}
+
 // GatewaySpec configures the gateway reverse proxy and authentication token
 type GatewaySpec struct {
 	// Enabled controls whether the built-in gateway reverse proxy sidecar is
@@ -1310,11 +2058,39 @@ type GatewaySpec struct {
 	Enabled *bool `json:"enabled,omitempty"`
 
 	// ExistingSecret is the name of a user-managed Secret containing the gateway token.
-	// The Secret must have a key named "token". When set, the operator skips
+	// The Secret must have a key named "token" (see existingSecretKey). When set, the operator skips
 	// auto-generating a gateway token Secret and uses this Secret instead.
 	// +optional
 	ExistingSecret string `json:"existingSecret,omitempty"`
 
+	// ExistingSecretKey is the key in ExistingSecret that holds the gateway
+	// token. Defaults to "token". Ignored unless existingSecret is set.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +optional
+	ExistingSecretKey string `json:"existingSecretKey,omitempty"`
+
+	// TokenFromEnvOnly omits gateway.auth.token from the generated ConfigMap so
+	// the token is only delivered through the OPENCLAW_GATEWAY_TOKEN env var
+	// sourced from the Secret. gateway.auth.mode=token is still injected.
+	// +kubebuilder:default=false
+	// +optional
+	TokenFromEnvOnly *bool `json:"tokenFromEnvOnly,omitempty"`
+
+	// AuthMode selects how clients authenticate to the gateway: "token"
+	// (default) or "basic" for HTTP basic auth with the credentials in
+	// basicAuthSecret. Sets gateway.auth.mode in the generated config.
+	// +kubebuilder:validation:Enum=token;basic
+	// +optional
+	AuthMode string `json:"authMode,omitempty"`
+
+	// BasicAuthSecret is the name of a Secret with "username" and "password"
+	// keys, passed to the gateway as OPENCLAW_GATEWAY_USERNAME and
+	// OPENCLAW_GATEWAY_PASSWORD. Required when authMode is "basic".
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	BasicAuthSecret string `json:"basicAuthSecret,omitempty"`
+
 	// ControlUiOrigins is a list of additional allowed origins for the Control UI.
 	// The operator always auto-injects localhost origins (http://localhost:18789,
 	// http://127.0.0.1:18789) and derives origins from ingress hosts. Use this
@@ -1322,6 +2098,14 @@ type GatewaySpec struct {
 	// +kubebuilder:validation:MaxItems=20
 	// +optional
 	ControlUIOrigins []string `json:"controlUiOrigins,omitempty"`
+
+	// Bind sets gateway.bind in the generated openclaw.json (e.g. "loopback",
+	// "lan", "0.0.0.0"). Takes precedence over gateway.bind in spec.config.
+	// When unset, a bind from the config is kept, otherwise it defaults to
+	// "loopback" with the gateway proxy and "0.0.0.0" without it.
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	Bind string `json:"bind,omitempty"`
 }
 
 // AutoUpdateStatus tracks the state of automatic version updates
@@ -1377,7 +2161,7 @@ type AutoUpdateStatus struct {
 // OpenClawInstanceStatus defines the observed state of OpenClawInstance
 type OpenClawInstanceStatus struct {
 	// Phase represents the current lifecycle phase of the instance
-	// +kubebuilder:validation:Enum=Pending;Provisioning;Running;Degraded;Failed;Terminating;BackingUp;Restoring;Updating;Suspended
+	// +kubebuilder:validation:Enum=Pending;Provisioning;Running;Degraded;Failed;Terminating;BackingUp;Restoring;Updating;Suspended;Succeeded
 	// +optional
 	Phase string `json:"phase,omitempty"`
 
@@ -1442,6 +2226,14 @@ type ManagedResourcesStatus struct {
 	// +optional
 	StatefulSet string `json:"statefulSet,omitempty"`
 
+	// Job is the name of the managed workload Job (spec.mode=job)
+	// +optional
+	Job string `json:"job,omitempty"`
+
+	// CronJob is the name of the managed workload CronJob (spec.schedule)
+	// +optional
+	CronJob string `json:"cronJob,omitempty"`
+
 	// Deployment is the name of the legacy Deployment (deprecated, used during migration)
 	// +optional
 	Deployment string `json:"deployment,omitempty"`
@@ -1462,6 +2254,10 @@ type ManagedResourcesStatus struct {
 	// +optional
 	ChromiumPVC string `json:"chromiumPVC,omitempty"`
 
+	// LogsPVC is the name of the managed logs PVC
+	// +optional
+	LogsPVC string `json:"logsPVC,omitempty"`
+
 	// NetworkPolicy is the name of the managed NetworkPolicy
 	// +optional
 	NetworkPolicy string `json:"networkPolicy,omitempty"`
@@ -1556,6 +2352,9 @@ const (
 	// ConditionTypeStatefulSetReady indicates the StatefulSet is ready
 	ConditionTypeStatefulSetReady = "StatefulSetReady"
 
+	// ConditionTypeJobComplete indicates the workload Job finished successfully (spec.mode=job)
+	ConditionTypeJobComplete = "JobComplete"
+
 	// ConditionTypeDeploymentReady indicates the Deployment is ready (deprecated)
 	ConditionTypeDeploymentReady = "DeploymentReady"
 
@@ -1589,6 +2388,10 @@ const (
 	// ConditionTypeSkillPacksReady indicates skill packs were resolved successfully
 	ConditionTypeSkillPacksReady = "SkillPacksReady"
 
+	// ConditionTypeSkillsInstalled indicates the skills install Job
+	// (skillsInstallMode "job") completed successfully
+	ConditionTypeSkillsInstalled = "SkillsInstalled"
+
 	// ConditionTypeWorkspaceReady indicates the workspace configuration is valid
 	// and any external ConfigMap referenced by spec.workspace.configMapRef exists
 	ConditionTypeWorkspaceReady = "WorkspaceReady"
@@ -1606,4 +2409,6 @@ const (
 	PhaseRestoring    = "Restoring"
 	PhaseUpdating     = "Updating"
 	PhaseSuspended    = "Suspended"
+	// PhaseSucceeded is set when the workload Job of spec.mode "job" completed
+	PhaseSucceeded = "Succeeded"
 )
@@ -54,6 +54,32 @@ type SelfConfigureSpec struct {
 	// +kubebuilder:validation:MaxItems=4
 	// +optional
 	AllowedActions []SelfConfigAction `json:"allowedActions,omitempty"`
+
+	// TokenAudience, when set, mounts a projected service account token with
+	// this audience into the main container instead of the automounted token.
+	// Useful when an API gateway in front of the Kubernetes API expects a
+	// specific audience.
+	// +optional
+	TokenAudience string `json:"tokenAudience,omitempty"`
+
+	// AdditionalConfigMaps lists extra ConfigMaps in the instance namespace the
+	// agent may read (e.g. a shared prompt library). Access is scoped by name.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	AdditionalConfigMaps []string `json:"additionalConfigMaps,omitempty"`
+
+	// AdditionalConfigMapsWritable also grants update and patch on the
+	// additionalConfigMaps.
+	// +kubebuilder:default=false
+	// +optional
+	AdditionalConfigMapsWritable *bool `json:"additionalConfigMapsWritable,omitempty"`
+
+	// ReadOnly lets the agent read its own configuration without changing it.
+	// The Role then has no create verb on openclawselfconfigs and no write
+	// verbs on other resources, and additionalConfigMapsWritable is ignored.
+	// +kubebuilder:default=false
+	// +optional
+	ReadOnly *bool `json:"readOnly,omitempty"`
 }
 
 // OpenClawSelfConfigSpec defines the desired changes to an OpenClawInstance.
@@ -172,6 +172,11 @@ func (in *AvailabilitySpec) DeepCopyInto(out *AvailabilitySpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.OSNodeSelector != nil {
+		in, out := &in.OSNodeSelector, &out.OSNodeSelector
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]v1.Toleration, len(*in))
@@ -191,11 +196,21 @@ func (in *AvailabilitySpec) DeepCopyInto(out *AvailabilitySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SpreadAcrossNodes != nil {
+		in, out := &in.SpreadAcrossNodes, &out.SpreadAcrossNodes
+		*out = new(bool)
+		**out = **in
+	}
 	if in.RuntimeClassName != nil {
 		in, out := &in.RuntimeClassName, &out.RuntimeClassName
 		*out = new(string)
 		**out = **in
 	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]v1.PodReadinessGate, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailabilitySpec.
@@ -238,6 +253,21 @@ func (in *BackupSpec) DeepCopy() *BackupSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserProfileSpec) DeepCopyInto(out *BrowserProfileSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserProfileSpec.
+func (in *BrowserProfileSpec) DeepCopy() *BrowserProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CABundleSpec) DeepCopyInto(out *CABundleSpec) {
 	*out = *in
@@ -253,6 +283,31 @@ func (in *CABundleSpec) DeepCopy() *CABundleSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelSpec) DeepCopyInto(out *ChannelSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(RawConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelSpec.
+func (in *ChannelSpec) DeepCopy() *ChannelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChromiumImageSpec) DeepCopyInto(out *ChromiumImageSpec) {
 	*out = *in
@@ -292,7 +347,7 @@ func (in *ChromiumPersistenceSpec) DeepCopy() *ChromiumPersistenceSpec {
 func (in *ChromiumSpec) DeepCopyInto(out *ChromiumSpec) {
 	*out = *in
 	out.Image = in.Image
-	out.Resources = in.Resources
+	in.Resources.DeepCopyInto(&out.Resources)
 	in.Persistence.DeepCopyInto(&out.Persistence)
 	if in.ExtraArgs != nil {
 		in, out := &in.ExtraArgs, &out.ExtraArgs
@@ -306,6 +361,21 @@ func (in *ChromiumSpec) DeepCopyInto(out *ChromiumSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]BrowserProfileSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChromiumSpec.
@@ -356,11 +426,48 @@ func (in *ConfigSpec) DeepCopyInto(out *ConfigSpec) {
 		*out = new(ConfigMapKeySelector)
 		**out = **in
 	}
+	if in.ConfigMapRefs != nil {
+		in, out := &in.ConfigMapRefs, &out.ConfigMapRefs
+		*out = make([]ConfigMapKeySelector, len(*in))
+		copy(*out, *in)
+	}
 	if in.Raw != nil {
 		in, out := &in.Raw, &out.Raw
 		*out = new(RawConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Strict != nil {
+		in, out := &in.Strict, &out.Strict
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Overlays != nil {
+		in, out := &in.Overlays, &out.Overlays
+		*out = make(map[string]RawConfig, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.WritableMount != nil {
+		in, out := &in.WritableMount, &out.WritableMount
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FileMode != nil {
+		in, out := &in.FileMode, &out.FileMode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Immutable != nil {
+		in, out := &in.Immutable, &out.Immutable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ValidateOnStart != nil {
+		in, out := &in.ValidateOnStart, &out.ValidateOnStart
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigSpec.
@@ -413,6 +520,31 @@ func (in *ContainerSecurityContextSpec) DeepCopy() *ContainerSecurityContextSpec
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronJobSpec) DeepCopyInto(out *CronJobSpec) {
+	*out = *in
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedHistoryLimit != nil {
+		in, out := &in.FailedHistoryLimit, &out.FailedHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronJobSpec.
+func (in *CronJobSpec) DeepCopy() *CronJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CronJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewaySpec) DeepCopyInto(out *GatewaySpec) {
 	*out = *in
@@ -426,6 +558,11 @@ func (in *GatewaySpec) DeepCopyInto(out *GatewaySpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.TokenFromEnvOnly != nil {
+		in, out := &in.TokenFromEnvOnly, &out.TokenFromEnvOnly
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewaySpec.
@@ -438,6 +575,38 @@ func (in *GatewaySpec) DeepCopy() *GatewaySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayProxySpec) DeepCopyInto(out *GatewayProxySpec) {
+	*out = *in
+	if in.DrainSeconds != nil {
+		in, out := &in.DrainSeconds, &out.DrainSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraEnv != nil {
+		in, out := &in.ExtraEnv, &out.ExtraEnv
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayProxySpec.
+func (in *GatewayProxySpec) DeepCopy() *GatewayProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrafanaDashboardSpec) DeepCopyInto(out *GrafanaDashboardSpec) {
 	*out = *in
@@ -473,6 +642,11 @@ func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrePull != nil {
+		in, out := &in.PrePull, &out.PrePull
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSpec.
@@ -570,6 +744,11 @@ func (in *IngressSecuritySpec) DeepCopyInto(out *IngressSecuritySpec) {
 		*out = new(IngressBasicAuthSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AllowedSourceRanges != nil {
+		in, out := &in.AllowedSourceRanges, &out.AllowedSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSecuritySpec.
@@ -644,6 +823,26 @@ func (in *IngressTLS) DeepCopy() *IngressTLS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LimitRangeSpec) DeepCopyInto(out *LimitRangeSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LimitRangeSpec.
+func (in *LimitRangeSpec) DeepCopy() *LimitRangeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LimitRangeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoggingSpec) DeepCopyInto(out *LoggingSpec) {
 	*out = *in
@@ -659,6 +858,46 @@ func (in *LoggingSpec) DeepCopy() *LoggingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogsStorageSpec) DeepCopyInto(out *LogsStorageSpec) {
+	*out = *in
+	if in.StorageClass != nil {
+		in, out := &in.StorageClass, &out.StorageClass
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogsStorageSpec.
+func (in *LogsStorageSpec) DeepCopy() *LogsStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogsStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceSpec) DeepCopyInto(out *MaintenanceSpec) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceSpec.
+func (in *MaintenanceSpec) DeepCopy() *MaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedResourcesStatus) DeepCopyInto(out *ManagedResourcesStatus) {
 	*out = *in
@@ -674,6 +913,33 @@ func (in *ManagedResourcesStatus) DeepCopy() *ManagedResourcesStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsIngressSpec) DeepCopyInto(out *MetricsIngressSpec) {
+	*out = *in
+	if in.ClassName != nil {
+		in, out := &in.ClassName, &out.ClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsIngressSpec.
+func (in *MetricsIngressSpec) DeepCopy() *MetricsIngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsIngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricsSpec) DeepCopyInto(out *MetricsSpec) {
 	*out = *in
@@ -702,6 +968,26 @@ func (in *MetricsSpec) DeepCopyInto(out *MetricsSpec) {
 		*out = new(GrafanaDashboardSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(MetricsIngressSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SeparateService != nil {
+		in, out := &in.SeparateService, &out.SeparateService
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AnnotationScrape != nil {
+		in, out := &in.AnnotationScrape, &out.AnnotationScrape
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RulesAsConfigMap != nil {
+		in, out := &in.RulesAsConfigMap, &out.RulesAsConfigMap
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsSpec.
@@ -714,6 +1000,23 @@ func (in *MetricsSpec) DeepCopy() *MetricsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSpec) DeepCopyInto(out *NamespaceSpec) {
+	*out = *in
+	in.LimitRange.DeepCopyInto(&out.LimitRange)
+	in.ResourceQuota.DeepCopyInto(&out.ResourceQuota)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSpec.
+func (in *NamespaceSpec) DeepCopy() *NamespaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
 	*out = *in
@@ -766,6 +1069,7 @@ func (in *NetworkingSpec) DeepCopyInto(out *NetworkingSpec) {
 	*out = *in
 	in.Service.DeepCopyInto(&out.Service)
 	in.Ingress.DeepCopyInto(&out.Ingress)
+	in.GatewayProxy.DeepCopyInto(&out.GatewayProxy)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkingSpec.
@@ -813,19 +1117,54 @@ func (in *OllamaImageSpec) DeepCopy() *OllamaImageSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OllamaSpec) DeepCopyInto(out *OllamaSpec) {
 	*out = *in
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExternalHostCIDRs != nil {
+		in, out := &in.ExternalHostCIDRs, &out.ExternalHostCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExternalPort != nil {
+		in, out := &in.ExternalPort, &out.ExternalPort
+		*out = new(int32)
+		**out = **in
+	}
 	out.Image = in.Image
 	if in.Models != nil {
 		in, out := &in.Models, &out.Models
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	out.Resources = in.Resources
+	if in.InitTimeoutSeconds != nil {
+		in, out := &in.InitTimeoutSeconds, &out.InitTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RegistryCIDRs != nil {
+		in, out := &in.RegistryCIDRs, &out.RegistryCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
 	out.Storage = in.Storage
 	if in.GPU != nil {
 		in, out := &in.GPU, &out.GPU
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RequireGPUNode != nil {
+		in, out := &in.RequireGPUNode, &out.RequireGPUNode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OllamaSpec.
@@ -917,6 +1256,30 @@ func (in *OpenClawInstanceSpec) DeepCopyInto(out *OpenClawInstanceSpec) {
 	*out = *in
 	in.Image.DeepCopyInto(&out.Image)
 	in.Config.DeepCopyInto(&out.Config)
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make(map[string]ChannelSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.FeatureFlags != nil {
+		in, out := &in.FeatureFlags, &out.FeatureFlags
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ConfigHashExclude != nil {
+		in, out := &in.ConfigHashExclude, &out.ConfigHashExclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DisableConfigHashAnnotation != nil {
+		in, out := &in.DisableConfigHashAnnotation, &out.DisableConfigHashAnnotation
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Workspace != nil {
 		in, out := &in.Workspace, &out.Workspace
 		*out = new(WorkspaceSpec)
@@ -927,6 +1290,11 @@ func (in *OpenClawInstanceSpec) DeepCopyInto(out *OpenClawInstanceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SkillsPrune != nil {
+		in, out := &in.SkillsPrune, &out.SkillsPrune
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Plugins != nil {
 		in, out := &in.Plugins, &out.Plugins
 		*out = make([]string, len(*in))
@@ -946,7 +1314,7 @@ func (in *OpenClawInstanceSpec) DeepCopyInto(out *OpenClawInstanceSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.Resources = in.Resources
+	in.Resources.DeepCopyInto(&out.Resources)
 	in.Security.DeepCopyInto(&out.Security)
 	in.Storage.DeepCopyInto(&out.Storage)
 	in.Chromium.DeepCopyInto(&out.Chromium)
@@ -988,6 +1356,13 @@ func (in *OpenClawInstanceSpec) DeepCopyInto(out *OpenClawInstanceSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExtraConfigMapData != nil {
+		in, out := &in.ExtraConfigMapData, &out.ExtraConfigMapData
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	in.Networking.DeepCopyInto(&out.Networking)
 	if in.Probes != nil {
 		in, out := &in.Probes, &out.Probes
@@ -996,8 +1371,16 @@ func (in *OpenClawInstanceSpec) DeepCopyInto(out *OpenClawInstanceSpec) {
 	}
 	in.Observability.DeepCopyInto(&out.Observability)
 	in.Availability.DeepCopyInto(&out.Availability)
+	in.Namespace.DeepCopyInto(&out.Namespace)
+	if in.EnableServiceLinks != nil {
+		in, out := &in.EnableServiceLinks, &out.EnableServiceLinks
+		*out = new(bool)
+		**out = **in
+	}
+	in.CronJob.DeepCopyInto(&out.CronJob)
 	in.Backup.DeepCopyInto(&out.Backup)
 	out.RuntimeDeps = in.RuntimeDeps
+	in.Maintenance.DeepCopyInto(&out.Maintenance)
 	in.Gateway.DeepCopyInto(&out.Gateway)
 	in.AutoUpdate.DeepCopyInto(&out.AutoUpdate)
 	in.SelfConfigure.DeepCopyInto(&out.SelfConfigure)
@@ -1008,6 +1391,20 @@ func (in *OpenClawInstanceSpec) DeepCopyInto(out *OpenClawInstanceSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ExtraPodLabels != nil {
+		in, out := &in.ExtraPodLabels, &out.ExtraPodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StatefulSetAnnotations != nil {
+		in, out := &in.StatefulSetAnnotations, &out.StatefulSetAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenClawInstanceSpec.
@@ -1269,6 +1666,11 @@ func (in *PodSecurityContextSpec) DeepCopyInto(out *PodSecurityContextSpec) {
 		*out = new(v1.PodFSGroupChangePolicy)
 		**out = **in
 	}
+	if in.DisableFSGroup != nil {
+		in, out := &in.DisableFSGroup, &out.DisableFSGroup
+		*out = new(bool)
+		**out = **in
+	}
 	if in.RunAsNonRoot != nil {
 		in, out := &in.RunAsNonRoot, &out.RunAsNonRoot
 		*out = new(bool)
@@ -1344,6 +1746,11 @@ func (in *ProbesSpec) DeepCopyInto(out *ProbesSpec) {
 		*out = new(ProbeSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExecCommand != nil {
+		in, out := &in.ExecCommand, &out.ExecCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbesSpec.
@@ -1371,6 +1778,13 @@ func (in *PrometheusRuleSpec) DeepCopyInto(out *PrometheusRuleSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.AlertFor != nil {
+		in, out := &in.AlertFor, &out.AlertFor
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRuleSpec.
@@ -1435,6 +1849,16 @@ func (in *RBACSpec) DeepCopyInto(out *RBACSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AutomountToken != nil {
+		in, out := &in.AutomountToken, &out.AutomountToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConfigMapVerbs != nil {
+		in, out := &in.ConfigMapVerbs, &out.ConfigMapVerbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACSpec.
@@ -1503,11 +1927,46 @@ func (in *ResourceList) DeepCopy() *ResourceList {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceQuotaSpec) DeepCopyInto(out *ResourceQuotaSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PersistentVolumeClaims != nil {
+		in, out := &in.PersistentVolumeClaims, &out.PersistentVolumeClaims
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuotaSpec.
+func (in *ResourceQuotaSpec) DeepCopy() *ResourceQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourcesSpec) DeepCopyInto(out *ResourcesSpec) {
 	*out = *in
 	out.Requests = in.Requests
 	out.Limits = in.Limits
+	if in.Guaranteed != nil {
+		in, out := &in.Guaranteed, &out.Guaranteed
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcesSpec.
@@ -1548,6 +2007,11 @@ func (in *SecuritySpec) DeepCopyInto(out *SecuritySpec) {
 		*out = new(ContainerSecurityContextSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.InitSeccompProfile != nil {
+		in, out := &in.InitSeccompProfile, &out.InitSeccompProfile
+		*out = new(v1.SeccompProfile)
+		(*in).DeepCopyInto(*out)
+	}
 	in.NetworkPolicy.DeepCopyInto(&out.NetworkPolicy)
 	in.RBAC.DeepCopyInto(&out.RBAC)
 	if in.CABundle != nil {
@@ -1555,6 +2019,11 @@ func (in *SecuritySpec) DeepCopyInto(out *SecuritySpec) {
 		*out = new(CABundleSpec)
 		**out = **in
 	}
+	if in.ShareProcessNamespace != nil {
+		in, out := &in.ShareProcessNamespace, &out.ShareProcessNamespace
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuritySpec.
@@ -1590,6 +2059,21 @@ func (in *SelfConfigureSpec) DeepCopyInto(out *SelfConfigureSpec) {
 		*out = make([]SelfConfigAction, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdditionalConfigMaps != nil {
+		in, out := &in.AdditionalConfigMaps, &out.AdditionalConfigMaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalConfigMapsWritable != nil {
+		in, out := &in.AdditionalConfigMapsWritable, &out.AdditionalConfigMapsWritable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadOnly != nil {
+		in, out := &in.ReadOnly, &out.ReadOnly
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfConfigureSpec.
@@ -1637,6 +2121,11 @@ func (in *ServicePortSpec) DeepCopyInto(out *ServicePortSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.AppProtocol != nil {
+		in, out := &in.AppProtocol, &out.AppProtocol
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePortSpec.
@@ -1666,6 +2155,21 @@ func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TrafficDistribution != nil {
+		in, out := &in.TrafficDistribution, &out.TrafficDistribution
+		*out = new(string)
+		**out = **in
+	}
+	if in.NodePort != nil {
+		in, out := &in.NodePort, &out.NodePort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PublishNotReadyAddresses != nil {
+		in, out := &in.PublishNotReadyAddresses, &out.PublishNotReadyAddresses
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
@@ -1682,6 +2186,7 @@ func (in *ServiceSpec) DeepCopy() *ServiceSpec {
 func (in *StorageSpec) DeepCopyInto(out *StorageSpec) {
 	*out = *in
 	in.Persistence.DeepCopyInto(&out.Persistence)
+	in.Logs.DeepCopyInto(&out.Logs)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageSpec.
@@ -1709,16 +2214,61 @@ func (in *TailscaleImageSpec) DeepCopy() *TailscaleImageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TailscaleProbesSpec) DeepCopyInto(out *TailscaleProbesSpec) {
+	*out = *in
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TailscaleProbesSpec.
+func (in *TailscaleProbesSpec) DeepCopy() *TailscaleProbesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TailscaleProbesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TailscaleSpec) DeepCopyInto(out *TailscaleSpec) {
 	*out = *in
+	if in.Userspace != nil {
+		in, out := &in.Userspace, &out.Userspace
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ServePort != nil {
+		in, out := &in.ServePort, &out.ServePort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ServeCanvas != nil {
+		in, out := &in.ServeCanvas, &out.ServeCanvas
+		*out = new(bool)
+		**out = **in
+	}
 	out.Image = in.Image
 	if in.AuthKeySecretRef != nil {
 		in, out := &in.AuthKeySecretRef, &out.AuthKeySecretRef
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
-	out.Resources = in.Resources
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(TailscaleProbesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TailscaleSpec.
@@ -1766,7 +2316,7 @@ func (in *WebTerminalImageSpec) DeepCopy() *WebTerminalImageSpec {
 func (in *WebTerminalSpec) DeepCopyInto(out *WebTerminalSpec) {
 	*out = *in
 	out.Image = in.Image
-	out.Resources = in.Resources
+	in.Resources.DeepCopyInto(&out.Resources)
 	if in.Credential != nil {
 		in, out := &in.Credential, &out.Credential
 		*out = new(WebTerminalCredentialSpec)
@@ -49,6 +49,7 @@ import (
 	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
 	"github.com/openclawrocks/openclaw-operator/internal/controller"
 	"github.com/openclawrocks/openclaw-operator/internal/registry"
+	"github.com/openclawrocks/openclaw-operator/internal/resources"
 	"github.com/openclawrocks/openclaw-operator/internal/skillpacks"
 )
 
@@ -73,6 +74,10 @@ func main() {
 	var enableHTTP2 bool
 	var otlpEndpoint string
 	var otlpInsecure bool
+	var annotationPrefix string
+	var managedBy string
+	var prePullAnnotation string
+	var defaultImageTag string
 	var tlsOpts []func(*tls.Config)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable.")
@@ -82,6 +87,10 @@ func main() {
 	flag.BoolVar(&enableHTTP2, "enable-http2", false, "If set, HTTP/2 will be enabled for the metrics and webhook servers.")
 	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "", "OTLP gRPC endpoint for metrics export (e.g. collector.observability.svc:4317). Also respects OTEL_EXPORTER_OTLP_ENDPOINT env var.")
 	flag.BoolVar(&otlpInsecure, "otlp-insecure", true, "If set, OTLP exporter connects without TLS.")
+	flag.StringVar(&annotationPrefix, "annotation-prefix", resources.AnnotationPrefix, "Domain prefix (a DNS subdomain) for annotations written by the operator (e.g. config-hash, skip-backup). Annotations under a previous prefix, such as skip-backup set by users, are no longer honored after a change.")
+	flag.StringVar(&managedBy, "managed-by", resources.ManagedByValue, "Value of the app.kubernetes.io/managed-by label set on generated resources.")
+	flag.StringVar(&defaultImageTag, "default-image-tag", resources.DefaultOpenClawImageTag, "OpenClaw image tag used when spec.image.tag is empty. Pin a release to avoid the mutable \"latest\" tag.")
+	flag.StringVar(&prePullAnnotation, "prepull-annotation", "", "Pod template annotation listing an instance's images when spec.image.prePull is set (default \"<annotation-prefix>/prepull-images\").")
 
 	opts := zap.Options{
 		Development: true,
@@ -90,6 +99,13 @@ func main() {
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	if err := resources.SetAnnotationPrefix(annotationPrefix); err != nil {
+		setupLog.Error(err, "invalid --annotation-prefix")
+		os.Exit(1)
+	}
+	resources.SetManagedBy(managedBy)
+	resources.SetPrePullAnnotation(prePullAnnotation)
+	resources.SetDefaultOpenClawImageTag(defaultImageTag)
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -58,7 +58,7 @@ func (r *OpenClawInstanceReconciler) resolveInitialTag(ctx context.Context, inst
 	if !isAutoUpdateEnabled(instance) {
 		return false, nil
 	}
-	if instance.Spec.Image.Tag != "latest" {
+	if resources.GetImageTag(instance) != "latest" {
 		return false, nil
 	}
 	if r.VersionResolver == nil {
@@ -145,8 +145,8 @@ func (r *OpenClawInstanceReconciler) reconcileAutoUpdate(ctx context.Context, in
 	autoUpdateChecksTotal.WithLabelValues(instance.Name, instance.Namespace, "success").Inc()
 
 	// Determine current version
-	currentTag := instance.Spec.Image.Tag
-	if currentTag == "" || currentTag == "latest" {
+	currentTag := resources.GetImageTag(instance)
+	if currentTag == "latest" {
 		// Existing instance still on "latest" — resolve to the concrete version now
 		logger.Info("Resolving 'latest' tag to concrete version", "resolved", version)
 		original := instance.DeepCopy()
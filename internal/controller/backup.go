@@ -65,7 +65,7 @@ func (r *OpenClawInstanceReconciler) reconcileDeleteWithBackup(ctx context.Conte
 	}
 
 	// Step 1: Check skip-backup annotation
-	if instance.Annotations[AnnotationSkipBackup] == "true" {
+	if instance.Annotations[skipBackupAnnotation()] == "true" {
 		logger.Info("Skip-backup annotation set, removing finalizer immediately")
 		instance.Status.Phase = openclawv1alpha1.PhaseTerminating
 		if err := r.Status().Update(ctx, instance); err != nil {
@@ -225,7 +225,7 @@ func (r *OpenClawInstanceReconciler) reconcileDeleteWithBackup(ctx context.Conte
 	if condType == batchv1.JobFailed {
 		logger.Error(nil, "Backup Job failed - retrying until timeout", "job", jobName)
 		r.Recorder.Event(instance, corev1.EventTypeWarning, "BackupFailed",
-			fmt.Sprintf("Backup Job %s failed. Will retry until backup timeout elapses. To skip immediately: annotate %s=true.", jobName, AnnotationSkipBackup))
+			fmt.Sprintf("Backup Job %s failed. Will retry until backup timeout elapses. To skip immediately: annotate %s=true.", jobName, skipBackupAnnotation()))
 
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
 			Type:    openclawv1alpha1.ConditionTypeBackupComplete,
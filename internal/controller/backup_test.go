@@ -101,7 +101,7 @@ var _ = Describe("Backup on Delete", func() {
 					Name:      "backup-skip-test",
 					Namespace: "default",
 					Annotations: map[string]string{
-						AnnotationSkipBackup: "true",
+						skipBackupAnnotation(): "true",
 					},
 				},
 				Spec: openclawv1alpha1.OpenClawInstanceSpec{},
@@ -190,7 +190,7 @@ var _ = Describe("Backup on Delete", func() {
 				if inst.Annotations == nil {
 					inst.Annotations = map[string]string{}
 				}
-				inst.Annotations[AnnotationSkipBackup] = "true"
+				inst.Annotations[skipBackupAnnotation()] = "true"
 				return k8sClient.Update(ctx, inst)
 			}, timeout, interval).Should(Succeed())
 
@@ -39,7 +39,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -94,6 +93,8 @@ type OpenClawInstanceReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=limitranges,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=resourcequotas,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
@@ -245,9 +246,27 @@ func (r *OpenClawInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{RequeueAfter: RequeueAfter}, nil
 	}
 
-	// Determine phase based on condition health
+	// Determine phase based on condition health. In job mode the workload
+	// Job's outcome decides the phase once it has finished.
+	jobCondition := meta.FindStatusCondition(instance.Status.Conditions, openclawv1alpha1.ConditionTypeJobComplete)
 	skillPacksCondition := meta.FindStatusCondition(instance.Status.Conditions, openclawv1alpha1.ConditionTypeSkillPacksReady)
-	if skillPacksCondition != nil && skillPacksCondition.Status == metav1.ConditionFalse {
+	if resources.IsJobMode(instance) && jobCondition != nil && jobCondition.Status == metav1.ConditionTrue {
+		instance.Status.Phase = openclawv1alpha1.PhaseSucceeded
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    openclawv1alpha1.ConditionTypeReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  "JobComplete",
+			Message: "Workload Job completed",
+		})
+	} else if resources.IsJobMode(instance) && jobCondition != nil && jobCondition.Reason == "JobFailed" {
+		instance.Status.Phase = openclawv1alpha1.PhaseFailed
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    openclawv1alpha1.ConditionTypeReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  "JobFailed",
+			Message: "Workload Job failed - delete the Job to retry",
+		})
+	} else if skillPacksCondition != nil && skillPacksCondition.Status == metav1.ConditionFalse {
 		instance.Status.Phase = openclawv1alpha1.PhaseDegraded
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
 			Type:    openclawv1alpha1.ConditionTypeReady,
@@ -255,6 +274,14 @@ func (r *OpenClawInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			Reason:  "ReconcileSucceededDegraded",
 			Message: "Resources reconciled but skill packs unavailable - instance running without skill packs",
 		})
+	} else if meta.IsStatusConditionFalse(instance.Status.Conditions, openclawv1alpha1.ConditionTypeSkillsInstalled) {
+		instance.Status.Phase = openclawv1alpha1.PhaseDegraded
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    openclawv1alpha1.ConditionTypeReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  "ReconcileSucceededDegraded",
+			Message: "Resources reconciled but the skills install Job failed - instance running without its skills",
+		})
 	} else {
 		instance.Status.Phase = openclawv1alpha1.PhaseRunning
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
@@ -302,7 +329,7 @@ func (r *OpenClawInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 }
 
 func updatePhaseMetric(name, namespace, currentPhase string) {
-	phases := []string{"Pending", "Provisioning", "Running", "Degraded", "Failed", "Terminating", "BackingUp", "Restoring", "Updating", "Suspended"}
+	phases := []string{"Pending", "Provisioning", "Running", "Degraded", "Failed", "Terminating", "BackingUp", "Restoring", "Updating", "Suspended", "Succeeded"}
 	for _, phase := range phases {
 		val := float64(0)
 		if phase == currentPhase {
@@ -316,6 +343,22 @@ func updatePhaseMetric(name, namespace, currentPhase string) {
 func (r *OpenClawInstanceReconciler) reconcileResources(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) error {
 	logger := log.FromContext(ctx)
 
+	// 0. Reject invalid resource quantities before building anything (the
+	// webhook catches these too, but it may be disabled)
+	if err := resources.ValidateResources(instance); err != nil {
+		return fmt.Errorf("invalid resources: %w", err)
+	}
+	if err := resources.ValidateStorage(instance); err != nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:               openclawv1alpha1.ConditionTypeStorageReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             "SharedReadWriteOnceClaim",
+			Message:            err.Error(),
+			ObservedGeneration: instance.Generation,
+		})
+		return fmt.Errorf("invalid storage: %w", err)
+	}
+
 	// 1. Reconcile RBAC (ServiceAccount, Role, RoleBinding)
 	if err := r.reconcileRBAC(ctx, instance); err != nil {
 		return fmt.Errorf("failed to reconcile RBAC: %w", err)
@@ -384,7 +427,7 @@ func (r *OpenClawInstanceReconciler) reconcileResources(ctx context.Context, ins
 
 	if resources.HasGatewayBindConflict(instance) {
 		r.Recorder.Event(instance, corev1.EventTypeWarning, "GatewayBindConflict",
-			"gateway.enabled is false but config sets gateway.bind to loopback - the pod will be unreachable because no proxy is running on the external interface")
+			"gateway.enabled is false but gateway.bind is loopback - the pod will be unreachable because no proxy is running on the external interface")
 	}
 
 	// 3b. Reconcile Workspace ConfigMap (seed files for workspace)
@@ -406,6 +449,11 @@ func (r *OpenClawInstanceReconciler) reconcileResources(ctx context.Context, ins
 	}
 	logger.V(1).Info("Chromium PVC reconciled")
 
+	// 4a2. Reconcile logs PVC (if storage.logs has a size)
+	if err := r.reconcileLogsPVC(ctx, instance); err != nil {
+		return fmt.Errorf("failed to reconcile logs PVC: %w", err)
+	}
+
 	// 4b. Restore from backup if spec.restoreFrom is set (must happen after PVC, before StatefulSet)
 	if result, done, err := r.reconcileRestore(ctx, instance); !done {
 		if err != nil {
@@ -417,6 +465,14 @@ func (r *OpenClawInstanceReconciler) reconcileResources(ctx context.Context, ins
 	}
 	logger.V(1).Info("Restore reconciled")
 
+	// 4c. Install skills via Job in job mode (after PVC, before StatefulSet)
+	if result, done, err := r.reconcileSkillsJob(ctx, instance); !done {
+		if err != nil {
+			return fmt.Errorf("failed to reconcile skills Job: %w", err)
+		}
+		return &requeueError{Result: result}
+	}
+
 	// 5. Reconcile PodDisruptionBudget
 	if err := r.reconcilePDB(ctx, instance); err != nil {
 		return fmt.Errorf("failed to reconcile PodDisruptionBudget: %w", err)
@@ -429,14 +485,39 @@ func (r *OpenClawInstanceReconciler) reconcileResources(ctx context.Context, ins
 	}
 	logger.V(1).Info("HPA reconciled")
 
-	// 6. Migrate Deployment → StatefulSet (if legacy Deployment exists), then reconcile StatefulSet
+	// 5c. Reconcile namespace LimitRange and ResourceQuota (if enabled)
+	if err := r.reconcileLimitRange(ctx, instance); err != nil {
+		return fmt.Errorf("failed to reconcile LimitRange: %w", err)
+	}
+	if err := r.reconcileResourceQuota(ctx, instance); err != nil {
+		return fmt.Errorf("failed to reconcile ResourceQuota: %w", err)
+	}
+
+	// 6. Migrate Deployment → StatefulSet (if legacy Deployment exists), then reconcile
+	// the workload: a StatefulSet, or a one-off Job in job mode
 	if err := r.migrateDeploymentToStatefulSet(ctx, instance); err != nil {
 		return fmt.Errorf("failed to migrate Deployment to StatefulSet: %w", err)
 	}
-	if err := r.reconcileStatefulSet(ctx, instance, gatewayToken, skillPacks, wsFiles); err != nil {
-		return fmt.Errorf("failed to reconcile StatefulSet: %w", err)
+	if err := r.deleteStaleWorkloads(ctx, instance); err != nil {
+		return fmt.Errorf("failed to delete stale workloads: %w", err)
+	}
+	switch {
+	case resources.IsScheduled(instance):
+		if err := r.reconcileWorkloadCronJob(ctx, instance, gatewayToken, skillPacks, wsFiles); err != nil {
+			return fmt.Errorf("failed to reconcile workload CronJob: %w", err)
+		}
+		logger.V(1).Info("Workload CronJob reconciled")
+	case resources.IsJobMode(instance):
+		if err := r.reconcileWorkloadJob(ctx, instance, gatewayToken, skillPacks, wsFiles); err != nil {
+			return fmt.Errorf("failed to reconcile workload Job: %w", err)
+		}
+		logger.V(1).Info("Workload Job reconciled")
+	default:
+		if err := r.reconcileStatefulSet(ctx, instance, gatewayToken, skillPacks, wsFiles); err != nil {
+			return fmt.Errorf("failed to reconcile StatefulSet: %w", err)
+		}
+		logger.V(1).Info("StatefulSet reconciled")
 	}
-	logger.V(1).Info("StatefulSet reconciled")
 
 	// 6b. Reconcile periodic backup CronJob (after StatefulSet so pod affinity labels exist)
 	if err := r.reconcileBackupCronJob(ctx, instance); err != nil {
@@ -455,12 +536,22 @@ func (r *OpenClawInstanceReconciler) reconcileResources(ctx context.Context, ins
 		return fmt.Errorf("failed to reconcile Chromium CDP Service: %w", err)
 	}
 
+	// 7c. Reconcile separate metrics Service (if enabled)
+	if err := r.reconcileMetricsService(ctx, instance); err != nil {
+		return fmt.Errorf("failed to reconcile metrics Service: %w", err)
+	}
+
 	// 8. Reconcile Ingress (if enabled)
 	if err := r.reconcileIngress(ctx, instance); err != nil {
 		return fmt.Errorf("failed to reconcile Ingress: %w", err)
 	}
 	logger.V(1).Info("Ingress reconciled")
 
+	// 8b. Reconcile metrics Ingress (if enabled)
+	if err := r.reconcileMetricsIngress(ctx, instance); err != nil {
+		return fmt.Errorf("failed to reconcile metrics Ingress: %w", err)
+	}
+
 	// 9. Reconcile ServiceMonitor (if enabled)
 	if err := r.reconcileServiceMonitor(ctx, instance); err != nil {
 		return fmt.Errorf("failed to reconcile ServiceMonitor: %w", err)
@@ -473,6 +564,10 @@ func (r *OpenClawInstanceReconciler) reconcileResources(ctx context.Context, ins
 	}
 	logger.V(1).Info("PrometheusRule reconciled")
 
+	if err := r.reconcileRulesConfigMap(ctx, instance); err != nil {
+		return fmt.Errorf("failed to reconcile rules ConfigMap: %w", err)
+	}
+
 	// 11. Reconcile Grafana Dashboards (if enabled)
 	if err := r.reconcileGrafanaDashboards(ctx, instance); err != nil {
 		return fmt.Errorf("failed to reconcile Grafana dashboards: %w", err)
@@ -600,24 +695,34 @@ func (r *OpenClawInstanceReconciler) reconcileNetworkPolicy(ctx context.Context,
 // mutually exclusive with token-based auth, so the operator must not inject
 // gateway token env vars or config keys when it is active.
 func (r *OpenClawInstanceReconciler) isGatewayAuthTrustedProxy(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) bool {
-	if instance.Spec.Config.ConfigMapRef != nil {
-		ref := instance.Spec.Config.ConfigMapRef
-		externalCM := &corev1.ConfigMap{}
-		if err := r.Get(ctx, types.NamespacedName{
-			Namespace: instance.Namespace,
-			Name:      ref.Name,
-		}, externalCM); err != nil {
-			return false
+	if refs := resources.ExternalConfigRefs(instance); len(refs) > 0 {
+		sources := make([][]byte, 0, len(refs))
+		for _, ref := range refs {
+			externalCM := &corev1.ConfigMap{}
+			if err := r.Get(ctx, types.NamespacedName{
+				Namespace: instance.Namespace,
+				Name:      ref.Name,
+			}, externalCM); err != nil {
+				return false
+			}
+			key := ref.Key
+			if key == "" {
+				key = "openclaw.json"
+			}
+			data, ok := externalCM.Data[key]
+			if !ok {
+				return false
+			}
+			sources = append(sources, []byte(data))
 		}
-		key := ref.Key
-		if key == "" {
-			key = "openclaw.json"
+		if len(sources) == 1 {
+			return resources.IsGatewayAuthTrustedProxy(sources[0])
 		}
-		data, ok := externalCM.Data[key]
-		if !ok {
+		merged, err := resources.MergeConfigSources(sources...)
+		if err != nil {
 			return false
 		}
-		return resources.IsGatewayAuthTrustedProxy([]byte(data))
+		return resources.IsGatewayAuthTrustedProxy(merged)
 	}
 	if instance.Spec.Config.Raw != nil {
 		return resources.IsGatewayAuthTrustedProxy(instance.Spec.Config.Raw.Raw)
@@ -641,10 +746,11 @@ func (r *OpenClawInstanceReconciler) reconcileGatewayTokenSecret(ctx context.Con
 			return "", fmt.Errorf("failed to get gateway existing secret: %w", err)
 		}
 		instance.Status.ManagedResources.GatewayTokenSecret = existing.Name
-		if tok, ok := existing.Data[resources.GatewayTokenSecretKey]; ok {
+		key := resources.GatewayTokenKey(instance)
+		if tok, ok := existing.Data[key]; ok {
 			return string(tok), nil
 		}
-		return "", fmt.Errorf("gateway.existingSecret %q missing key %q", instance.Spec.Gateway.ExistingSecret, resources.GatewayTokenSecretKey)
+		return "", fmt.Errorf("gateway.existingSecret %q missing key %q", instance.Spec.Gateway.ExistingSecret, key)
 	}
 
 	secretName := resources.GatewayTokenSecretName(instance)
@@ -721,46 +827,99 @@ func (r *OpenClawInstanceReconciler) reconcileTailscaleStateSecret(ctx context.C
 
 // reconcileConfigMap reconciles the operator-managed ConfigMap for openclaw.json.
 // It always creates the enriched ConfigMap regardless of config source (raw,
-// configMapRef, or none). When configMapRef is set, the external ConfigMap is
-// read and its content is used as the base for the enrichment pipeline.
+// configMapRef(s), or none). When configMapRef or configMapRefs are set, the
+// external ConfigMaps are read, deep-merged in order, and the result is used as
+// the base for the enrichment pipeline.
 func (r *OpenClawInstanceReconciler) reconcileConfigMap(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance, gatewayToken string, skillPacks *resources.ResolvedSkillPacks) error {
-	var desired *corev1.ConfigMap
+	// A nil base means "use the inline raw config".
+	var base []byte
+
+	if refs := resources.ExternalConfigRefs(instance); len(refs) > 0 {
+		sources := make([][]byte, 0, len(refs))
+		for _, ref := range refs {
+			// Read the user's external ConfigMap
+			externalCM := &corev1.ConfigMap{}
+			if err := r.Get(ctx, client.ObjectKey{
+				Namespace: instance.Namespace,
+				Name:      ref.Name,
+			}, externalCM); err != nil {
+				meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+					Type:    openclawv1alpha1.ConditionTypeConfigValid,
+					Status:  metav1.ConditionFalse,
+					Reason:  "ConfigMapNotFound",
+					Message: fmt.Sprintf("External ConfigMap %q not found: %v", ref.Name, err),
+				})
+				return fmt.Errorf("external ConfigMap %q not found: %w", ref.Name, err)
+			}
 
-	if instance.Spec.Config.ConfigMapRef != nil {
-		// Read the user's external ConfigMap
-		ref := instance.Spec.Config.ConfigMapRef
-		externalCM := &corev1.ConfigMap{}
-		if err := r.Get(ctx, client.ObjectKey{
-			Namespace: instance.Namespace,
-			Name:      ref.Name,
-		}, externalCM); err != nil {
-			meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
-				Type:    openclawv1alpha1.ConditionTypeConfigValid,
-				Status:  metav1.ConditionFalse,
-				Reason:  "ConfigMapNotFound",
-				Message: fmt.Sprintf("External ConfigMap %q not found: %v", ref.Name, err),
-			})
-			return fmt.Errorf("external ConfigMap %q not found: %w", ref.Name, err)
+			key := ref.Key
+			if key == "" {
+				key = "openclaw.json"
+			}
+			data, ok := externalCM.Data[key]
+			if !ok {
+				meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+					Type:    openclawv1alpha1.ConditionTypeConfigValid,
+					Status:  metav1.ConditionFalse,
+					Reason:  "ConfigMapKeyNotFound",
+					Message: fmt.Sprintf("Key %q not found in ConfigMap %q", key, ref.Name),
+				})
+				return fmt.Errorf("key %q not found in ConfigMap %q", key, ref.Name)
+			}
+			sources = append(sources, []byte(data))
 		}
 
-		key := ref.Key
-		if key == "" {
-			key = "openclaw.json"
-		}
-		data, ok := externalCM.Data[key]
-		if !ok {
-			meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
-				Type:    openclawv1alpha1.ConditionTypeConfigValid,
-				Status:  metav1.ConditionFalse,
-				Reason:  "ConfigMapKeyNotFound",
-				Message: fmt.Sprintf("Key %q not found in ConfigMap %q", key, ref.Name),
-			})
-			return fmt.Errorf("key %q not found in ConfigMap %q", key, ref.Name)
+		// A single source is passed through untouched so non-JSON formats
+		// (json5) keep working; multiple sources must be JSON objects.
+		base = sources[0]
+		if len(sources) > 1 {
+			merged, err := resources.MergeConfigSources(sources...)
+			if err != nil {
+				meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+					Type:    openclawv1alpha1.ConditionTypeConfigValid,
+					Status:  metav1.ConditionFalse,
+					Reason:  "ConfigMergeFailed",
+					Message: fmt.Sprintf("Failed to merge external config: %v", err),
+				})
+				return fmt.Errorf("merging external config: %w", err)
+			}
+			base = merged
 		}
+	}
 
-		desired = resources.BuildConfigMapFromBytes(instance, []byte(data), gatewayToken, skillPacks)
-	} else {
-		desired = resources.BuildConfigMap(instance, gatewayToken, skillPacks)
+	if err := resources.ValidateConfigOverlay(instance); err != nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    openclawv1alpha1.ConditionTypeConfigValid,
+			Status:  metav1.ConditionFalse,
+			Reason:  "UnknownConfigOverlay",
+			Message: err.Error(),
+		})
+		return err
+	}
+
+	desired, err := resources.BuildConfigMapStrict(instance, base, gatewayToken, skillPacks)
+	if err != nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    openclawv1alpha1.ConditionTypeConfigValid,
+			Status:  metav1.ConditionFalse,
+			Reason:  "InvalidConfig",
+			Message: err.Error(),
+		})
+		return err
+	}
+
+	// An immutable ConfigMap cannot be updated in place; delete it so
+	// CreateOrUpdate below recreates it with the new data.
+	existing := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err == nil {
+		if resources.ConfigMapNeedsRecreate(existing, desired) {
+			log.FromContext(ctx).Info("Recreating immutable config ConfigMap", "configmap", existing.Name)
+			if err := r.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return err
 	}
 
 	cm := &corev1.ConfigMap{
@@ -772,6 +931,7 @@ func (r *OpenClawInstanceReconciler) reconcileConfigMap(ctx context.Context, ins
 	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
 		cm.Labels = mergeStringMap(cm.Labels, desired.Labels)
 		cm.Data = desired.Data
+		cm.Immutable = desired.Immutable
 		return controllerutil.SetControllerReference(instance, cm, r.Scheme)
 	}); err != nil {
 		return err
@@ -831,7 +991,7 @@ func (r *OpenClawInstanceReconciler) reconcileWorkspaceConfigMap(ctx context.Con
 			resolved.defaultFiles = extCM.Data
 			// Validate all keys from the external ConfigMap
 			for key := range extCM.Data {
-				vErr := resources.ValidateWorkspaceFilename(key)
+				vErr := resources.ValidateWorkspaceFilename(instance, key)
 				if vErr == nil {
 					continue
 				}
@@ -885,7 +1045,7 @@ func (r *OpenClawInstanceReconciler) reconcileWorkspaceConfigMap(ctx context.Con
 			// Validate all keys
 			valid := true
 			for key := range extCM.Data {
-				vErr := resources.ValidateWorkspaceFilename(key)
+				vErr := resources.ValidateWorkspaceFilename(instance, key)
 				if vErr == nil {
 					continue
 				}
@@ -970,7 +1130,7 @@ func (r *OpenClawInstanceReconciler) reconcilePVC(ctx context.Context, instance
 
 	// When HPA is enabled, VolumeClaimTemplates on the StatefulSet handle
 	// per-replica PVCs - skip creating the standalone PVC.
-	if resources.IsHPAEnabled(instance) {
+	if resources.UsesVolumeClaimTemplates(instance) {
 		// Log when existingClaim is set but ignored due to HPA (config choice, not operational issue)
 		if instance.Spec.Storage.Persistence.ExistingClaim != "" {
 			log.FromContext(ctx).V(1).Info("existingClaim ignored when autoScaling is enabled - each replica gets its own PVC via VolumeClaimTemplates",
@@ -1091,6 +1251,43 @@ func (r *OpenClawInstanceReconciler) reconcileChromiumPVC(ctx context.Context, i
 	return nil
 }
 
+// reconcileLogsPVC reconciles the logs PersistentVolumeClaim
+func (r *OpenClawInstanceReconciler) reconcileLogsPVC(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) error {
+	if !resources.IsLogsPVCEnabled(instance) {
+		// Clean up managed logs PVC if it was disabled or switched to emptyDir
+		if instance.Status.ManagedResources.LogsPVC != "" {
+			pvc := &corev1.PersistentVolumeClaim{}
+			pvc.Name = instance.Status.ManagedResources.LogsPVC
+			pvc.Namespace = instance.Namespace
+			if err := r.Delete(ctx, pvc); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		instance.Status.ManagedResources.LogsPVC = ""
+		return nil
+	}
+
+	pvc := resources.BuildLogsPVC(instance)
+	if err := controllerutil.SetControllerReference(instance, pvc, r.Scheme); err != nil {
+		return err
+	}
+
+	// PVCs are immutable after creation, so we only create if not exists
+	existing := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(pvc), existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			if createErr := r.Create(ctx, pvc); createErr != nil {
+				return createErr
+			}
+		} else {
+			return err
+		}
+	}
+
+	instance.Status.ManagedResources.LogsPVC = pvc.Name
+	return nil
+}
+
 // reconcilePDB reconciles the PodDisruptionBudget
 func (r *OpenClawInstanceReconciler) reconcilePDB(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) error {
 	// Check if PDB is enabled
@@ -1162,6 +1359,52 @@ func (r *OpenClawInstanceReconciler) reconcileHPA(ctx context.Context, instance
 	return nil
 }
 
+// reconcileLimitRange reconciles the namespace LimitRange that defaults
+// container resources to the instance's. Deleted when disabled.
+func (r *OpenClawInstanceReconciler) reconcileLimitRange(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) error {
+	lr := &corev1.LimitRange{}
+	lr.Name = resources.LimitRangeName(instance)
+	lr.Namespace = instance.Namespace
+
+	if !resources.IsLimitRangeEnabled(instance) {
+		if err := r.Delete(ctx, lr); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, lr, func() error {
+		desired := resources.BuildLimitRange(instance)
+		lr.Labels = mergeStringMap(lr.Labels, desired.Labels)
+		lr.Spec = desired.Spec
+		return controllerutil.SetControllerReference(instance, lr, r.Scheme)
+	})
+	return err
+}
+
+// reconcileResourceQuota reconciles the namespace ResourceQuota. Deleted when
+// disabled.
+func (r *OpenClawInstanceReconciler) reconcileResourceQuota(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) error {
+	rq := &corev1.ResourceQuota{}
+	rq.Name = resources.ResourceQuotaName(instance)
+	rq.Namespace = instance.Namespace
+
+	if !resources.IsResourceQuotaEnabled(instance) {
+		if err := r.Delete(ctx, rq); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, rq, func() error {
+		desired := resources.BuildResourceQuota(instance)
+		rq.Labels = mergeStringMap(rq.Labels, desired.Labels)
+		rq.Spec = desired.Spec
+		return controllerutil.SetControllerReference(instance, rq, r.Scheme)
+	})
+	return err
+}
+
 // migrateDeploymentToStatefulSet detects and deletes a legacy Deployment so
 // the reconciler can create the replacement StatefulSet. This is a one-time
 // migration step — once the Deployment is gone, this function is a no-op.
@@ -1210,8 +1453,32 @@ func (r *OpenClawInstanceReconciler) migrateDeploymentToStatefulSet(ctx context.
 	return nil
 }
 
+// podGatewayTokenSecretName returns the Secret the pod reads
+// OPENCLAW_GATEWAY_TOKEN from, or "" when no token is injected. trusted-proxy
+// mode is mutually exclusive with token auth - skip injecting the env var when
+// trusted-proxy is configured.
+func (r *OpenClawInstanceReconciler) podGatewayTokenSecretName(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance, gatewayToken string) string {
+	if gatewayToken == "" || r.isGatewayAuthTrustedProxy(ctx, instance) {
+		return ""
+	}
+	if instance.Spec.Gateway.ExistingSecret != "" {
+		return instance.Spec.Gateway.ExistingSecret
+	}
+	return resources.GatewayTokenSecretName(instance)
+}
+
 // reconcileStatefulSet reconciles the StatefulSet
 func (r *OpenClawInstanceReconciler) reconcileStatefulSet(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance, gatewayToken string, skillPacks *resources.ResolvedSkillPacks, wsFiles *resolvedWorkspaceFiles) error {
+	if err := resources.ValidatePorts(instance); err != nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    openclawv1alpha1.ConditionTypeStatefulSetReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  "PortConflict",
+			Message: err.Error(),
+		})
+		return err
+	}
+
 	// Compute secret hash for rollout trigger on secret rotation
 	secretHash, missingSecrets, err := r.computeSecretHash(ctx, instance)
 	if err != nil {
@@ -1241,16 +1508,7 @@ func (r *OpenClawInstanceReconciler) reconcileStatefulSet(ctx context.Context, i
 	}
 
 	// Compute gateway token secret name once for both VCT-change detection and CreateOrUpdate.
-	// trusted-proxy mode is mutually exclusive with token auth - skip injecting the
-	// OPENCLAW_GATEWAY_TOKEN env var when trusted-proxy is configured.
-	var gwSecretName string
-	if gatewayToken != "" && !r.isGatewayAuthTrustedProxy(ctx, instance) {
-		if instance.Spec.Gateway.ExistingSecret != "" {
-			gwSecretName = instance.Spec.Gateway.ExistingSecret
-		} else {
-			gwSecretName = resources.GatewayTokenSecretName(instance)
-		}
-	}
+	gwSecretName := r.podGatewayTokenSecretName(ctx, instance, gatewayToken)
 
 	// Build the desired StatefulSet once and reuse for both VCT comparison
 	// and the CreateOrUpdate mutate func.
@@ -1287,9 +1545,12 @@ func (r *OpenClawInstanceReconciler) reconcileStatefulSet(ctx context.Context, i
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, sts, func() error {
 		sts.Labels = mergeStringMap(sts.Labels, desired.Labels)
+		sts.Annotations = mergeStringMap(sts.Annotations, desired.Annotations)
 		// Preserve current replica count when HPA manages scaling
 		existingReplicas := sts.Spec.Replicas
+		existingTemplateAnnotations := sts.Spec.Template.Annotations
 		sts.Spec = desired.Spec
+		holdSkillsHash(instance, existingTemplateAnnotations, &sts.Spec.Template)
 		if resources.IsHPAEnabled(instance) && !instance.Spec.Suspended && existingReplicas != nil {
 			sts.Spec.Replicas = existingReplicas
 		}
@@ -1298,7 +1559,7 @@ func (r *OpenClawInstanceReconciler) reconcileStatefulSet(ctx context.Context, i
 			if sts.Spec.Template.Annotations == nil {
 				sts.Spec.Template.Annotations = make(map[string]string)
 			}
-			sts.Spec.Template.Annotations["openclaw.rocks/secret-hash"] = secretHash
+			sts.Spec.Template.Annotations[resources.AnnotationKey("secret-hash")] = secretHash
 		}
 		return controllerutil.SetControllerReference(instance, sts, r.Scheme)
 	}); err != nil {
@@ -1425,6 +1686,29 @@ func (r *OpenClawInstanceReconciler) reconcileChromiumCDPService(ctx context.Con
 	return nil
 }
 
+// reconcileMetricsService reconciles the headless metrics-only Service. When
+// metrics.separateService is unset, the Service is deleted.
+func (r *OpenClawInstanceReconciler) reconcileMetricsService(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) error {
+	svc := &corev1.Service{}
+	svc.Name = resources.MetricsServiceName(instance)
+	svc.Namespace = instance.Namespace
+
+	if !resources.IsMetricsServiceEnabled(instance) {
+		if err := r.Delete(ctx, svc); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, svc, func() error {
+		desired := resources.BuildMetricsService(instance)
+		svc.Labels = mergeStringMap(svc.Labels, desired.Labels)
+		svc.Spec = desired.Spec
+		return controllerutil.SetControllerReference(instance, svc, r.Scheme)
+	})
+	return err
+}
+
 // reconcileIngress reconciles the Ingress and its supporting resources (basic auth Secret, Traefik Middleware).
 func (r *OpenClawInstanceReconciler) reconcileIngress(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) error {
 	if !instance.Spec.Networking.Ingress.Enabled {
@@ -1469,6 +1753,34 @@ func (r *OpenClawInstanceReconciler) reconcileIngress(ctx context.Context, insta
 	return nil
 }
 
+// reconcileMetricsIngress creates or deletes the Ingress that exposes /metrics
+func (r *OpenClawInstanceReconciler) reconcileMetricsIngress(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) error {
+	if !resources.IsMetricsIngressEnabled(instance) {
+		ing := &networkingv1.Ingress{}
+		ing.Name = resources.MetricsIngressName(instance)
+		ing.Namespace = instance.Namespace
+		if err := r.Delete(ctx, ing); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resources.MetricsIngressName(instance),
+			Namespace: instance.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, ingress, func() error {
+		desired := resources.BuildMetricsIngress(instance)
+		ingress.Labels = mergeStringMap(ingress.Labels, desired.Labels)
+		ingress.Annotations = mergeStringMap(ingress.Annotations, desired.Annotations)
+		ingress.Spec = desired.Spec
+		return controllerutil.SetControllerReference(instance, ingress, r.Scheme)
+	})
+	return err
+}
+
 // reconcileBasicAuthSecret ensures the htpasswd Secret for Ingress Basic Auth exists.
 // If spec.networking.ingress.security.basicAuth.existingSecret is set, no secret is created.
 // Otherwise a random 20-byte password is generated once and stored in a managed Secret.
@@ -1544,29 +1856,15 @@ func (r *OpenClawInstanceReconciler) reconcileTraefikBasicAuthMiddleware(ctx con
 		return nil
 	}
 
-	secretName := resources.BasicAuthSecretName(instance)
-	if ba.ExistingSecret != "" {
-		secretName = ba.ExistingSecret
-	}
-
-	middlewareName := instance.Name + "-basic-auth"
+	desired := resources.BuildTraefikBasicAuthMiddleware(instance)
 	mw := &unstructured.Unstructured{}
-	mw.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   "traefik.io",
-		Version: "v1alpha1",
-		Kind:    "Middleware",
-	})
-	mw.SetName(middlewareName)
-	mw.SetNamespace(instance.Namespace)
+	mw.SetGroupVersionKind(desired.GroupVersionKind())
+	mw.SetName(desired.GetName())
+	mw.SetNamespace(desired.GetNamespace())
 
 	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, mw, func() error {
-		mw.Object["spec"] = map[string]interface{}{
-			"basicAuth": map[string]interface{}{
-				"secret": secretName,
-			},
-		}
-		labels := resources.Labels(instance)
-		mw.SetLabels(labels)
+		mw.Object["spec"] = desired.Object["spec"]
+		mw.SetLabels(desired.GetLabels())
 		return controllerutil.SetControllerReference(instance, mw, r.Scheme)
 	}); err != nil {
 		return err
@@ -1583,6 +1881,10 @@ func (r *OpenClawInstanceReconciler) reconcileServiceMonitor(ctx context.Context
 		return nil
 	}
 
+	if err := r.deleteLegacyServiceMonitor(ctx, instance); err != nil {
+		return err
+	}
+
 	sm := &unstructured.Unstructured{}
 	sm.SetGroupVersionKind(resources.ServiceMonitorGVK())
 	sm.SetName(resources.ServiceMonitorName(instance))
@@ -1611,6 +1913,37 @@ func (r *OpenClawInstanceReconciler) reconcileServiceMonitor(ctx context.Context
 	return err
 }
 
+// deleteLegacyServiceMonitor deletes the ServiceMonitor left behind under its
+// pre-rename name, which would otherwise scrape every target twice. Once it is
+// gone, this function is a no-op.
+func (r *OpenClawInstanceReconciler) deleteLegacyServiceMonitor(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) error {
+	legacyName := resources.LegacyServiceMonitorName(instance)
+	if legacyName == resources.ServiceMonitorName(instance) {
+		return nil
+	}
+
+	legacy := &unstructured.Unstructured{}
+	legacy.SetGroupVersionKind(resources.ServiceMonitorGVK())
+	err := r.Get(ctx, client.ObjectKey{Name: legacyName, Namespace: instance.Namespace}, legacy)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Safety check: only delete ServiceMonitors we own
+	if !metav1.IsControlledBy(legacy, instance) {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Deleting ServiceMonitor with legacy name", "serviceMonitor", legacyName)
+	if err := r.Delete(ctx, legacy); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 // reconcilePrometheusRule reconciles the PrometheusRule for alerting
 func (r *OpenClawInstanceReconciler) reconcilePrometheusRule(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) error {
 	prEnabled := instance.Spec.Observability.Metrics.PrometheusRule != nil &&
@@ -1666,6 +1999,30 @@ func (r *OpenClawInstanceReconciler) reconcilePrometheusRule(ctx context.Context
 	return nil
 }
 
+// reconcileRulesConfigMap reconciles the ConfigMap carrying the alert rules
+// as a Prometheus rule file. When metrics.rulesAsConfigMap is unset, the
+// ConfigMap is deleted.
+func (r *OpenClawInstanceReconciler) reconcileRulesConfigMap(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) error {
+	cm := &corev1.ConfigMap{}
+	cm.Name = resources.RulesConfigMapName(instance)
+	cm.Namespace = instance.Namespace
+
+	if !resources.IsRulesConfigMapEnabled(instance) {
+		if err := r.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		desired := resources.BuildRecordingRulesConfigMap(instance)
+		cm.Labels = mergeStringMap(cm.Labels, desired.Labels)
+		cm.Data = desired.Data
+		return controllerutil.SetControllerReference(instance, cm, r.Scheme)
+	})
+	return err
+}
+
 // reconcileGrafanaDashboards reconciles Grafana dashboard ConfigMaps
 func (r *OpenClawInstanceReconciler) reconcileGrafanaDashboards(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) error {
 	dashEnabled := instance.Spec.Observability.Metrics.GrafanaDashboard != nil &&
@@ -1752,6 +2109,11 @@ func (r *OpenClawInstanceReconciler) computeSecretHash(ctx context.Context, inst
 	}
 	secretNames = append(secretNames, gwSecretName)
 
+	// Include the gateway basic auth Secret so credential changes roll the pod
+	if resources.IsGatewayBasicAuth(instance) && instance.Spec.Gateway.BasicAuthSecret != "" {
+		secretNames = append(secretNames, instance.Spec.Gateway.BasicAuthSecret)
+	}
+
 	// Include the Tailscale auth key Secret so rotations trigger a pod rollout
 	if instance.Spec.Tailscale.Enabled && instance.Spec.Tailscale.AuthKeySecretRef != nil {
 		secretNames = append(secretNames, instance.Spec.Tailscale.AuthKeySecretRef.Name)
@@ -1845,6 +2207,8 @@ func (r *OpenClawInstanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.PersistentVolumeClaim{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&corev1.Secret{}).
+		Owns(&corev1.LimitRange{}).
+		Owns(&corev1.ResourceQuota{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
 		Owns(&networkingv1.NetworkPolicy{}).
@@ -1874,9 +2238,12 @@ func (r *OpenClawInstanceReconciler) findInstancesForConfigMap(ctx context.Conte
 	for i := range instanceList.Items {
 		instance := &instanceList.Items[i]
 		matched := false
-		// Check spec.config.configMapRef
-		if instance.Spec.Config.ConfigMapRef != nil && instance.Spec.Config.ConfigMapRef.Name == cm.Name {
-			matched = true
+		// Check spec.config.configMapRef and spec.config.configMapRefs
+		for _, ref := range resources.ExternalConfigRefs(instance) {
+			if ref.Name == cm.Name {
+				matched = true
+				break
+			}
 		}
 		// Check spec.workspace.configMapRef
 		if !matched && instance.Spec.Workspace != nil &&
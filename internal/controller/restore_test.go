@@ -65,7 +65,7 @@ var _ = Describe("Restore from Backup", func() {
 			jobList := &batchv1.JobList{}
 			Expect(k8sClient.List(ctx, jobList)).Should(Succeed())
 			for _, job := range jobList.Items {
-				Expect(job.Labels).NotTo(HaveKeyWithValue("openclaw.rocks/job-type", "restore"))
+				Expect(job.Labels).NotTo(HaveKeyWithValue(jobTypeLabel(), "restore"))
 			}
 
 			// Clean up: add skip-backup BEFORE deleting to prevent backup flow
@@ -77,7 +77,7 @@ var _ = Describe("Restore from Backup", func() {
 				if inst.Annotations == nil {
 					inst.Annotations = map[string]string{}
 				}
-				inst.Annotations[AnnotationSkipBackup] = "true"
+				inst.Annotations[skipBackupAnnotation()] = "true"
 				return k8sClient.Update(ctx, inst)
 			}, timeout, interval).Should(Succeed())
 			Expect(k8sClient.Delete(ctx, instance)).Should(Succeed())
@@ -144,7 +144,7 @@ var _ = Describe("Restore from Backup", func() {
 				Name:      "restore-test-restore",
 				Namespace: "default",
 			}, job)).Should(Succeed())
-			Expect(job.Labels["openclaw.rocks/job-type"]).To(Equal("restore"))
+			Expect(job.Labels[jobTypeLabel()]).To(Equal("restore"))
 
 			// Clean up: delete the instance with skip-backup
 			Eventually(func() error {
@@ -155,7 +155,7 @@ var _ = Describe("Restore from Backup", func() {
 				if inst.Annotations == nil {
 					inst.Annotations = map[string]string{}
 				}
-				inst.Annotations[AnnotationSkipBackup] = "true"
+				inst.Annotations[skipBackupAnnotation()] = "true"
 				return k8sClient.Update(ctx, inst)
 			}, timeout, interval).Should(Succeed())
 			Expect(k8sClient.Delete(ctx, instance)).Should(Succeed())
@@ -42,9 +42,6 @@ const (
 	// RcloneImage is the pinned rclone container image
 	RcloneImage = "rclone/rclone:1.68"
 
-	// AnnotationSkipBackup allows skipping backup on delete
-	AnnotationSkipBackup = "openclaw.rocks/skip-backup"
-
 	// LabelTenant is the label key for the tenant ID
 	LabelTenant = "openclaw.rocks/tenant"
 
@@ -167,7 +164,7 @@ func (r *OpenClawInstanceReconciler) reconcileS3MirrorSecret(ctx context.Context
 	}
 	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
 		secret.Labels = map[string]string{
-			LabelManagedBy: "openclaw-operator",
+			LabelManagedBy: resources.ManagedByValue,
 			LabelInstance:  instance.Name,
 		}
 		secret.Data = map[string][]byte{
@@ -318,6 +315,18 @@ func int64Ptr(v int64) *int64 {
 	return &v
 }
 
+// skipBackupAnnotation returns the annotation key that allows skipping the
+// backup on delete: "<AnnotationPrefix>/skip-backup".
+func skipBackupAnnotation() string {
+	return resources.AnnotationKey("skip-backup")
+}
+
+// jobTypeLabel returns the label key for the backup/restore Job type:
+// "<AnnotationPrefix>/job-type".
+func jobTypeLabel() string {
+	return resources.AnnotationKey("job-type")
+}
+
 // backupJobName returns a deterministic name for the backup Job
 func backupJobName(instance *openclawv1alpha1.OpenClawInstance) string {
 	return instance.Name + "-backup"
@@ -331,10 +340,10 @@ func restoreJobName(instance *openclawv1alpha1.OpenClawInstance) string {
 // backupLabels returns labels for a backup/restore Job
 func backupLabels(instance *openclawv1alpha1.OpenClawInstance, jobType string) map[string]string {
 	return map[string]string{
-		LabelManagedBy:            "openclaw-operator",
-		LabelTenant:               getTenantID(instance),
-		LabelInstance:             instance.Name,
-		"openclaw.rocks/job-type": jobType,
+		LabelManagedBy: resources.ManagedByValue,
+		LabelTenant:    getTenantID(instance),
+		LabelInstance:  instance.Name,
+		jobTypeLabel(): jobType,
 	}
 }
 
@@ -17,6 +17,8 @@ limitations under the License.
 package controller
 
 import (
+	"testing"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -364,7 +366,7 @@ var _ = Describe("S3 Helpers", func() {
 			labels := backupLabels(instance, "backup")
 			Expect(labels[LabelTenant]).To(Equal("cus_123"))
 			Expect(labels[LabelInstance]).To(Equal("myinst"))
-			Expect(labels["openclaw.rocks/job-type"]).To(Equal("backup"))
+			Expect(labels[jobTypeLabel()]).To(Equal("backup"))
 			Expect(labels[LabelManagedBy]).To(Equal("openclaw-operator"))
 		})
 	})
@@ -522,7 +524,7 @@ var _ = Describe("S3 Helpers", func() {
 
 		It("Should set periodic-backup label", func() {
 			cronJob := buildBackupCronJob(instance, creds, "myinst-s3-credentials")
-			Expect(cronJob.Labels["openclaw.rocks/job-type"]).To(Equal("periodic-backup"))
+			Expect(cronJob.Labels[jobTypeLabel()]).To(Equal("periodic-backup"))
 		})
 
 		It("Should propagate nodeSelector and tolerations from spec.availability", func() {
@@ -682,3 +684,24 @@ var _ = Describe("S3 Helpers", func() {
 		})
 	})
 })
+
+func TestBackupKeys_CustomAnnotationPrefix(t *testing.T) {
+	orig := resources.AnnotationPrefix
+	t.Cleanup(func() { resources.AnnotationPrefix = orig })
+
+	if err := resources.SetAnnotationPrefix("claw.example.com"); err != nil {
+		t.Fatalf("SetAnnotationPrefix: %v", err)
+	}
+
+	if got := skipBackupAnnotation(); got != "claw.example.com/skip-backup" {
+		t.Errorf("skipBackupAnnotation() = %q, want %q", got, "claw.example.com/skip-backup")
+	}
+	instance := &openclawv1alpha1.OpenClawInstance{ObjectMeta: metav1.ObjectMeta{Name: "prefixed"}}
+	labels := backupLabels(instance, "backup")
+	if labels["claw.example.com/job-type"] != "backup" {
+		t.Errorf("expected job-type label under the custom prefix, got %v", labels)
+	}
+	if _, ok := labels["openclaw.rocks/job-type"]; ok {
+		t.Error("default-prefix job-type label should not be set")
+	}
+}
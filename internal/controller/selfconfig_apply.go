@@ -40,7 +40,9 @@ var protectedConfigKeys = map[string]bool{
 var protectedEnvVars = map[string]bool{
 	"HOME":                      true,
 	"OPENCLAW_DISABLE_BONJOUR":  true,
+	"OPENCLAW_GATEWAY_PASSWORD": true,
 	"OPENCLAW_GATEWAY_TOKEN":    true,
+	"OPENCLAW_GATEWAY_USERNAME": true,
 	"OPENCLAW_INSTANCE_NAME":    true,
 	"OPENCLAW_NAMESPACE":        true,
 	"PATH":                      true,
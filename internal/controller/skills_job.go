@@ -0,0 +1,143 @@
+/*
+Copyright 2026 OpenClaw.rocks
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
+	"github.com/openclawrocks/openclaw-operator/internal/resources"
+)
+
+// reconcileSkillsJob runs the skills install Job when spec.skillsInstallMode
+// is "job". It must run after the PVC exists and before the StatefulSet.
+// Returns (result, done, error):
+//   - done=true: skills are installed, the Job failed (reported through the
+//     SkillsInstalled condition, see holdSkillsHash), or job mode is off;
+//     continue to the StatefulSet
+//   - done=false: the Job is running or was (re)created; requeue with result
+func (r *OpenClawInstanceReconciler) reconcileSkillsJob(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) (result ctrl.Result, done bool, _ error) {
+	logger := log.FromContext(ctx)
+
+	if !resources.IsSkillsJobMode(instance) {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, openclawv1alpha1.ConditionTypeSkillsInstalled)
+		return ctrl.Result{}, true, nil
+	}
+	desired := resources.BuildSkillsJob(instance)
+	if desired == nil {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, openclawv1alpha1.ConditionTypeSkillsInstalled)
+		return ctrl.Result{}, true, nil
+	}
+	hashKey := resources.AnnotationKey("skills-hash")
+
+	existing, err := r.getJob(ctx, desired.Name, desired.Namespace)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, false, err
+	}
+
+	if apierrors.IsNotFound(err) || existing == nil {
+		if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+			return ctrl.Result{}, false, err
+		}
+		logger.Info("Creating skills install Job", "job", desired.Name)
+		if err := r.Create(ctx, desired); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, false, nil
+			}
+			return ctrl.Result{}, false, err
+		}
+		r.Recorder.Event(instance, corev1.EventTypeNormal, "SkillsInstallStarted",
+			fmt.Sprintf("Skills install Job %s created", desired.Name))
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, false, nil
+	}
+
+	// Job pod templates are immutable - replace the Job when the skills change
+	if existing.Annotations[hashKey] != desired.Annotations[hashKey] {
+		logger.Info("Skills changed, replacing install Job", "job", existing.Name)
+		if err := r.Delete(ctx, existing, client.PropagationPolicy("Background")); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, false, err
+		}
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, false, nil
+	}
+
+	finished, condType := isJobFinished(existing)
+	if !finished {
+		logger.V(1).Info("Skills install Job still running", "job", existing.Name)
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, false, nil
+	}
+
+	// A failed install must not block the rest of the reconcile: report it
+	// and carry on. The Job is owned by the instance, so deleting it to retry
+	// triggers a new reconcile.
+	if condType == batchv1.JobFailed {
+		if !meta.IsStatusConditionFalse(instance.Status.Conditions, openclawv1alpha1.ConditionTypeSkillsInstalled) {
+			logger.Error(nil, "Skills install Job failed", "job", existing.Name)
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "SkillsInstallFailed",
+				fmt.Sprintf("Skills install Job %s failed. Delete the Job to retry.", existing.Name))
+		}
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:               openclawv1alpha1.ConditionTypeSkillsInstalled,
+			Status:             metav1.ConditionFalse,
+			Reason:             "JobFailed",
+			Message:            fmt.Sprintf("Skills install Job %s failed. Delete the Job to retry.", existing.Name),
+			ObservedGeneration: instance.Generation,
+		})
+		return ctrl.Result{}, true, nil
+	}
+
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               openclawv1alpha1.ConditionTypeSkillsInstalled,
+		Status:             metav1.ConditionTrue,
+		Reason:             "JobComplete",
+		Message:            fmt.Sprintf("Skills install Job %s completed", existing.Name),
+		ObservedGeneration: instance.Generation,
+	})
+	return ctrl.Result{}, true, nil
+}
+
+// holdSkillsHash keeps the skills-hash annotation the StatefulSet pod template
+// currently carries while the skills install Job has failed, so the pods don't
+// roll onto skills that were never installed. The failure stays reported in
+// the SkillsInstalled condition; the new hash is applied once a Job succeeds.
+func holdSkillsHash(instance *openclawv1alpha1.OpenClawInstance, existing map[string]string, template *corev1.PodTemplateSpec) {
+	cond := meta.FindStatusCondition(instance.Status.Conditions, openclawv1alpha1.ConditionTypeSkillsInstalled)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "JobFailed" {
+		return
+	}
+	hashKey := resources.AnnotationKey("skills-hash")
+	prev, ok := existing[hashKey]
+	if !ok {
+		delete(template.Annotations, hashKey)
+		return
+	}
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+	template.Annotations[hashKey] = prev
+}
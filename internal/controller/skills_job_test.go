@@ -0,0 +1,104 @@
+/*
+Copyright 2026 OpenClaw.rocks
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
+	"github.com/openclawrocks/openclaw-operator/internal/resources"
+)
+
+func TestReconcileSkillsJob_FailedJobHoldsSkillsHash(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := openclawv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	instance := &openclawv1alpha1.OpenClawInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "skills-failed", Namespace: "default"},
+	}
+	instance.Spec.SkillsInstallMode = resources.SkillsInstallModeJob
+	instance.Spec.Skills = []string{"new-skill"}
+
+	job := resources.BuildSkillsJob(instance)
+	job.Status.Conditions = []batchv1.JobCondition{
+		{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+	}
+	r := &OpenClawInstanceReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(s).WithObjects(job).Build(),
+		Scheme:   s,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	_, done, err := r.reconcileSkillsJob(context.Background(), instance)
+	if err != nil {
+		t.Fatalf("reconcileSkillsJob: %v", err)
+	}
+	if !done {
+		t.Fatal("a failed Job must not block the rest of the reconcile")
+	}
+	cond := meta.FindStatusCondition(instance.Status.Conditions, openclawv1alpha1.ConditionTypeSkillsInstalled)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "JobFailed" {
+		t.Fatalf("expected SkillsInstalled False/JobFailed, got %+v", cond)
+	}
+
+	hashKey := resources.AnnotationKey("skills-hash")
+	desired := resources.BuildStatefulSet(instance, "", nil, nil, nil)
+	if desired.Spec.Template.Annotations[hashKey] == "" {
+		t.Fatal("desired pod template should carry the new skills-hash")
+	}
+
+	// The StatefulSet keeps the skills-hash it was rolled out with
+	template := desired.Spec.Template.DeepCopy()
+	holdSkillsHash(instance, map[string]string{hashKey: "previous"}, template)
+	if got := template.Annotations[hashKey]; got != "previous" {
+		t.Errorf("skills-hash = %q, want the previous hash while the Job is failed", got)
+	}
+
+	// Without a previous hash the new one must not be introduced either
+	template = desired.Spec.Template.DeepCopy()
+	holdSkillsHash(instance, nil, template)
+	if _, ok := template.Annotations[hashKey]; ok {
+		t.Error("skills-hash must not be added while the Job is failed")
+	}
+
+	// Once the Job succeeds the new hash is applied
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:   openclawv1alpha1.ConditionTypeSkillsInstalled,
+		Status: metav1.ConditionTrue,
+		Reason: "JobComplete",
+	})
+	template = desired.Spec.Template.DeepCopy()
+	holdSkillsHash(instance, map[string]string{hashKey: "previous"}, template)
+	if got, want := template.Annotations[hashKey], desired.Spec.Template.Annotations[hashKey]; got != want {
+		t.Errorf("skills-hash = %q, want the new hash %q after success", got, want)
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 OpenClaw.rocks
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
+	"github.com/openclawrocks/openclaw-operator/internal/resources"
+)
+
+// reconcileWorkloadJob runs the instance as a one-off Job when spec.mode is
+// "job". Job pod templates are immutable, so the Job is replaced when its
+// config hash changes; spec.suspended maps onto the Job's suspend field.
+func (r *OpenClawInstanceReconciler) reconcileWorkloadJob(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance, gatewayToken string, skillPacks *resources.ResolvedSkillPacks, wsFiles *resolvedWorkspaceFiles) error {
+	logger := log.FromContext(ctx)
+
+	if err := resources.ValidatePorts(instance); err != nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    openclawv1alpha1.ConditionTypeJobComplete,
+			Status:  metav1.ConditionFalse,
+			Reason:  "PortConflict",
+			Message: err.Error(),
+		})
+		return err
+	}
+
+	desired := resources.BuildJob(instance, r.podGatewayTokenSecretName(ctx, instance, gatewayToken),
+		skillPacks, wsFiles.defaultFiles, wsFiles.additionalFiles)
+	hashKey := resources.AnnotationKey("config-hash")
+
+	existing, err := r.getJob(ctx, desired.Name, desired.Namespace)
+	if apierrors.IsNotFound(err) {
+		if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+			return err
+		}
+		logger.Info("Creating workload Job", "job", desired.Name)
+		if err := r.Create(ctx, desired); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		r.Recorder.Event(instance, corev1.EventTypeNormal, "JobCreated",
+			fmt.Sprintf("Workload Job %s created", desired.Name))
+		instance.Status.ManagedResources.Job = desired.Name
+		setJobCompleteCondition(instance, metav1.ConditionFalse, "JobRunning", "Workload Job is running")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	instance.Status.ManagedResources.Job = existing.Name
+
+	if existing.Annotations[hashKey] != desired.Annotations[hashKey] {
+		logger.Info("Spec changed, replacing workload Job", "job", existing.Name)
+		if err := r.Delete(ctx, existing, client.PropagationPolicy("Background")); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		setJobCompleteCondition(instance, metav1.ConditionFalse, "JobReplacing", "Workload Job is being replaced after a spec change")
+		return nil
+	}
+
+	if existing.Spec.Suspend == nil || *existing.Spec.Suspend != instance.Spec.Suspended {
+		patch := client.MergeFrom(existing.DeepCopy())
+		existing.Spec.Suspend = desired.Spec.Suspend
+		if err := r.Patch(ctx, existing, patch); err != nil {
+			return fmt.Errorf("updating Job suspend: %w", err)
+		}
+	}
+
+	switch finished, condType := isJobFinished(existing); {
+	case finished && condType == batchv1.JobComplete:
+		setJobCompleteCondition(instance, metav1.ConditionTrue, "JobComplete", "Workload Job completed")
+	case finished:
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "JobFailed",
+			fmt.Sprintf("Workload Job %s failed. Delete the Job to retry.", existing.Name))
+		setJobCompleteCondition(instance, metav1.ConditionFalse, "JobFailed", "Workload Job failed")
+	case instance.Spec.Suspended:
+		setJobCompleteCondition(instance, metav1.ConditionFalse, "JobSuspended", "Workload Job is suspended")
+	default:
+		setJobCompleteCondition(instance, metav1.ConditionFalse, "JobRunning", "Workload Job is running")
+	}
+
+	readyVal := float64(0)
+	if existing.Status.Ready != nil && *existing.Status.Ready > 0 && !instance.Spec.Suspended {
+		readyVal = 1
+	}
+	instanceReady.WithLabelValues(instance.Name, instance.Namespace).Set(readyVal)
+
+	return nil
+}
+
+// reconcileWorkloadCronJob runs the instance on spec.schedule via a CronJob.
+func (r *OpenClawInstanceReconciler) reconcileWorkloadCronJob(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance, gatewayToken string, skillPacks *resources.ResolvedSkillPacks, wsFiles *resolvedWorkspaceFiles) error {
+	if err := resources.ValidatePorts(instance); err != nil {
+		return err
+	}
+
+	desired := resources.BuildCronJob(instance, r.podGatewayTokenSecretName(ctx, instance, gatewayToken),
+		skillPacks, wsFiles.defaultFiles, wsFiles.additionalFiles)
+	obj := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      desired.Name,
+			Namespace: desired.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+		obj.Labels = mergeStringMap(obj.Labels, desired.Labels)
+		obj.Spec = desired.Spec
+		return controllerutil.SetControllerReference(instance, obj, r.Scheme)
+	}); err != nil {
+		return err
+	}
+	instance.Status.ManagedResources.CronJob = obj.Name
+	return nil
+}
+
+// deleteStaleWorkloads removes the workloads of other modes after the
+// instance switches between statefulset mode, job mode and a schedule.
+// Only objects controlled by the instance are deleted.
+func (r *OpenClawInstanceReconciler) deleteStaleWorkloads(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance) error {
+	scheduled := resources.IsScheduled(instance)
+	jobMode := resources.IsJobMode(instance)
+
+	if scheduled || jobMode {
+		sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: resources.StatefulSetName(instance), Namespace: instance.Namespace}}
+		if err := r.deleteOwnedWorkload(ctx, instance, sts); err != nil {
+			return err
+		}
+		instance.Status.ManagedResources.StatefulSet = ""
+		meta.RemoveStatusCondition(&instance.Status.Conditions, openclawv1alpha1.ConditionTypeStatefulSetReady)
+	}
+	if !jobMode {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: resources.JobName(instance), Namespace: instance.Namespace}}
+		if err := r.deleteOwnedWorkload(ctx, instance, job); err != nil {
+			return err
+		}
+		instance.Status.ManagedResources.Job = ""
+		meta.RemoveStatusCondition(&instance.Status.Conditions, openclawv1alpha1.ConditionTypeJobComplete)
+	}
+	if !scheduled {
+		cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: resources.CronJobName(instance), Namespace: instance.Namespace}}
+		if err := r.deleteOwnedWorkload(ctx, instance, cronJob); err != nil {
+			return err
+		}
+		instance.Status.ManagedResources.CronJob = ""
+	}
+	return nil
+}
+
+// deleteOwnedWorkload deletes obj if it exists and is controlled by the instance.
+func (r *OpenClawInstanceReconciler) deleteOwnedWorkload(ctx context.Context, instance *openclawv1alpha1.OpenClawInstance, obj client.Object) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(obj, instance) {
+		return nil
+	}
+	log.FromContext(ctx).Info("Workload mode changed, deleting stale workload",
+		"kind", fmt.Sprintf("%T", obj), "name", obj.GetName())
+	if err := r.Delete(ctx, obj, client.PropagationPolicy("Background")); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func setJobCompleteCondition(instance *openclawv1alpha1.OpenClawInstance, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    openclawv1alpha1.ConditionTypeJobComplete,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
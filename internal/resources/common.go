@@ -17,9 +17,13 @@ limitations under the License.
 package resources
 
 import (
+	"fmt"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
@@ -27,6 +31,106 @@ import (
 
 var rLog = ctrl.Log.WithName("resources")
 
+// AnnotationPrefix is the domain prefix for annotations the operator writes
+// (e.g. "<prefix>/config-hash"). Override it with SetAnnotationPrefix to avoid
+// collisions with a forked operator running in the same cluster.
+var AnnotationPrefix = "openclaw.rocks"
+
+// SetAnnotationPrefix overrides AnnotationPrefix. Empty values and trailing
+// slashes are ignored so callers can pass flag values through unchanged. The
+// prefix must be a DNS subdomain, otherwise the API server rejects every
+// annotation written with it.
+func SetAnnotationPrefix(prefix string) error {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(prefix); len(errs) > 0 {
+		return fmt.Errorf("annotation prefix %q must be a DNS subdomain: %s", prefix, strings.Join(errs, "; "))
+	}
+	AnnotationPrefix = prefix
+	return nil
+}
+
+// defaultManagedBy is the baseline app.kubernetes.io/managed-by value. It is
+// also pinned on immutable fields such as VolumeClaimTemplate labels.
+const defaultManagedBy = "openclaw-operator"
+
+// ManagedByValue is the value of the app.kubernetes.io/managed-by label set on
+// every operator-generated object. Override it with SetManagedBy when the
+// operator is embedded in a larger platform.
+var ManagedByValue = defaultManagedBy
+
+// SetManagedBy overrides ManagedByValue. Empty values are ignored so callers
+// can pass flag values through unchanged.
+func SetManagedBy(value string) {
+	if value != "" {
+		ManagedByValue = value
+	}
+}
+
+// PrePullAnnotation is the pod template annotation that lists the instance's
+// images when spec.image.prePull is set. Empty means "<AnnotationPrefix>/prepull-images".
+// Override it with SetPrePullAnnotation to match the key an image pre-puller
+// watches.
+var PrePullAnnotation = ""
+
+// SetPrePullAnnotation overrides PrePullAnnotation. Empty values are ignored
+// so callers can pass flag values through unchanged.
+func SetPrePullAnnotation(key string) {
+	if key != "" {
+		PrePullAnnotation = key
+	}
+}
+
+// PrePullAnnotationKey returns the annotation key used for the image list.
+func PrePullAnnotationKey() string {
+	if PrePullAnnotation != "" {
+		return PrePullAnnotation
+	}
+	return AnnotationKey("prepull-images")
+}
+
+// DefaultOpenClawImageTag is the main image tag used when spec.image.tag is
+// empty. Override it with SetDefaultOpenClawImageTag to pin a known-good
+// release instead of the mutable DefaultImageTag. Sidecar images keep their
+// own defaults.
+var DefaultOpenClawImageTag = DefaultImageTag
+
+// SetDefaultOpenClawImageTag overrides DefaultOpenClawImageTag. Empty values
+// are ignored so callers can pass flag values through unchanged.
+func SetDefaultOpenClawImageTag(tag string) {
+	if tag != "" {
+		DefaultOpenClawImageTag = tag
+	}
+}
+
+// RestartAtAnnotationKey returns the instance annotation that forces a
+// rollout when its value changes (e.g. "openclaw.rocks/restart-at").
+func RestartAtAnnotationKey() string {
+	return AnnotationKey("restart-at")
+}
+
+// AnnotationKey returns the fully qualified annotation key for name under
+// AnnotationPrefix.
+func AnnotationKey(name string) string {
+	return AnnotationPrefix + "/" + name
+}
+
+// ApplyTrackingAnnotations records which operator version last applied obj
+// and the instance config hash it was built from, as
+// "<prefix>/operator-version" and "<prefix>/last-applied-hash". Existing
+// annotations on obj are kept.
+func ApplyTrackingAnnotations(obj metav1.Object, instance *openclawv1alpha1.OpenClawInstance, operatorVersion string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 2)
+	}
+	annotations[AnnotationKey("operator-version")] = operatorVersion
+	annotations[AnnotationKey("last-applied-hash")] = calculateConfigHash(instance, nil, nil)
+	obj.SetAnnotations(annotations)
+}
+
 const (
 	// GatewayPort is the port for the OpenClaw gateway WebSocket server
 	GatewayPort = 18789
@@ -46,15 +150,42 @@ const (
 	// DefaultGatewayProxyImage is the default image for the gateway proxy sidecar
 	DefaultGatewayProxyImage = "nginx:1.27-alpine"
 
+	// DefaultGatewayProxyDrainSeconds is the default preStop sleep for the
+	// gateway-proxy sidecar.
+	DefaultGatewayProxyDrainSeconds int32 = 5
+
+	// DefaultConfigFileName is the config file name used when
+	// spec.config.fileName is unset.
+	DefaultConfigFileName = "openclaw.json"
+
+	// DefaultContainerName is the main container name used when
+	// spec.containerName is unset.
+	DefaultContainerName = "openclaw"
+
 	// NginxConfigKey is the ConfigMap data key for the nginx stream config
 	NginxConfigKey = "nginx.conf"
 
+	// EntrypointScriptKey is the ConfigMap data key for spec.entrypointScript
+	EntrypointScriptKey = "entrypoint.sh"
+
+	// EntrypointScriptMountPath is the directory spec.entrypointScript is
+	// mounted into in the main container
+	EntrypointScriptMountPath = "/operator-entrypoint"
+
 	// ChromiumPort is the CDP port that Chromium listens on.
 	// The image entrypoint (run.sh) starts Chrome with
 	// --remote-debugging-port=9222 so all CDP clients (OpenClaw,
 	// health probes) connect here.
 	ChromiumPort = 9222
 
+	// DefaultChromiumCDPEnvName is the main container env var that holds the
+	// Chromium CDP URL when spec.chromium.cdpEnvName is unset.
+	DefaultChromiumCDPEnvName = "OPENCLAW_CHROMIUM_CDP"
+
+	// DefaultBrowserProfileColor is the color of the operator-configured
+	// browser profiles (OpenClaw's config validation requires one).
+	DefaultBrowserProfileColor = "#4285F4"
+
 	// DefaultChromiumImage is the default image for the Chromium sidecar.
 	// chromedp/headless-shell is a minimal (~133 MB) image purpose-built
 	// for CDP automation with daily automated builds tracking Chrome stable.
@@ -73,15 +204,37 @@ const (
 	// "stable" tracks the latest Chrome stable channel release.
 	DefaultChromiumTag = "stable"
 
+	// ChromiumUID is the user the Chromium sidecar runs as (nobody)
+	ChromiumUID = 65534
+
 	// OllamaPort is the port for the Ollama API
 	OllamaPort = 11434
 
+	// DefaultGPUResourceName is the extended resource requested for Ollama GPUs
+	// unless spec.ollama.gpuResourceName overrides it.
+	DefaultGPUResourceName corev1.ResourceName = "nvidia.com/gpu"
+
+	// DefaultGPUNodeLabel is the node label required by spec.ollama.requireGPUNode
+	// unless spec.ollama.gpuNodeLabel overrides it.
+	DefaultGPUNodeLabel = "nvidia.com/gpu.present"
+
 	// WebTerminalPort is the port for the ttyd web terminal
 	WebTerminalPort = 7681
 
-	// ConfigMergeModeMerge is the merge mode that deep-merges config with existing PVC config
+	// ConfigMergeModeMerge is the merge mode that merges config with existing PVC config
+	// (deep or shallow per spec.config.mergeStrategy)
 	ConfigMergeModeMerge = "merge"
 
+	// ConfigMergeStrategyShallow merges only top-level keys in merge mode
+	ConfigMergeStrategyShallow = "shallow"
+
+	// SkillsInstallModeJob installs skills via a Job instead of an init container
+	SkillsInstallModeJob = "job"
+
+	// WorkloadModeJob runs the instance pod once as a batch/v1 Job instead of
+	// a StatefulSet
+	WorkloadModeJob = "job"
+
 	// ConfigFormatJSON5 is the config format that accepts JSON5 (comments, trailing commas)
 	ConfigFormatJSON5 = "json5"
 
@@ -104,9 +257,17 @@ const (
 	// ComponentLabel is the component label key
 	ComponentLabel = "app.kubernetes.io/component"
 
+	// InstanceUIDLabel carries the UID of the owning instance, so objects left
+	// over from a deleted instance can be told apart from those of a new
+	// instance with the same name. Never part of a selector.
+	InstanceUIDLabel = "app.kubernetes.io/instance-uid"
+
 	// GatewayTokenSecretKey is the data key used in the gateway token Secret
 	GatewayTokenSecretKey = "token"
 
+	// GatewayAuthModeBasic is the spec.gateway.authMode value for HTTP basic auth
+	GatewayAuthModeBasic = "basic"
+
 	// DefaultTailscaleAuthKeySecretKey is the default key in the Tailscale auth key Secret
 	DefaultTailscaleAuthKeySecretKey = "authkey"
 
@@ -121,12 +282,24 @@ const (
 	// directory, avoiding a chmod failure on a kubelet-owned mount point.
 	TailscaleStatePath = "/tmp/tailscale"
 
+	// ServiceAccountTokenMountPath is the well-known path where Kubernetes
+	// clients look for the service account token, CA and namespace.
+	ServiceAccountTokenMountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+	// ServiceAccountTokenExpirationSeconds is the requested lifetime of the
+	// projected self-configure token. The kubelet refreshes it before expiry.
+	ServiceAccountTokenExpirationSeconds = 3600
+
 	// TailscaleSocketDir is the directory containing the tailscaled Unix socket
 	TailscaleSocketDir = "/var/run/tailscale"
 
 	// TailscaleSocketPath is the full path to the tailscaled Unix socket
 	TailscaleSocketPath = "/var/run/tailscale/tailscaled.sock"
 
+	// TailscaleTunDevicePath is the host TUN device mounted into the sidecar
+	// when tailscaled uses kernel networking
+	TailscaleTunDevicePath = "/dev/net/tun"
+
 	// TailscaleBinPath is the shared volume path where the tailscale CLI binary is copied
 	TailscaleBinPath = "/tailscale-bin"
 
@@ -136,6 +309,13 @@ const (
 	// TailscaleModeFunnel exposes the instance to the public internet via Tailscale Funnel
 	TailscaleModeFunnel = "funnel"
 
+	// DefaultTailscaleServePort is the tailnet HTTPS port Tailscale serve listens on
+	DefaultTailscaleServePort int32 = 443
+
+	// TailscaleCanvasServePort is the tailnet HTTPS port the canvas is served
+	// on when spec.tailscale.serveCanvas is enabled
+	TailscaleCanvasServePort int32 = 8443
+
 	// GatewayBindLoopback is the bind value for loopback mode. The gateway
 	// proxy sidecar handles external access; binding to loopback prevents
 	// CWE-319 plaintext ws:// errors on non-loopback addresses.
@@ -186,10 +366,16 @@ const (
 //
 // Only used for the default chromedp/headless-shell image; custom images
 // keep their own entrypoint.
-var ChromiumEntrypointCommand = []string{
-	"/bin/bash", "-c",
-	"exec socat TCP4-LISTEN:9222,fork TCP4:127.0.0.1:9223 &\nexec /headless-shell/headless-shell --no-sandbox --use-gl=angle --use-angle=swiftshader --remote-debugging-address=0.0.0.0 --remote-debugging-port=9223 \"$@\"",
-	"--",
+var ChromiumEntrypointCommand = chromiumEntrypointCommand(ChromiumPort)
+
+// chromiumEntrypointCommand returns ChromiumEntrypointCommand for a sidecar
+// serving CDP on port (Chrome itself listens on port+1).
+func chromiumEntrypointCommand(port int32) []string {
+	return []string{
+		"/bin/bash", "-c",
+		fmt.Sprintf("exec socat TCP4-LISTEN:%d,fork TCP4:127.0.0.1:%d &\nexec /headless-shell/headless-shell --no-sandbox --use-gl=angle --use-angle=swiftshader --remote-debugging-address=0.0.0.0 --remote-debugging-port=%d \"$@\"", port, port+1, port+1),
+		"--",
+	}
 }
 
 // DefaultChromiumLaunchArgs are additional Chrome flags passed as container
@@ -252,16 +438,23 @@ func ChromiumArgs(instance *openclawv1alpha1.OpenClawInstance) []string {
 	return args
 }
 
-// Labels returns the standard labels for an OpenClawInstance
+// Labels returns the standard labels for an OpenClawInstance, plus the
+// instance UID label once the instance has a UID.
 func Labels(instance *openclawv1alpha1.OpenClawInstance) map[string]string {
-	return map[string]string{
+	labels := map[string]string{
 		"app.kubernetes.io/name":       AppName,
 		"app.kubernetes.io/instance":   instance.Name,
-		"app.kubernetes.io/managed-by": "openclaw-operator",
+		"app.kubernetes.io/managed-by": ManagedByValue,
 	}
+	if instance.UID != "" {
+		labels[InstanceUIDLabel] = string(instance.UID)
+	}
+	return labels
 }
 
-// SelectorLabels returns the labels used for selecting pods
+// SelectorLabels returns the labels used for selecting pods. They never
+// include InstanceUIDLabel so selectors stay stable across instance
+// recreation.
 func SelectorLabels(instance *openclawv1alpha1.OpenClawInstance) map[string]string {
 	return map[string]string{
 		"app.kubernetes.io/name":     AppName,
@@ -269,19 +462,35 @@ func SelectorLabels(instance *openclawv1alpha1.OpenClawInstance) map[string]stri
 	}
 }
 
+// resourceName builds the name of an operator-generated object from the
+// instance name plus an optional base suffix (e.g. "-config"), wrapped in
+// spec.namePrefix and spec.nameSuffix.
+func resourceName(instance *openclawv1alpha1.OpenClawInstance, base string) string {
+	return instance.Spec.NamePrefix + instance.Name + base + instance.Spec.NameSuffix
+}
+
 // StatefulSetName returns the name of the StatefulSet
 func StatefulSetName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name
+	return resourceName(instance, "")
 }
 
-// DeploymentName returns the name of the legacy Deployment (used during migration)
+// DeploymentName returns the name of the legacy Deployment (used during migration).
+// Not affected by namePrefix/nameSuffix since it refers to a pre-existing object.
 func DeploymentName(instance *openclawv1alpha1.OpenClawInstance) string {
 	return instance.Name
 }
 
 // ServiceName returns the name of the Service
 func ServiceName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name
+	return resourceName(instance, "")
+}
+
+// MainContainerName returns the name of the main application container
+func MainContainerName(instance *openclawv1alpha1.OpenClawInstance) string {
+	if instance.Spec.ContainerName != "" {
+		return instance.Spec.ContainerName
+	}
+	return DefaultContainerName
 }
 
 // ChromiumCDPServiceName returns the name of the headless Service used for
@@ -289,7 +498,7 @@ func ServiceName(instance *openclawv1alpha1.OpenClawInstance) string {
 // publishNotReadyAddresses is needed so the CDP URL resolves before the pod
 // is fully Ready (the main container may still be starting).
 func ChromiumCDPServiceName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name + "-cdp"
+	return resourceName(instance, "-cdp")
 }
 
 // ServiceAccountName returns the name of the ServiceAccount
@@ -297,32 +506,32 @@ func ServiceAccountName(instance *openclawv1alpha1.OpenClawInstance) string {
 	if instance.Spec.Security.RBAC.ServiceAccountName != "" {
 		return instance.Spec.Security.RBAC.ServiceAccountName
 	}
-	return instance.Name
+	return resourceName(instance, "")
 }
 
 // RoleName returns the name of the Role
 func RoleName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name
+	return resourceName(instance, "")
 }
 
 // RoleBindingName returns the name of the RoleBinding
 func RoleBindingName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name
+	return resourceName(instance, "")
 }
 
 // ConfigMapName returns the name of the ConfigMap
 func ConfigMapName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name + "-config"
+	return resourceName(instance, "-config")
 }
 
 // WorkspaceConfigMapName returns the name of the workspace ConfigMap
 func WorkspaceConfigMapName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name + "-workspace"
+	return resourceName(instance, "-workspace")
 }
 
 // PVCName returns the name of the PVC
 func PVCName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name + "-data"
+	return resourceName(instance, "-data")
 }
 
 // IsPersistenceEnabled returns true if persistent storage is enabled for the instance.
@@ -333,37 +542,96 @@ func IsPersistenceEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
 
 // ChromiumPVCName returns the name of the Chromium browser profile PVC
 func ChromiumPVCName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name + "-chromium-data"
+	return resourceName(instance, "-chromium-data")
+}
+
+// LogsPVCName returns the name of the logs PVC
+func LogsPVCName(instance *openclawv1alpha1.OpenClawInstance) string {
+	return resourceName(instance, "-logs")
+}
+
+// IsLogsPVCEnabled returns true if the logs volume is backed by a managed PVC
+// (spec.storage.logs enabled with a size).
+func IsLogsPVCEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Storage.Logs.Enabled && instance.Spec.Storage.Logs.Size != ""
+}
+
+// LogsMountPath returns the logs volume mount path, defaulting to /var/log/openclaw.
+func LogsMountPath(instance *openclawv1alpha1.OpenClawInstance) string {
+	if instance.Spec.Storage.Logs.MountPath != "" {
+		return instance.Spec.Storage.Logs.MountPath
+	}
+	return "/var/log/openclaw"
 }
 
 // NetworkPolicyName returns the name of the NetworkPolicy
 func NetworkPolicyName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name
+	return resourceName(instance, "")
 }
 
 // PDBName returns the name of the PodDisruptionBudget
 func PDBName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name
+	return resourceName(instance, "")
+}
+
+// LimitRangeName returns the name of the namespace LimitRange
+func LimitRangeName(instance *openclawv1alpha1.OpenClawInstance) string {
+	return resourceName(instance, "")
+}
+
+// ResourceQuotaName returns the name of the namespace ResourceQuota
+func ResourceQuotaName(instance *openclawv1alpha1.OpenClawInstance) string {
+	return resourceName(instance, "")
 }
 
 // IngressName returns the name of the Ingress
 func IngressName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name
+	return resourceName(instance, "")
+}
+
+// MetricsIngressName returns the name of the metrics Ingress
+func MetricsIngressName(instance *openclawv1alpha1.OpenClawInstance) string {
+	return resourceName(instance, "-metrics")
+}
+
+// MetricsServiceName returns the name of the separate metrics Service
+func MetricsServiceName(instance *openclawv1alpha1.OpenClawInstance) string {
+	return resourceName(instance, "-metrics")
 }
 
 // GatewayTokenSecretName returns the name of the auto-generated gateway token Secret
 func GatewayTokenSecretName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name + "-gateway-token"
+	return resourceName(instance, "-gateway-token")
+}
+
+// GatewayTokenKey returns the Secret data key that holds the gateway token:
+// spec.gateway.existingSecretKey for a user-managed Secret, otherwise
+// GatewayTokenSecretKey.
+func GatewayTokenKey(instance *openclawv1alpha1.OpenClawInstance) string {
+	if instance.Spec.Gateway.ExistingSecret != "" && instance.Spec.Gateway.ExistingSecretKey != "" {
+		return instance.Spec.Gateway.ExistingSecretKey
+	}
+	return GatewayTokenSecretKey
 }
 
 // BasicAuthSecretName returns the name of the auto-generated Ingress Basic Auth Secret
 func BasicAuthSecretName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name + "-basic-auth"
+	return resourceName(instance, "-basic-auth")
+}
+
+// SkillsJobName returns the name of the skills install Job
+func SkillsJobName(instance *openclawv1alpha1.OpenClawInstance) string {
+	return resourceName(instance, "-skills")
+}
+
+// TraefikBasicAuthMiddlewareName returns the name of the Traefik BasicAuth Middleware
+func TraefikBasicAuthMiddlewareName(instance *openclawv1alpha1.OpenClawInstance) string {
+	return resourceName(instance, "-basic-auth")
 }
 
 // TailscaleStateSecretName returns the name of the Tailscale state Secret
 func TailscaleStateSecretName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name + "-ts-state"
+	return resourceName(instance, "-ts-state")
 }
 
 // GetImageRepository returns the image repository with defaults
@@ -379,7 +647,7 @@ func GetImageTag(instance *openclawv1alpha1.OpenClawInstance) string {
 	if instance.Spec.Image.Tag != "" {
 		return instance.Spec.Image.Tag
 	}
-	return DefaultImageTag
+	return DefaultOpenClawImageTag
 }
 
 // GetImage returns the full image reference
@@ -389,11 +657,20 @@ func GetImage(instance *openclawv1alpha1.OpenClawInstance) string {
 	if instance.Spec.Image.Digest != "" {
 		image = repo + "@" + instance.Spec.Image.Digest
 	} else {
-		image = repo + ":" + GetImageTag(instance)
+		image = repo + ":" + WithTagVariant(GetImageTag(instance), instance.Spec.Image.Variant)
 	}
 	return ApplyRegistryOverride(image, instance.Spec.Registry)
 }
 
+// WithTagVariant appends an architecture/variant suffix to an image tag
+// ("latest" + "arm64" -> "latest-arm64"). An empty variant returns the tag as-is.
+func WithTagVariant(tag, variant string) string {
+	if variant == "" {
+		return tag
+	}
+	return tag + "-" + variant
+}
+
 // GetTailscaleImage returns the full Tailscale sidecar image reference
 func GetTailscaleImage(instance *openclawv1alpha1.OpenClawInstance) string {
 	repo := instance.Spec.Tailscale.Image.Repository
@@ -409,7 +686,7 @@ func GetTailscaleImage(instance *openclawv1alpha1.OpenClawInstance) string {
 		if tag == "" {
 			tag = DefaultImageTag
 		}
-		image = repo + ":" + tag
+		image = repo + ":" + WithTagVariant(tag, instance.Spec.Tailscale.Image.Variant)
 	}
 	return ApplyRegistryOverride(image, instance.Spec.Registry)
 }
@@ -420,11 +697,104 @@ func IsGatewayProxyEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
 	return instance.Spec.Gateway.Enabled == nil || *instance.Spec.Gateway.Enabled
 }
 
+// IsOllamaSidecarEnabled returns true if the Ollama sidecar should be
+// injected: Ollama is enabled and not pointed at an external server.
+func IsOllamaSidecarEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Ollama.Enabled && !IsOllamaExternal(instance)
+}
+
+// IsOllamaExternal returns true if Ollama is enabled as an external endpoint
+// (spec.ollama.external) rather than a sidecar.
+func IsOllamaExternal(instance *openclawv1alpha1.OpenClawInstance) bool {
+	ollama := instance.Spec.Ollama
+	return ollama.Enabled && ollama.External != nil && *ollama.External
+}
+
+// ChromiumCDPEnvName returns the name of the env var that carries the
+// Chromium CDP URL into the main container.
+func ChromiumCDPEnvName(instance *openclawv1alpha1.OpenClawInstance) string {
+	if instance.Spec.Chromium.CDPEnvName != "" {
+		return instance.Spec.Chromium.CDPEnvName
+	}
+	return DefaultChromiumCDPEnvName
+}
+
+// ChromiumReplicas returns the number of Chromium sidecars (spec.chromium.replicas,
+// default 1).
+func ChromiumReplicas(instance *openclawv1alpha1.OpenClawInstance) int {
+	if r := instance.Spec.Chromium.Replicas; r != nil && *r > 1 {
+		return int(*r)
+	}
+	return 1
+}
+
+// UsesDefaultChromiumImage returns true if the Chromium sidecar runs the
+// default image (including the migrated deprecated one). Only then does the
+// operator control the entrypoint and with it the per-replica CDP port.
+func UsesDefaultChromiumImage(instance *openclawv1alpha1.OpenClawInstance) bool {
+	switch instance.Spec.Chromium.Image.Repository {
+	case "", DefaultChromiumImage, DeprecatedChromiumImage:
+		return true
+	}
+	return false
+}
+
+// ChromiumReplicaPort returns the CDP port of Chromium sidecar i. Ports step
+// by two because the default entrypoint's socat bridge forwards each CDP
+// port to Chrome on the next port up (9222 -> 9223).
+func ChromiumReplicaPort(i int) int32 {
+	return int32(ChromiumPort + 2*i)
+}
+
+// ChromiumContainerName returns the name of Chromium sidecar i: "chromium"
+// for a single sidecar, chromium-0..chromium-N-1 otherwise.
+func ChromiumContainerName(instance *openclawv1alpha1.OpenClawInstance, i int) string {
+	if ChromiumReplicas(instance) == 1 {
+		return "chromium"
+	}
+	return fmt.Sprintf("chromium-%d", i)
+}
+
+// OperatorSidecarNames returns the container names of the sidecars the
+// operator manages, whether or not they are enabled. Chromium sidecars are
+// also listed by replica name when spec.chromium.replicas > 1.
+func OperatorSidecarNames(instance *openclawv1alpha1.OpenClawInstance) []string {
+	names := []string{"chromium", "ollama", "gateway-proxy", "tailscale", "otel-collector", "web-terminal"}
+	if n := ChromiumReplicas(instance); n > 1 {
+		for i := range n {
+			names = append(names, ChromiumContainerName(instance, i))
+		}
+	}
+	return names
+}
+
 // IsMetricsEnabled returns true if the metrics endpoint is enabled for the instance
 func IsMetricsEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
 	return instance.Spec.Observability.Metrics.Enabled == nil || *instance.Spec.Observability.Metrics.Enabled
 }
 
+// IsMetricsIngressEnabled returns true if /metrics is exposed through its own
+// Ingress. It requires the metrics endpoint itself to be enabled.
+func IsMetricsIngressEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	ing := instance.Spec.Observability.Metrics.Ingress
+	return IsMetricsEnabled(instance) && ing != nil && ing.Enabled
+}
+
+// IsMetricsServiceEnabled returns true if the separate metrics Service should
+// be created. It requires the metrics endpoint itself to be enabled.
+func IsMetricsServiceEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	sep := instance.Spec.Observability.Metrics.SeparateService
+	return IsMetricsEnabled(instance) && sep != nil && *sep
+}
+
+// IsMetricsAnnotationScrapeEnabled returns true if the Service carries
+// prometheus.io scrape annotations. It requires the metrics endpoint itself
+// to be enabled.
+func IsMetricsAnnotationScrapeEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	scrape := instance.Spec.Observability.Metrics.AnnotationScrape
+	return IsMetricsEnabled(instance) && scrape != nil && *scrape
+}
+
 // MetricsPort returns the configured metrics port or the default
 func MetricsPort(instance *openclawv1alpha1.OpenClawInstance) int32 {
 	if instance.Spec.Observability.Metrics.Port != nil {
@@ -433,6 +803,86 @@ func MetricsPort(instance *openclawv1alpha1.OpenClawInstance) int32 {
 	return DefaultMetricsPort
 }
 
+// DefaultedInstance returns a deep copy of the instance with the defaults the
+// builders would otherwise apply implicitly (image, resources, probes, metrics
+// port) written into the spec, so tooling can inspect the effective settings.
+// Building from the result produces the same objects as building from the
+// original. The input is not modified.
+func DefaultedInstance(instance *openclawv1alpha1.OpenClawInstance) *openclawv1alpha1.OpenClawInstance {
+	out := instance.DeepCopy()
+	spec := &out.Spec
+
+	// Image
+	spec.Image.Repository = GetImageRepository(out)
+	spec.Image.Tag = GetImageTag(out)
+	spec.Image.PullPolicy = getPullPolicy(out)
+
+	// Resources, guaranteed mode as in applyGuaranteedResources
+	guaranteed := spec.Resources.Guaranteed != nil && *spec.Resources.Guaranteed
+	if spec.Resources.Requests.CPU == "" {
+		spec.Resources.Requests.CPU = "500m"
+		if guaranteed && spec.Resources.Limits.CPU != "" {
+			spec.Resources.Requests.CPU = spec.Resources.Limits.CPU
+		}
+	}
+	if spec.Resources.Requests.Memory == "" {
+		spec.Resources.Requests.Memory = "1Gi"
+		if guaranteed && spec.Resources.Limits.Memory != "" {
+			spec.Resources.Requests.Memory = spec.Resources.Limits.Memory
+		}
+	}
+	if guaranteed {
+		spec.Resources.Limits.CPU = spec.Resources.Requests.CPU
+		spec.Resources.Limits.Memory = spec.Resources.Requests.Memory
+	}
+	if spec.Resources.Limits.CPU == "" {
+		spec.Resources.Limits.CPU = "2000m"
+	}
+	if spec.Resources.Limits.Memory == "" {
+		spec.Resources.Limits.Memory = "4Gi"
+	}
+
+	// Probes
+	if spec.Probes == nil {
+		spec.Probes = &openclawv1alpha1.ProbesSpec{}
+	}
+	spec.Probes.Liveness = defaultProbeSpec(spec.Probes.Liveness, buildLivenessProbe(instance))
+	spec.Probes.Readiness = defaultProbeSpec(spec.Probes.Readiness, buildReadinessProbe(instance))
+	spec.Probes.Startup = defaultProbeSpec(spec.Probes.Startup, buildStartupProbe(instance))
+
+	// Ports
+	spec.Observability.Metrics.Port = Ptr(MetricsPort(out))
+
+	return out
+}
+
+// defaultProbeSpec fills the unset fields of spec from the probe the builder
+// produced. A nil probe means the probe is disabled and spec is returned as-is.
+func defaultProbeSpec(spec *openclawv1alpha1.ProbeSpec, probe *corev1.Probe) *openclawv1alpha1.ProbeSpec {
+	if probe == nil {
+		return spec
+	}
+	if spec == nil {
+		spec = &openclawv1alpha1.ProbeSpec{}
+	}
+	if spec.Enabled == nil {
+		spec.Enabled = Ptr(true)
+	}
+	if spec.InitialDelaySeconds == nil {
+		spec.InitialDelaySeconds = Ptr(probe.InitialDelaySeconds)
+	}
+	if spec.PeriodSeconds == nil {
+		spec.PeriodSeconds = Ptr(probe.PeriodSeconds)
+	}
+	if spec.TimeoutSeconds == nil {
+		spec.TimeoutSeconds = Ptr(probe.TimeoutSeconds)
+	}
+	if spec.FailureThreshold == nil {
+		spec.FailureThreshold = Ptr(probe.FailureThreshold)
+	}
+	return spec
+}
+
 // Ptr returns a pointer to the given value
 func Ptr[T any](v T) *T {
 	return &v
@@ -7,6 +7,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
 )
@@ -48,6 +50,46 @@ func TestParseQuantity(t *testing.T) {
 	}
 }
 
+func TestApplyTrackingAnnotations(t *testing.T) {
+	instance := newTestInstance("tracking")
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"keep": "me"},
+		},
+	}
+
+	ApplyTrackingAnnotations(cm, instance, "v1.2.3")
+
+	if got := cm.Annotations["openclaw.rocks/operator-version"]; got != "v1.2.3" {
+		t.Errorf("operator-version = %q, want %q", got, "v1.2.3")
+	}
+	wantHash := calculateConfigHash(instance, nil, nil)
+	if got := cm.Annotations["openclaw.rocks/last-applied-hash"]; got != wantHash {
+		t.Errorf("last-applied-hash = %q, want %q", got, wantHash)
+	}
+	if cm.Annotations["keep"] != "me" {
+		t.Error("existing annotations should be preserved")
+	}
+
+	// Objects without annotations get a fresh map
+	svc := &corev1.Service{}
+	ApplyTrackingAnnotations(svc, instance, "v1.2.3")
+	if len(svc.Annotations) != 2 {
+		t.Errorf("expected 2 annotations, got %v", svc.Annotations)
+	}
+
+	// The hash follows the config
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{
+			Raw: []byte(`{"key":"value"}`),
+		},
+	}
+	ApplyTrackingAnnotations(cm, instance, "v1.2.3")
+	if cm.Annotations["openclaw.rocks/last-applied-hash"] == wantHash {
+		t.Error("last-applied-hash should change with the config")
+	}
+}
+
 func TestApplyRegistryOverride(t *testing.T) {
 	tests := []struct {
 		name     string
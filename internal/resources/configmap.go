@@ -17,9 +17,13 @@ limitations under the License.
 package resources
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,7 +32,7 @@ import (
 )
 
 // BuildConfigMap creates a ConfigMap for the OpenClawInstance configuration.
-// It always sets gateway.bind=loopback (the proxy sidecar handles external
+// It sets gateway.bind=loopback by default (the proxy sidecar handles external
 // access) and optionally injects gateway.auth credentials when gatewayToken
 // is non-empty. Also includes the nginx stream config for the proxy sidecar.
 // Uses the inline raw config from the instance spec as the base.
@@ -42,11 +46,32 @@ func BuildConfigMap(instance *openclawv1alpha1.OpenClawInstance, gatewayToken st
 	return BuildConfigMapFromBytes(instance, configBytes, gatewayToken, skillPacks)
 }
 
+// BuildConfigMapStrict is like BuildConfigMapFromBytes but honors
+// spec.config.strict: when enabled, a base config that is not valid JSON
+// returns an error instead of passing through unenriched. A nil baseConfig
+// falls back to the inline raw config. JSON5 configs are never checked
+// because they are converted by the init container.
+func BuildConfigMapStrict(instance *openclawv1alpha1.OpenClawInstance, baseConfig []byte, gatewayToken string, skillPacks *ResolvedSkillPacks) (*corev1.ConfigMap, error) {
+	if baseConfig == nil && instance.Spec.Config.Raw != nil {
+		baseConfig = instance.Spec.Config.Raw.Raw
+	}
+	if IsConfigStrict(instance) && len(baseConfig) > 0 && !json.Valid(baseConfig) {
+		return nil, fmt.Errorf("config is not valid JSON (spec.config.strict is enabled)")
+	}
+	return BuildConfigMapFromBytes(instance, baseConfig, gatewayToken, skillPacks), nil
+}
+
+// IsConfigStrict returns true if strict config validation applies to the instance.
+func IsConfigStrict(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Config.Strict != nil && *instance.Spec.Config.Strict &&
+		instance.Spec.Config.Format != "json5"
+}
+
 // BuildConfigMapFromBytes creates a ConfigMap for the OpenClawInstance using
 // the provided base config bytes. This allows the controller to pass config
 // from any source (inline raw, external ConfigMap, or empty default).
-// The enrichment pipeline (OTel metrics, gateway auth, device auth, tailscale,
-// browser, gateway bind, skill packs) always runs on the provided bytes.
+// The enrichment pipeline (channels, feature flags, OTel metrics, gateway auth, device auth,
+// tailscale, browser, gateway bind, skill packs) always runs on the provided bytes.
 func BuildConfigMapFromBytes(instance *openclawv1alpha1.OpenClawInstance, baseConfig []byte, gatewayToken string, skillPacks *ResolvedSkillPacks) *corev1.ConfigMap {
 	labels := Labels(instance)
 
@@ -55,14 +80,32 @@ func BuildConfigMapFromBytes(instance *openclawv1alpha1.OpenClawInstance, baseCo
 		configBytes = []byte("{}")
 	}
 
-	// Enrichment pipeline: OTel metrics -> gateway auth -> device auth -> tailscale -> browser -> gateway bind -> trusted proxies -> control UI origins -> skill packs
+	// Enrichment pipeline: channels -> feature flags -> OTel metrics -> gateway auth -> device auth -> tailscale -> browser -> gateway bind -> trusted proxies -> control UI origins -> skill packs -> active overlay
+	if len(instance.Spec.Channels) > 0 {
+		if enriched, err := enrichConfigWithChannels(configBytes, instance); err == nil {
+			configBytes = enriched
+		}
+	}
+	if len(instance.Spec.FeatureFlags) > 0 {
+		if enriched, err := enrichConfigWithFeatureFlags(configBytes, instance); err == nil {
+			configBytes = enriched
+		}
+	}
 	if IsMetricsEnabled(instance) {
 		if enriched, err := enrichConfigWithOTelMetrics(configBytes); err == nil {
 			configBytes = enriched
 		}
 	}
-	if gatewayToken != "" {
-		if enriched, err := enrichConfigWithGatewayAuth(configBytes, gatewayToken); err == nil {
+	if IsGatewayBasicAuth(instance) {
+		if enriched, err := enrichConfigWithGatewayBasicAuth(configBytes); err == nil {
+			configBytes = enriched
+		}
+	} else if gatewayToken != "" {
+		token := gatewayToken
+		if IsGatewayTokenFromEnvOnly(instance) {
+			token = ""
+		}
+		if enriched, err := enrichConfigWithGatewayAuth(configBytes, token); err == nil {
 			configBytes = enriched
 		}
 	}
@@ -75,7 +118,7 @@ func BuildConfigMapFromBytes(instance *openclawv1alpha1.OpenClawInstance, baseCo
 		}
 	}
 	if instance.Spec.Chromium.Enabled {
-		if enriched, err := enrichConfigWithBrowser(configBytes); err == nil {
+		if enriched, err := enrichConfigWithBrowser(configBytes, instance); err == nil {
 			configBytes = enriched
 		}
 	}
@@ -93,6 +136,11 @@ func BuildConfigMapFromBytes(instance *openclawv1alpha1.OpenClawInstance, baseCo
 			configBytes = enriched
 		}
 	}
+	if instance.Spec.Config.ActiveOverlay != "" {
+		if overlaid, err := applyConfigOverlay(configBytes, instance); err == nil {
+			configBytes = overlaid
+		}
+	}
 
 	configContent := string(configBytes)
 
@@ -104,9 +152,12 @@ func BuildConfigMapFromBytes(instance *openclawv1alpha1.OpenClawInstance, baseCo
 		}
 	}
 
-	data := map[string]string{
-		"openclaw.json": configContent,
+	// User-provided extra entries first so operator-managed keys always win
+	data := make(map[string]string, len(instance.Spec.ExtraConfigMapData)+4)
+	for k, v := range instance.Spec.ExtraConfigMapData {
+		data[k] = v
 	}
+	data[ConfigFileName(instance)] = configContent
 
 	// Only include nginx config when the gateway proxy is enabled
 	if IsGatewayProxyEnabled(instance) {
@@ -123,14 +174,162 @@ func BuildConfigMapFromBytes(instance *openclawv1alpha1.OpenClawInstance, baseCo
 		data[OTelCollectorConfigKey] = otelCollectorConfig(instance)
 	}
 
+	if instance.Spec.EntrypointScript != "" {
+		data[EntrypointScriptKey] = instance.Spec.EntrypointScript
+	}
+
+	var immutable *bool
+	if IsConfigImmutable(instance) {
+		immutable = Ptr(true)
+	}
+
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      ConfigMapName(instance),
 			Namespace: instance.Namespace,
 			Labels:    labels,
 		},
-		Data: data,
+		Data:      data,
+		Immutable: immutable,
+	}
+}
+
+// IsConfigImmutable returns true if the config ConfigMap is immutable
+// (spec.config.immutable).
+func IsConfigImmutable(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Config.Immutable != nil && *instance.Spec.Config.Immutable
+}
+
+// ConfigMapNeedsRecreate returns true if existing cannot be updated in place
+// to desired: an immutable ConfigMap can neither change its data nor become
+// mutable again, so the controller has to delete and recreate it.
+func ConfigMapNeedsRecreate(existing, desired *corev1.ConfigMap) bool {
+	if existing.Immutable == nil || !*existing.Immutable {
+		return false
 	}
+	if desired.Immutable == nil || !*desired.Immutable {
+		return true
+	}
+	return !maps.Equal(existing.Data, desired.Data) || !maps.EqualFunc(existing.BinaryData, desired.BinaryData, bytes.Equal)
+}
+
+// ExternalConfigRefs returns the external config ConfigMap references in merge
+// order: spec.config.configMapRef first (if set), then spec.config.configMapRefs.
+func ExternalConfigRefs(instance *openclawv1alpha1.OpenClawInstance) []openclawv1alpha1.ConfigMapKeySelector {
+	var refs []openclawv1alpha1.ConfigMapKeySelector
+	if instance.Spec.Config.ConfigMapRef != nil {
+		refs = append(refs, *instance.Spec.Config.ConfigMapRef)
+	}
+	return append(refs, instance.Spec.Config.ConfigMapRefs...)
+}
+
+// MergeConfigSources deep-merges JSON config objects in order. Later sources
+// win: nested objects are merged recursively, while arrays and scalars are
+// replaced. Returns an error if any source is not a JSON object.
+func MergeConfigSources(sources ...[]byte) ([]byte, error) {
+	merged := map[string]interface{}{}
+	for i, src := range sources {
+		if len(src) == 0 {
+			continue
+		}
+		var obj map[string]interface{}
+		if err := json.Unmarshal(src, &obj); err != nil {
+			return nil, fmt.Errorf("config source %d is not a valid JSON object: %w", i, err)
+		}
+		merged = deepMergeConfig(merged, obj)
+	}
+	return json.Marshal(merged)
+}
+
+// deepMergeConfig recursively merges src into dst and returns the result.
+// Arrays are replaced, not merged.
+func deepMergeConfig(dst, src map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(dst)+len(src))
+	for k, v := range dst {
+		result[k] = v
+	}
+	for k, v := range src {
+		if srcMap, ok := v.(map[string]interface{}); ok {
+			if dstMap, ok := result[k].(map[string]interface{}); ok {
+				result[k] = deepMergeConfig(dstMap, srcMap)
+				continue
+			}
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// applyConfigOverlay deep-merges the active overlay from spec.config.overlays
+// over the enriched config. It runs last so environment-specific values win
+// over both the base config and operator enrichment. An unknown overlay name
+// returns the config unchanged (ValidateConfigOverlay rejects it upstream).
+func applyConfigOverlay(configJSON []byte, instance *openclawv1alpha1.OpenClawInstance) ([]byte, error) {
+	overlay, ok := instance.Spec.Config.Overlays[instance.Spec.Config.ActiveOverlay]
+	if !ok || len(overlay.Raw) == 0 {
+		return configJSON, nil
+	}
+	return MergeConfigSources(configJSON, overlay.Raw)
+}
+
+// enrichConfigWithChannels merges the typed spec.channels entries into the
+// config's "channels" object. Each entry becomes channels.<name> with its
+// settings plus enabled (default true). Values already present under
+// channels.<name> in the base config win, so raw config can override any
+// typed field. Entries whose settings are not a JSON object are skipped.
+func enrichConfigWithChannels(configJSON []byte, instance *openclawv1alpha1.OpenClawInstance) ([]byte, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return configJSON, nil // not a JSON object, return unchanged
+	}
+
+	channels, _ := config["channels"].(map[string]interface{})
+	if channels == nil {
+		channels = make(map[string]interface{})
+	}
+
+	for name, spec := range instance.Spec.Channels {
+		typed := map[string]interface{}{}
+		if spec.Settings != nil && len(spec.Settings.Raw) > 0 {
+			if err := json.Unmarshal(spec.Settings.Raw, &typed); err != nil {
+				continue
+			}
+		}
+		typed["enabled"] = spec.Enabled == nil || *spec.Enabled
+
+		if existing, ok := channels[name].(map[string]interface{}); ok {
+			channels[name] = deepMergeConfig(typed, existing)
+		} else if _, ok := channels[name]; !ok {
+			channels[name] = typed
+		}
+	}
+
+	config["channels"] = channels
+	return json.Marshal(config)
+}
+
+// enrichConfigWithFeatureFlags merges spec.featureFlags into the config's
+// "features" object. Flags already present under features in the base config
+// win, so raw config can override any typed flag.
+func enrichConfigWithFeatureFlags(configJSON []byte, instance *openclawv1alpha1.OpenClawInstance) ([]byte, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return configJSON, nil // not a JSON object, return unchanged
+	}
+
+	features, _ := config["features"].(map[string]interface{})
+	if features == nil {
+		features = make(map[string]interface{})
+	}
+
+	for name, enabled := range instance.Spec.FeatureFlags {
+		if _, ok := features[name]; !ok {
+			features[name] = enabled
+		}
+	}
+
+	config["features"] = features
+	return json.Marshal(config)
 }
 
 // enrichConfigWithGatewayAuth injects the gateway token into the config JSON
@@ -138,6 +337,8 @@ func BuildConfigMapFromBytes(instance *openclawv1alpha1.OpenClawInstance, baseCo
 // not set gateway.auth.mode, it also injects mode=token. If the user has already
 // set gateway.auth.token or gateway.auth.mode is trusted-proxy, the config is
 // returned unchanged (user override wins/trusted-proxy is incompatible with tokens).
+// An empty token only injects the mode; OpenClaw then reads the token from
+// OPENCLAW_GATEWAY_TOKEN.
 func enrichConfigWithGatewayAuth(configJSON []byte, token string) ([]byte, error) {
 	var config map[string]interface{}
 	if err := json.Unmarshal(configJSON, &config); err != nil {
@@ -170,13 +371,51 @@ func enrichConfigWithGatewayAuth(configJSON []byte, token string) ([]byte, error
 		auth["mode"] = "token" //nolint:goconst // OpenClaw auth mode, not k8s Secret key
 	}
 
-	auth["token"] = token
+	if token != "" {
+		auth["token"] = token
+	}
 	gw["auth"] = auth
 	config["gateway"] = gw
 
 	return json.Marshal(config)
 }
 
+// IsGatewayBasicAuth returns true if spec.gateway.authMode selects HTTP basic
+// auth instead of the default token auth.
+func IsGatewayBasicAuth(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Gateway.AuthMode == GatewayAuthModeBasic
+}
+
+// enrichConfigWithGatewayBasicAuth sets gateway.auth.mode=basic in the config
+// JSON. The spec field wins over a mode set in the config; the credentials
+// are delivered as env vars, never through the ConfigMap.
+func enrichConfigWithGatewayBasicAuth(configJSON []byte) ([]byte, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return configJSON, nil // not a JSON object, return unchanged
+	}
+
+	gw, _ := config["gateway"].(map[string]interface{})
+	if gw == nil {
+		gw = make(map[string]interface{})
+	}
+	auth, _ := gw["auth"].(map[string]interface{})
+	if auth == nil {
+		auth = make(map[string]interface{})
+	}
+	auth["mode"] = GatewayAuthModeBasic
+	gw["auth"] = auth
+	config["gateway"] = gw
+
+	return json.Marshal(config)
+}
+
+// IsGatewayTokenFromEnvOnly returns true if the gateway token must be kept out
+// of the ConfigMap and delivered only via the OPENCLAW_GATEWAY_TOKEN env var.
+func IsGatewayTokenFromEnvOnly(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Gateway.TokenFromEnvOnly != nil && *instance.Spec.Gateway.TokenFromEnvOnly
+}
+
 // IsGatewayAuthTrustedProxy returns true if the given config JSON sets
 // gateway.auth.mode to "trusted-proxy".
 func IsGatewayAuthTrustedProxy(configJSON []byte) bool {
@@ -339,18 +578,37 @@ type tailscaleWebHandler struct {
 	Proxy string `json:"Proxy"`
 }
 
+// TailscaleServePort returns the tailnet HTTPS port Tailscale serve listens on
+// (spec.tailscale.servePort, default 443).
+func TailscaleServePort(instance *openclawv1alpha1.OpenClawInstance) int32 {
+	if p := instance.Spec.Tailscale.ServePort; p != nil && *p > 0 {
+		return *p
+	}
+	return DefaultTailscaleServePort
+}
+
+// IsTailscaleServeCanvasEnabled returns true if the canvas is served on the
+// tailnet alongside the gateway (spec.tailscale.serveCanvas).
+func IsTailscaleServeCanvasEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Tailscale.ServeCanvas != nil && *instance.Spec.Tailscale.ServeCanvas
+}
+
 // BuildTailscaleServeConfig generates the TS_SERVE_CONFIG JSON for the sidecar.
-// It proxies HTTPS traffic to the gateway on 127.0.0.1:GatewayPort.
+// It proxies HTTPS traffic on the serve port to the gateway on
+// 127.0.0.1:GatewayPort and, with serveCanvas, TailscaleCanvasServePort to the
+// canvas on 127.0.0.1:CanvasPort.
 // In funnel mode, AllowFunnel is set to expose the instance publicly.
 func BuildTailscaleServeConfig(instance *openclawv1alpha1.OpenClawInstance) string {
 	proxy := fmt.Sprintf("http://127.0.0.1:%d", GatewayPort)
+	port := strconv.Itoa(int(TailscaleServePort(instance)))
+	hostPort := "${TS_CERT_DOMAIN}:" + port
 
 	cfg := tailscaleServeConfig{
 		TCP: map[string]*tailscaleTCPHandler{
-			"443": {HTTPS: true},
+			port: {HTTPS: true},
 		},
 		Web: map[string]*tailscaleWebConfig{
-			"${TS_CERT_DOMAIN}:443": {
+			hostPort: {
 				Handlers: map[string]*tailscaleWebHandler{
 					"/": {Proxy: proxy},
 				},
@@ -358,13 +616,28 @@ func BuildTailscaleServeConfig(instance *openclawv1alpha1.OpenClawInstance) stri
 		},
 	}
 
+	var canvasHostPort string
+	if IsTailscaleServeCanvasEnabled(instance) {
+		canvasPort := strconv.Itoa(int(TailscaleCanvasServePort))
+		canvasHostPort = "${TS_CERT_DOMAIN}:" + canvasPort
+		cfg.TCP[canvasPort] = &tailscaleTCPHandler{HTTPS: true}
+		cfg.Web[canvasHostPort] = &tailscaleWebConfig{
+			Handlers: map[string]*tailscaleWebHandler{
+				"/": {Proxy: fmt.Sprintf("http://127.0.0.1:%d", CanvasPort)},
+			},
+		}
+	}
+
 	mode := instance.Spec.Tailscale.Mode
 	if mode == "" {
 		mode = TailscaleModeServe
 	}
 	if mode == TailscaleModeFunnel {
 		cfg.AllowFunnel = map[string]bool{
-			"${TS_CERT_DOMAIN}:443": true,
+			hostPort: true,
+		}
+		if canvasHostPort != "" {
+			cfg.AllowFunnel[canvasHostPort] = true
 		}
 	}
 
@@ -389,8 +662,9 @@ func BuildTailscaleServeConfig(instance *openclawv1alpha1.OpenClawInstance) stri
 // profile="chrome" explicitly in browser tool calls, bypassing defaultProfile.
 // Without this override the built-in "chrome" profile falls back to the
 // extension relay which does not work in a headless container.
+// spec.chromium.profiles customizes or adds profiles on top of these two.
 // Does not override user-set values.
-func enrichConfigWithBrowser(configJSON []byte) ([]byte, error) {
+func enrichConfigWithBrowser(configJSON []byte, instance *openclawv1alpha1.OpenClawInstance) ([]byte, error) {
 	var config map[string]interface{}
 	if err := json.Unmarshal(configJSON, &config); err != nil {
 		return configJSON, nil // not a JSON object, return unchanged
@@ -426,18 +700,51 @@ func enrichConfigWithBrowser(configJSON []byte) ([]byte, error) {
 		profiles = make(map[string]interface{})
 	}
 
-	// Use ${OPENCLAW_CHROMIUM_CDP} env var (resolved at runtime by OpenClaw)
-	// which points to the Chromium sidecar via localhost (127.0.0.1:9222).
+	// Use ${OPENCLAW_CHROMIUM_CDP} env var (resolved at runtime by OpenClaw,
+	// renamed via spec.chromium.cdpEnvName) which points to the Chromium
+	// sidecar via localhost (127.0.0.1:9222).
 	// Using a localhost address is required because OpenClaw's browser control
 	// service treats non-localhost CDP URLs as remote browsers that require
 	// device pairing, which is not available in a headless container.
-	cdpURL := "${OPENCLAW_CHROMIUM_CDP}"
+	cdpURL := "${" + ChromiumCDPEnvName(instance) + "}"
 
 	// Configure both "default" and "chrome" profiles to point at the sidecar.
 	// LLMs often explicitly pass profile="chrome", so we redirect it to the
 	// sidecar CDP endpoint instead of the extension relay.
-	for _, profileName := range []string{"default", "chrome"} {
-		profile, _ := profiles[profileName].(map[string]interface{})
+	// spec.chromium.profiles overrides their color/cdpUrl or adds profiles.
+	wanted := []openclawv1alpha1.BrowserProfileSpec{
+		{Name: "default", Color: DefaultBrowserProfileColor, CDPURL: cdpURL},
+		{Name: "chrome", Color: DefaultBrowserProfileColor, CDPURL: cdpURL},
+	}
+	// With several sidecars each gets a profile named after its container so
+	// the agent can browse in parallel. The first is also "default"/"chrome".
+	if n := ChromiumReplicas(instance); n > 1 {
+		for i := range n {
+			url := cdpURL
+			if i > 0 {
+				url = fmt.Sprintf("http://127.0.0.1:%d", ChromiumReplicaPort(i))
+			}
+			wanted = append(wanted, openclawv1alpha1.BrowserProfileSpec{
+				Name: ChromiumContainerName(instance, i), Color: DefaultBrowserProfileColor, CDPURL: url,
+			})
+		}
+	}
+	for _, p := range instance.Spec.Chromium.Profiles {
+		i := slices.IndexFunc(wanted, func(w openclawv1alpha1.BrowserProfileSpec) bool { return w.Name == p.Name })
+		if i < 0 {
+			wanted = append(wanted, openclawv1alpha1.BrowserProfileSpec{Name: p.Name, Color: DefaultBrowserProfileColor, CDPURL: cdpURL})
+			i = len(wanted) - 1
+		}
+		if p.Color != "" {
+			wanted[i].Color = p.Color
+		}
+		if p.CDPURL != "" {
+			wanted[i].CDPURL = p.CDPURL
+		}
+	}
+
+	for _, w := range wanted {
+		profile, _ := profiles[w.Name].(map[string]interface{})
 		if profile == nil {
 			profile = make(map[string]interface{})
 		}
@@ -445,16 +752,16 @@ func enrichConfigWithBrowser(configJSON []byte) ([]byte, error) {
 		// Only set cdpUrl if the user hasn't configured cdpUrl or cdpPort
 		if _, hasURL := profile["cdpUrl"]; !hasURL {
 			if _, hasPort := profile["cdpPort"]; !hasPort {
-				profile["cdpUrl"] = cdpURL
+				profile["cdpUrl"] = w.CDPURL
 			}
 		}
 
 		// color is required by OpenClaw's config validation
 		if _, hasColor := profile["color"]; !hasColor {
-			profile["color"] = "#4285F4"
+			profile["color"] = w.Color
 		}
 
-		profiles[profileName] = profile
+		profiles[w.Name] = profile
 	}
 
 	browser["profiles"] = profiles
@@ -464,11 +771,11 @@ func enrichConfigWithBrowser(configJSON []byte) ([]byte, error) {
 }
 
 // enrichConfigWithGatewayBind injects gateway.bind into the config JSON.
-// When the gateway proxy sidecar is enabled, the gateway binds to loopback
-// (the proxy handles external access). When disabled, the gateway must bind
-// to 0.0.0.0 so the kubelet and Service can reach it directly.
-// If the user has already set gateway.bind, the config is returned unchanged
-// (user override wins).
+// spec.gateway.bind always wins. Otherwise, if the user has already set
+// gateway.bind in the config, it is returned unchanged (user override wins).
+// Failing both, the gateway binds to loopback when the proxy sidecar is
+// enabled (the proxy handles external access) and to 0.0.0.0 when it is
+// disabled so the kubelet and Service can reach it directly.
 func enrichConfigWithGatewayBind(configJSON []byte, instance *openclawv1alpha1.OpenClawInstance) ([]byte, error) {
 	var config map[string]interface{}
 	if err := json.Unmarshal(configJSON, &config); err != nil {
@@ -480,51 +787,69 @@ func enrichConfigWithGatewayBind(configJSON []byte, instance *openclawv1alpha1.O
 		gw = make(map[string]interface{})
 	}
 
-	// If the user already set bind, don't override
-	if _, ok := gw["bind"]; ok {
+	if instance.Spec.Gateway.Bind != "" {
+		gw["bind"] = instance.Spec.Gateway.Bind
+	} else if _, ok := gw["bind"]; ok {
+		// If the user already set bind, don't override
 		return configJSON, nil
-	}
-
-	if IsGatewayProxyEnabled(instance) {
-		gw["bind"] = GatewayBindLoopback
 	} else {
-		gw["bind"] = GatewayBindAllInterfaces
+		gw["bind"] = defaultGatewayBind(instance)
 	}
 	config["gateway"] = gw
 
 	return json.Marshal(config)
 }
 
-// HasGatewayBindConflict returns true when the gateway proxy is disabled but
-// the user has manually set gateway.bind to loopback in their config JSON.
-// This combination makes the pod unreachable because nothing is listening on
-// the external interface.
-func HasGatewayBindConflict(instance *openclawv1alpha1.OpenClawInstance) bool {
+// defaultGatewayBind returns the bind used when neither spec.gateway.bind nor
+// the config sets one.
+func defaultGatewayBind(instance *openclawv1alpha1.OpenClawInstance) string {
 	if IsGatewayProxyEnabled(instance) {
-		return false
+		return GatewayBindLoopback
 	}
+	return GatewayBindAllInterfaces
+}
 
-	configBytes := []byte("{}")
-	if instance.Spec.Config.Raw != nil && len(instance.Spec.Config.Raw.Raw) > 0 {
-		configBytes = instance.Spec.Config.Raw.Raw
+// GatewayBind returns the effective gateway.bind for the instance:
+// spec.gateway.bind, then gateway.bind from the inline config, then the
+// proxy-dependent default.
+func GatewayBind(instance *openclawv1alpha1.OpenClawInstance) string {
+	if instance.Spec.Gateway.Bind != "" {
+		return instance.Spec.Gateway.Bind
 	}
 
-	var config map[string]interface{}
-	if err := json.Unmarshal(configBytes, &config); err != nil {
-		return false
+	if instance.Spec.Config.Raw != nil && len(instance.Spec.Config.Raw.Raw) > 0 {
+		var config map[string]interface{}
+		if err := json.Unmarshal(instance.Spec.Config.Raw.Raw, &config); err == nil {
+			if gw, _ := config["gateway"].(map[string]interface{}); gw != nil {
+				if bind, ok := gw["bind"].(string); ok {
+					return bind
+				}
+			}
+		}
 	}
 
-	gw, _ := config["gateway"].(map[string]interface{})
-	if gw == nil {
-		return false
-	}
+	return defaultGatewayBind(instance)
+}
 
-	bind, ok := gw["bind"]
-	if !ok {
-		return false
-	}
-	bindStr, ok := bind.(string)
-	return ok && (bindStr == GatewayBindLoopback || bindStr == "127.0.0.1")
+// IsLoopbackBind reports whether a gateway.bind value only listens on loopback.
+func IsLoopbackBind(bind string) bool {
+	return bind == GatewayBindLoopback || bind == "127.0.0.1"
+}
+
+// HasGatewayBindConflict returns true when the gateway proxy is disabled but
+// gateway.bind is set to loopback (via spec.gateway.bind or the config JSON).
+// This combination makes the pod unreachable because nothing is listening on
+// the external interface.
+func HasGatewayBindConflict(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return !IsGatewayProxyEnabled(instance) && IsLoopbackBind(GatewayBind(instance))
+}
+
+// HasGatewayProxyBindMismatch returns true when the gateway proxy is enabled
+// but gateway.bind is not loopback. The gateway is then reachable directly,
+// bypassing the proxy, and OpenClaw rejects plaintext ws:// on non-loopback
+// addresses.
+func HasGatewayProxyBindMismatch(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return IsGatewayProxyEnabled(instance) && !IsLoopbackBind(GatewayBind(instance))
 }
 
 // enrichConfigWithTrustedProxies ensures 127.0.0.0/8 is present in
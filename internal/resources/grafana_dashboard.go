@@ -29,12 +29,12 @@ const defaultGrafanaFolder = "OpenClaw"
 
 // GrafanaDashboardOperatorName returns the name of the operator overview dashboard ConfigMap
 func GrafanaDashboardOperatorName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name + "-dashboard-operator"
+	return resourceName(instance, "-dashboard-operator")
 }
 
 // GrafanaDashboardInstanceName returns the name of the instance detail dashboard ConfigMap
 func GrafanaDashboardInstanceName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name + "-dashboard-instance"
+	return resourceName(instance, "-dashboard-instance")
 }
 
 // BuildGrafanaDashboardOperator creates a ConfigMap containing the operator overview Grafana dashboard
@@ -45,7 +45,7 @@ func BuildGrafanaDashboardOperator(instance *openclawv1alpha1.OpenClawInstance)
 
 // BuildGrafanaDashboardInstance creates a ConfigMap containing the per-instance Grafana dashboard
 func BuildGrafanaDashboardInstance(instance *openclawv1alpha1.OpenClawInstance) *corev1.ConfigMap {
-	dashboardJSON := buildInstanceDashboard()
+	dashboardJSON := buildInstanceDashboard(MainContainerName(instance))
 	return buildDashboardConfigMap(instance, GrafanaDashboardInstanceName(instance), "openclaw-instance.json", dashboardJSON)
 }
 
@@ -417,7 +417,7 @@ func buildOperatorPanels() []grafanaPanel {
 
 // --- Instance dashboard ---
 
-func buildInstanceDashboard() string {
+func buildInstanceDashboard(container string) string {
 	dashboard := grafanaDashboard{
 		Annotations:   grafanaAnnotations{List: []interface{}{}},
 		Editable:      true,
@@ -435,13 +435,15 @@ func buildInstanceDashboard() string {
 				instanceVar(false),
 			},
 		},
-		Panels: buildInstancePanels(),
+		Panels: buildInstancePanels(container),
 	}
 	return mustMarshalJSON(dashboard)
 }
 
-func buildInstancePanels() []grafanaPanel {
+func buildInstancePanels(container string) []grafanaPanel {
 	gp := func(h, w, x, y int) grafanaGridPos { return grafanaGridPos{H: h, W: w, X: x, Y: y} }
+	// Label selector for the main container, which follows spec.containerName.
+	sel := `namespace="$namespace",pod=~"$instance-.*",container="` + container + `"`
 
 	panels := []grafanaPanel{
 		// --- Health row ---
@@ -453,10 +455,10 @@ func buildInstancePanels() []grafanaPanel {
 			`openclaw_instance_ready{namespace="$namespace",instance="$instance"}`,
 			gp(4, 5, 5, 1)),
 		gaugePanel(23, "CPU %",
-			`sum(rate(container_cpu_usage_seconds_total{namespace="$namespace",pod=~"$instance-.*",container="openclaw"}[5m])) / sum(kube_pod_container_resource_limits{namespace="$namespace",pod=~"$instance-.*",container="openclaw",resource="cpu"})`,
+			`sum(rate(container_cpu_usage_seconds_total{`+sel+`}[5m])) / sum(kube_pod_container_resource_limits{`+sel+`,resource="cpu"})`,
 			gp(4, 5, 10, 1)),
 		gaugePanel(24, "Memory %",
-			`sum(container_memory_working_set_bytes{namespace="$namespace",pod=~"$instance-.*",container="openclaw"}) / sum(kube_pod_container_resource_limits{namespace="$namespace",pod=~"$instance-.*",container="openclaw",resource="memory"})`,
+			`sum(container_memory_working_set_bytes{`+sel+`}) / sum(kube_pod_container_resource_limits{`+sel+`,resource="memory"})`,
 			gp(4, 5, 15, 1)),
 		gaugePanel(25, "PVC %",
 			`kubelet_volume_stats_used_bytes{namespace="$namespace",persistentvolumeclaim=~"data-$instance.*"} / kubelet_volume_stats_capacity_bytes{namespace="$namespace",persistentvolumeclaim=~"data-$instance.*"}`,
@@ -466,26 +468,26 @@ func buildInstancePanels() []grafanaPanel {
 		rowPanel(201, "CPU", 5, false, nil),
 		timeseriesPanel(26, "CPU Usage vs Request/Limit",
 			[]grafanaTarget{
-				{Expr: `sum(rate(container_cpu_usage_seconds_total{namespace="$namespace",pod=~"$instance-.*",container="openclaw"}[5m]))`, LegendFormat: "usage", RefID: "A"},
-				{Expr: `sum(kube_pod_container_resource_requests{namespace="$namespace",pod=~"$instance-.*",container="openclaw",resource="cpu"})`, LegendFormat: "request", RefID: "B"},
-				{Expr: `sum(kube_pod_container_resource_limits{namespace="$namespace",pod=~"$instance-.*",container="openclaw",resource="cpu"})`, LegendFormat: "limit", RefID: "C"},
+				{Expr: `sum(rate(container_cpu_usage_seconds_total{` + sel + `}[5m]))`, LegendFormat: "usage", RefID: "A"},
+				{Expr: `sum(kube_pod_container_resource_requests{` + sel + `,resource="cpu"})`, LegendFormat: "request", RefID: "B"},
+				{Expr: `sum(kube_pod_container_resource_limits{` + sel + `,resource="cpu"})`, LegendFormat: "limit", RefID: "C"},
 			}, gp(8, 12, 0, 6)),
 		timeseriesPanel(27, "CPU Throttling",
 			[]grafanaTarget{
-				{Expr: `sum(rate(container_cpu_cfs_throttled_seconds_total{namespace="$namespace",pod=~"$instance-.*",container="openclaw"}[5m]))`, LegendFormat: "throttled", RefID: "A"},
+				{Expr: `sum(rate(container_cpu_cfs_throttled_seconds_total{` + sel + `}[5m]))`, LegendFormat: "throttled", RefID: "A"},
 			}, gp(8, 12, 12, 6)),
 
 		// --- Memory row ---
 		rowPanel(202, "Memory", 14, false, nil),
 		timeseriesPanel(28, "Working Set vs Limit",
 			[]grafanaTarget{
-				{Expr: `sum(container_memory_working_set_bytes{namespace="$namespace",pod=~"$instance-.*",container="openclaw"})`, LegendFormat: "working set", RefID: "A"},
-				{Expr: `sum(kube_pod_container_resource_limits{namespace="$namespace",pod=~"$instance-.*",container="openclaw",resource="memory"})`, LegendFormat: "limit", RefID: "B"},
+				{Expr: `sum(container_memory_working_set_bytes{` + sel + `})`, LegendFormat: "working set", RefID: "A"},
+				{Expr: `sum(kube_pod_container_resource_limits{` + sel + `,resource="memory"})`, LegendFormat: "limit", RefID: "B"},
 			}, gp(8, 12, 0, 15)),
 		timeseriesPanel(29, "OOM Kills and Restarts",
 			[]grafanaTarget{
-				{Expr: `sum(kube_pod_container_status_restarts_total{namespace="$namespace",pod=~"$instance-.*",container="openclaw"})`, LegendFormat: "restarts", RefID: "A"},
-				{Expr: `sum(kube_pod_container_status_last_terminated_reason{namespace="$namespace",pod=~"$instance-.*",container="openclaw",reason="OOMKilled"})`, LegendFormat: "OOM killed", RefID: "B"},
+				{Expr: `sum(kube_pod_container_status_restarts_total{` + sel + `})`, LegendFormat: "restarts", RefID: "A"},
+				{Expr: `sum(kube_pod_container_status_last_terminated_reason{` + sel + `,reason="OOMKilled"})`, LegendFormat: "OOM killed", RefID: "B"},
 			}, gp(8, 12, 12, 15)),
 
 		// --- Network row (collapsed) ---
@@ -26,7 +26,7 @@ import (
 
 // HPAName returns the name of the HorizontalPodAutoscaler
 func HPAName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name
+	return resourceName(instance, "")
 }
 
 // IsHPAEnabled returns true if auto-scaling is enabled for the instance
@@ -36,6 +36,18 @@ func IsHPAEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
 		*instance.Spec.Availability.AutoScaling.Enabled
 }
 
+// MaxReplicas returns the most pods the StatefulSet can run:
+// autoScaling.maxReplicas (default 5) with HPA enabled, otherwise 1.
+func MaxReplicas(instance *openclawv1alpha1.OpenClawInstance) int32 {
+	if !IsHPAEnabled(instance) {
+		return 1
+	}
+	if m := instance.Spec.Availability.AutoScaling.MaxReplicas; m != nil {
+		return *m
+	}
+	return 5
+}
+
 // BuildHPA creates a HorizontalPodAutoscaler for the OpenClawInstance
 func BuildHPA(instance *openclawv1alpha1.OpenClawInstance) *autoscalingv2.HorizontalPodAutoscaler {
 	labels := Labels(instance)
@@ -47,10 +59,7 @@ func BuildHPA(instance *openclawv1alpha1.OpenClawInstance) *autoscalingv2.Horizo
 		minReplicas = *as.MinReplicas
 	}
 
-	maxReplicas := int32(5)
-	if as.MaxReplicas != nil {
-		maxReplicas = *as.MaxReplicas
-	}
+	maxReplicas := MaxReplicas(instance)
 
 	cpuTarget := int32(80)
 	if as.TargetCPUUtilization != nil {
@@ -17,11 +17,14 @@ limitations under the License.
 package resources
 
 import (
+	"regexp"
 	"strconv"
 	"strings"
 
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
 )
@@ -35,6 +38,37 @@ const (
 	IngressProviderUnknown IngressProvider = "unknown"
 )
 
+// TraefikMiddlewareGVK returns the GroupVersionKind for the Traefik Middleware
+func TraefikMiddlewareGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "traefik.io",
+		Version: "v1alpha1",
+		Kind:    "Middleware",
+	}
+}
+
+// BuildTraefikBasicAuthMiddleware creates the Traefik Middleware that enforces
+// Ingress basic auth against the operator-managed or existing htpasswd Secret.
+// Uses unstructured so the operator doesn't require the Traefik CRDs.
+func BuildTraefikBasicAuthMiddleware(instance *openclawv1alpha1.OpenClawInstance) *unstructured.Unstructured {
+	secretName := BasicAuthSecretName(instance)
+	if ba := instance.Spec.Networking.Ingress.Security.BasicAuth; ba != nil && ba.ExistingSecret != "" {
+		secretName = ba.ExistingSecret
+	}
+
+	mw := &unstructured.Unstructured{}
+	mw.SetGroupVersionKind(TraefikMiddlewareGVK())
+	mw.SetName(TraefikBasicAuthMiddlewareName(instance))
+	mw.SetNamespace(instance.Namespace)
+	mw.SetLabels(Labels(instance))
+	mw.Object["spec"] = map[string]interface{}{
+		"basicAuth": map[string]interface{}{
+			"secret": secretName,
+		},
+	}
+	return mw
+}
+
 // BuildIngress creates an Ingress for the OpenClawInstance
 func BuildIngress(instance *openclawv1alpha1.OpenClawInstance) *networkingv1.Ingress {
 	labels := Labels(instance)
@@ -57,6 +91,54 @@ func BuildIngress(instance *openclawv1alpha1.OpenClawInstance) *networkingv1.Ing
 	return ingress
 }
 
+// BuildMetricsIngress creates the Ingress that routes /metrics on
+// spec.observability.metrics.ingress.host to the Service's metrics port. It
+// carries only the user's annotations; the gateway Ingress security defaults
+// do not apply.
+func BuildMetricsIngress(instance *openclawv1alpha1.OpenClawInstance) *networkingv1.Ingress {
+	spec := instance.Spec.Observability.Metrics.Ingress
+	annotations := map[string]string{}
+	for k, v := range spec.Annotations {
+		annotations[k] = v
+	}
+	pathType := networkingv1.PathTypePrefix
+
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        MetricsIngressName(instance),
+			Namespace:   instance.Namespace,
+			Labels:      Labels(instance),
+			Annotations: annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: spec.ClassName,
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: spec.Host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/metrics",
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: ServiceName(instance),
+											Port: networkingv1.ServiceBackendPort{
+												Number: MetricsPort(instance),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // DetectIngressProvider determines the ingress controller type from the className.
 // Returns IngressProviderNginx if className contains "nginx" (case-insensitive),
 // IngressProviderTraefik if it contains "traefik", or IngressProviderUnknown otherwise.
@@ -123,6 +205,16 @@ func buildIngressAnnotations(instance *openclawv1alpha1.OpenClawInstance) map[st
 		}
 	}
 
+	// Client source allowlist (nginx only — traefik requires an IPAllowList Middleware CRD)
+	// Entries are trimmed because the webhook accepts surrounding whitespace.
+	if len(security.AllowedSourceRanges) > 0 && emitNginx {
+		ranges := make([]string, 0, len(security.AllowedSourceRanges))
+		for _, r := range security.AllowedSourceRanges {
+			ranges = append(ranges, strings.TrimSpace(r))
+		}
+		annotations["nginx.ingress.kubernetes.io/whitelist-source-range"] = strings.Join(ranges, ",")
+	}
+
 	// WebSocket support (nginx only — traefik auto-detects WebSocket upgrades)
 	if emitNginx {
 		annotations["nginx.ingress.kubernetes.io/proxy-read-timeout"] = "3600"
@@ -131,6 +223,13 @@ func buildIngressAnnotations(instance *openclawv1alpha1.OpenClawInstance) map[st
 		annotations["nginx.ingress.kubernetes.io/upstream-hash-by"] = "$binary_remote_addr"
 	}
 
+	// Sub-path hosting (nginx only — traefik requires a StripPrefix Middleware CRD).
+	// The rewritten paths capture everything after the prefix as $1.
+	if usesIngressPathRewrite(instance) {
+		annotations["nginx.ingress.kubernetes.io/use-regex"] = "true"
+		annotations["nginx.ingress.kubernetes.io/rewrite-target"] = "/$1"
+	}
+
 	// Basic Auth
 	if security.BasicAuth != nil {
 		basicAuthEnabled := security.BasicAuth.Enabled == nil || *security.BasicAuth.Enabled
@@ -151,7 +250,7 @@ func buildIngressAnnotations(instance *openclawv1alpha1.OpenClawInstance) map[st
 			if emitTraefik {
 				// For Traefik, a BasicAuth Middleware must be created alongside the Ingress.
 				// The annotation references it as <namespace>-<name>@kubernetescrd.
-				middlewareName := instance.Name + "-basic-auth"
+				middlewareName := TraefikBasicAuthMiddlewareName(instance)
 				annotations["traefik.ingress.kubernetes.io/router.middlewares"] =
 					instance.Namespace + "-" + middlewareName + "@kubernetescrd"
 			}
@@ -165,7 +264,11 @@ func buildIngressAnnotations(instance *openclawv1alpha1.OpenClawInstance) map[st
 func buildIngressRulesFromSpec(instance *openclawv1alpha1.OpenClawInstance) []networkingv1.IngressRule {
 	rules := []networkingv1.IngressRule{}
 
-	pathType := networkingv1.PathTypePrefix
+	// Unknown values fall back to Prefix; ValidateIngress rejects them upstream.
+	defaultPathType, ok := ParseIngressPathType(instance.Spec.Networking.Ingress.DefaultPathType)
+	if !ok {
+		defaultPathType = networkingv1.PathTypePrefix
+	}
 
 	for _, host := range instance.Spec.Networking.Ingress.Hosts {
 		rule := networkingv1.IngressRule{
@@ -180,7 +283,7 @@ func buildIngressRulesFromSpec(instance *openclawv1alpha1.OpenClawInstance) []ne
 		// Add paths or default to /
 		paths := host.Paths
 		if len(paths) == 0 {
-			paths = []openclawv1alpha1.IngressPath{{Path: "/", PathType: "Prefix"}}
+			paths = []openclawv1alpha1.IngressPath{{Path: "/"}}
 		}
 
 		for _, p := range paths {
@@ -189,10 +292,15 @@ func buildIngressRulesFromSpec(instance *openclawv1alpha1.OpenClawInstance) []ne
 				path = "/"
 			}
 
-			pt := pathType
-			if p.PathType == "Exact" {
-				pt = networkingv1.PathTypeExact
-			} else if p.PathType == "ImplementationSpecific" {
+			pt := defaultPathType
+			if p.PathType != "" {
+				if parsed, ok := ParseIngressPathType(p.PathType); ok {
+					pt = parsed
+				}
+			}
+
+			if usesIngressPathRewrite(instance) {
+				path = rewriteIngressPath(instance.Spec.Networking.Ingress.PathRewrite, path)
 				pt = networkingv1.PathTypeImplementationSpecific
 			}
 
@@ -221,6 +329,37 @@ func buildIngressRulesFromSpec(instance *openclawv1alpha1.OpenClawInstance) []ne
 	return rules
 }
 
+// usesIngressPathRewrite returns true if paths are served under
+// spec.networking.ingress.pathRewrite. Only the nginx provider supports it.
+func usesIngressPathRewrite(instance *openclawv1alpha1.OpenClawInstance) bool {
+	ing := instance.Spec.Networking.Ingress
+	return ing.PathRewrite != "" && DetectIngressProvider(ing.ClassName) == IngressProviderNginx
+}
+
+// rewriteIngressPath turns path into an nginx regex under prefix whose first
+// capture group is the backend path without its leading slash, e.g. prefix
+// "/openclaw" and path "/api" become "/openclaw(?:/|$)(api.*)".
+func rewriteIngressPath(prefix, path string) string {
+	rest := strings.TrimPrefix(path, "/")
+	return regexp.QuoteMeta(prefix) + "(?:/|$)(" + regexp.QuoteMeta(rest) + ".*)"
+}
+
+// ParseIngressPathType maps a spec path type string to its networking/v1 value.
+// An empty string maps to Prefix. The second return value is false for
+// unrecognized values.
+func ParseIngressPathType(s string) (networkingv1.PathType, bool) {
+	switch s {
+	case "", string(networkingv1.PathTypePrefix):
+		return networkingv1.PathTypePrefix, true
+	case string(networkingv1.PathTypeExact):
+		return networkingv1.PathTypeExact, true
+	case string(networkingv1.PathTypeImplementationSpecific):
+		return networkingv1.PathTypeImplementationSpecific, true
+	default:
+		return "", false
+	}
+}
+
 // buildIngressTLS creates TLS configuration from the spec
 func buildIngressTLS(instance *openclawv1alpha1.OpenClawInstance) []networkingv1.IngressTLS {
 	tls := []networkingv1.IngressTLS{}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 OpenClaw.rocks
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
+)
+
+// IsJobMode returns true if the instance runs as a one-off Job instead of a
+// StatefulSet (spec.mode=job). A schedule takes precedence over the mode.
+func IsJobMode(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Mode == WorkloadModeJob && !IsScheduled(instance)
+}
+
+// IsScheduled returns true if the instance runs as a CronJob (spec.schedule).
+func IsScheduled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Schedule != ""
+}
+
+// JobName returns the name of the workload Job. It matches the StatefulSet
+// name since only one of the two exists at a time.
+func JobName(instance *openclawv1alpha1.OpenClawInstance) string {
+	return resourceName(instance, "")
+}
+
+// BuildJob creates the batch/v1 Job that runs the instance when spec.mode is
+// "job". The pod template is the StatefulSet's, so containers, volumes and
+// scheduling stay identical, with two changes: restartPolicy is OnFailure,
+// and the regular sidecars (gateway proxy, Tailscale, Ollama, ...) become
+// native sidecars so the Job completes once the main container exits.
+//
+// The config hash is set as an annotation on the Job so the controller can
+// replace it when the spec changes (Job templates are immutable), even when
+// spec.disableConfigHashAnnotation drops it from the pod template.
+// spec.suspended maps onto the Job's suspend field.
+func BuildJob(instance *openclawv1alpha1.OpenClawInstance, gatewayTokenSecretName string, skillPacks *ResolvedSkillPacks, externalWorkspaceFiles map[string]string, additionalExternalFiles map[string]map[string]string) *batchv1.Job {
+	sts := BuildStatefulSet(instance, gatewayTokenSecretName, skillPacks, externalWorkspaceFiles, additionalExternalFiles)
+	template := sts.Spec.Template
+	template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+
+	// buildContainers always puts the main container first
+	main, sidecars := template.Spec.Containers[0], template.Spec.Containers[1:]
+	for _, c := range sidecars {
+		c.RestartPolicy = Ptr(corev1.ContainerRestartPolicyAlways)
+		template.Spec.InitContainers = append(template.Spec.InitContainers, c)
+	}
+	template.Spec.Containers = []corev1.Container{main}
+
+	hashKey := AnnotationKey("config-hash")
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      JobName(instance),
+			Namespace: instance.Namespace,
+			Labels:    Labels(instance),
+			Annotations: map[string]string{
+				hashKey: calculateConfigHash(instance, externalWorkspaceFiles, additionalExternalFiles),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: Ptr(int32(3)),
+			Suspend:      Ptr(instance.Spec.Suspended),
+			Template:     template,
+		},
+	}
+}
+
+// CronJobName returns the name of the workload CronJob.
+func CronJobName(instance *openclawv1alpha1.OpenClawInstance) string {
+	return resourceName(instance, "")
+}
+
+// BuildCronJob creates the batch/v1 CronJob that runs the instance on
+// spec.schedule. Each run uses the BuildJob template; spec.suspended suspends
+// the schedule rather than individual runs. Concurrency defaults to Forbid so
+// a slow run is never overlapped.
+func BuildCronJob(instance *openclawv1alpha1.OpenClawInstance, gatewayTokenSecretName string, skillPacks *ResolvedSkillPacks, externalWorkspaceFiles map[string]string, additionalExternalFiles map[string]map[string]string) *batchv1.CronJob {
+	job := BuildJob(instance, gatewayTokenSecretName, skillPacks, externalWorkspaceFiles, additionalExternalFiles)
+	job.Spec.Suspend = nil
+
+	spec := instance.Spec.CronJob
+	concurrency := spec.ConcurrencyPolicy
+	if concurrency == "" {
+		concurrency = batchv1.ForbidConcurrent
+	}
+	historyLimit := int32(3)
+	if spec.HistoryLimit != nil {
+		historyLimit = *spec.HistoryLimit
+	}
+	failedHistoryLimit := int32(1)
+	if spec.FailedHistoryLimit != nil {
+		failedHistoryLimit = *spec.FailedHistoryLimit
+	}
+
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CronJobName(instance),
+			Namespace: instance.Namespace,
+			Labels:    Labels(instance),
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   instance.Spec.Schedule,
+			ConcurrencyPolicy:          concurrency,
+			Suspend:                    Ptr(instance.Spec.Suspended),
+			SuccessfulJobsHistoryLimit: &historyLimit,
+			FailedJobsHistoryLimit:     &failedHistoryLimit,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      job.Labels,
+					Annotations: job.Annotations,
+				},
+				Spec: job.Spec,
+			},
+		},
+	}
+}
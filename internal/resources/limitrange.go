@@ -0,0 +1,54 @@
+/*
+Copyright 2026 OpenClaw.rocks
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
+)
+
+// IsLimitRangeEnabled returns true if the instance manages a namespace
+// LimitRange (spec.namespace.limitRange.enabled).
+func IsLimitRangeEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Namespace.LimitRange.Enabled != nil && *instance.Spec.Namespace.LimitRange.Enabled
+}
+
+// BuildLimitRange creates a LimitRange that defaults every container in the
+// namespace to the main container's resources: its limits become the
+// container default and its requests the default request.
+func BuildLimitRange(instance *openclawv1alpha1.OpenClawInstance) *corev1.LimitRange {
+	resources := buildResourceRequirements(instance)
+
+	return &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LimitRangeName(instance),
+			Namespace: instance.Namespace,
+			Labels:    Labels(instance),
+		},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{
+				{
+					Type:           corev1.LimitTypeContainer,
+					Default:        resources.Limits,
+					DefaultRequest: resources.Requests,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 OpenClaw.rocks
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
+)
+
+// ResourceRef identifies a namespaced object by kind and name.
+type ResourceRef struct {
+	Kind string
+	Name string
+}
+
+// ManagedResourceNames returns the kind and name of every object the operator
+// creates for the instance, honoring the same feature gates as the controller.
+// User-provided objects (existingClaim, existingSecret) are not included since
+// the operator does not own them.
+func ManagedResourceNames(instance *openclawv1alpha1.OpenClawInstance) []ResourceRef {
+	workload := ResourceRef{Kind: "StatefulSet", Name: StatefulSetName(instance)}
+	switch {
+	case IsScheduled(instance):
+		workload = ResourceRef{Kind: "CronJob", Name: CronJobName(instance)}
+	case IsJobMode(instance):
+		workload = ResourceRef{Kind: "Job", Name: JobName(instance)}
+	}
+	refs := []ResourceRef{
+		workload,
+		{Kind: "Service", Name: ServiceName(instance)},
+		{Kind: "ConfigMap", Name: ConfigMapName(instance)},
+		{Kind: "ConfigMap", Name: WorkspaceConfigMapName(instance)},
+	}
+
+	// RBAC
+	if instance.Spec.Security.RBAC.CreateServiceAccount == nil || *instance.Spec.Security.RBAC.CreateServiceAccount {
+		refs = append(refs,
+			ResourceRef{Kind: "ServiceAccount", Name: ServiceAccountName(instance)},
+			ResourceRef{Kind: "Role", Name: RoleName(instance)},
+			ResourceRef{Kind: "RoleBinding", Name: RoleBindingName(instance)},
+		)
+	}
+
+	// NetworkPolicy (default: enabled)
+	if instance.Spec.Security.NetworkPolicy.Enabled == nil || *instance.Spec.Security.NetworkPolicy.Enabled {
+		refs = append(refs, ResourceRef{Kind: "NetworkPolicy", Name: NetworkPolicyName(instance)})
+	}
+
+	// Secrets
+	if instance.Spec.Gateway.ExistingSecret == "" {
+		refs = append(refs, ResourceRef{Kind: "Secret", Name: GatewayTokenSecretName(instance)})
+	}
+	if instance.Spec.Tailscale.Enabled {
+		refs = append(refs, ResourceRef{Kind: "Secret", Name: TailscaleStateSecretName(instance)})
+	}
+
+	// Storage - with HPA, per-replica PVCs come from VolumeClaimTemplates instead
+	if IsPersistenceEnabled(instance) && !IsHPAEnabled(instance) &&
+		instance.Spec.Storage.Persistence.ExistingClaim == "" {
+		refs = append(refs, ResourceRef{Kind: "PersistentVolumeClaim", Name: PVCName(instance)})
+	}
+	if instance.Spec.Chromium.Enabled && instance.Spec.Chromium.Persistence.Enabled &&
+		instance.Spec.Chromium.Persistence.ExistingClaim == "" {
+		refs = append(refs, ResourceRef{Kind: "PersistentVolumeClaim", Name: ChromiumPVCName(instance)})
+	}
+
+	if IsLogsPVCEnabled(instance) {
+		refs = append(refs, ResourceRef{Kind: "PersistentVolumeClaim", Name: LogsPVCName(instance)})
+	}
+
+	if IsSkillsJobMode(instance) && BuildSkillsScript(instance) != "" {
+		refs = append(refs, ResourceRef{Kind: "Job", Name: SkillsJobName(instance)})
+	}
+
+	// Availability
+	pdb := instance.Spec.Availability.PodDisruptionBudget
+	if pdb == nil || pdb.Enabled == nil || *pdb.Enabled {
+		refs = append(refs, ResourceRef{Kind: "PodDisruptionBudget", Name: PDBName(instance)})
+	}
+	if IsHPAEnabled(instance) {
+		refs = append(refs, ResourceRef{Kind: "HorizontalPodAutoscaler", Name: HPAName(instance)})
+	}
+
+	// Namespace policy
+	if IsLimitRangeEnabled(instance) {
+		refs = append(refs, ResourceRef{Kind: "LimitRange", Name: LimitRangeName(instance)})
+	}
+	if IsResourceQuotaEnabled(instance) {
+		refs = append(refs, ResourceRef{Kind: "ResourceQuota", Name: ResourceQuotaName(instance)})
+	}
+
+	// Networking
+	if instance.Spec.Chromium.Enabled {
+		refs = append(refs, ResourceRef{Kind: "Service", Name: ChromiumCDPServiceName(instance)})
+	}
+	if instance.Spec.Networking.Ingress.Enabled {
+		refs = append(refs, ResourceRef{Kind: "Ingress", Name: IngressName(instance)})
+		if ba := instance.Spec.Networking.Ingress.Security.BasicAuth; ba != nil && (ba.Enabled == nil || *ba.Enabled) {
+			if ba.ExistingSecret == "" {
+				refs = append(refs, ResourceRef{Kind: "Secret", Name: BasicAuthSecretName(instance)})
+			}
+			if DetectIngressProvider(instance.Spec.Networking.Ingress.ClassName) == IngressProviderTraefik {
+				refs = append(refs, ResourceRef{Kind: "Middleware", Name: TraefikBasicAuthMiddlewareName(instance)})
+			}
+		}
+	}
+
+	// Observability
+	metrics := instance.Spec.Observability.Metrics
+	if metrics.ServiceMonitor != nil && metrics.ServiceMonitor.Enabled != nil && *metrics.ServiceMonitor.Enabled {
+		refs = append(refs, ResourceRef{Kind: "ServiceMonitor", Name: ServiceMonitorName(instance)})
+	}
+	if metrics.PrometheusRule != nil && metrics.PrometheusRule.Enabled != nil && *metrics.PrometheusRule.Enabled {
+		refs = append(refs, ResourceRef{Kind: "PrometheusRule", Name: PrometheusRuleName(instance)})
+	}
+	if IsMetricsIngressEnabled(instance) {
+		refs = append(refs, ResourceRef{Kind: "Ingress", Name: MetricsIngressName(instance)})
+	}
+	if IsMetricsServiceEnabled(instance) {
+		refs = append(refs, ResourceRef{Kind: "Service", Name: MetricsServiceName(instance)})
+	}
+	if IsRulesConfigMapEnabled(instance) {
+		refs = append(refs, ResourceRef{Kind: "ConfigMap", Name: RulesConfigMapName(instance)})
+	}
+	if metrics.GrafanaDashboard != nil && metrics.GrafanaDashboard.Enabled != nil && *metrics.GrafanaDashboard.Enabled {
+		refs = append(refs,
+			ResourceRef{Kind: "ConfigMap", Name: GrafanaDashboardOperatorName(instance)},
+			ResourceRef{Kind: "ConfigMap", Name: GrafanaDashboardInstanceName(instance)},
+		)
+	}
+
+	return refs
+}
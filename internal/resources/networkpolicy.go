@@ -177,15 +177,77 @@ func buildIngressRules(instance *openclawv1alpha1.OpenClawInstance) []networking
 	return rules
 }
 
+// DNSTargetKubeDNS is the spec.security.networkPolicy.dnsTarget value that
+// scopes DNS egress to the kube-dns pods in kube-system
+const DNSTargetKubeDNS = "KubeDNS"
+
+// Egress rule labels returned by EgressRuleSummary
+const (
+	EgressRuleDNS            = "dns"
+	EgressRuleHTTPS          = "https"
+	EgressRuleK8sAPI         = "k8s-api"
+	EgressRuleTailscale      = "tailscale"
+	EgressRuleChromium       = "chromium"
+	EgressRuleOllamaRegistry = "ollama-registry"
+	EgressRuleOllamaExternal = "ollama-external"
+	EgressRuleCustomCIDR     = "custom-cidr"
+	EgressRuleAdditional     = "additional"
+)
+
+// labeledEgressRule is an egress rule with the label EgressRuleSummary reports
+type labeledEgressRule struct {
+	label string
+	rule  networkingv1.NetworkPolicyEgressRule
+}
+
 // buildEgressRules creates the egress rules for the NetworkPolicy
 func buildEgressRules(instance *openclawv1alpha1.OpenClawInstance) []networkingv1.NetworkPolicyEgressRule {
 	rules := []networkingv1.NetworkPolicyEgressRule{}
+	for _, r := range buildLabeledEgressRules(instance) {
+		rules = append(rules, r.rule)
+	}
+	return rules
+}
+
+// EgressRuleSummary returns one label per NetworkPolicy egress rule, in the
+// order BuildNetworkPolicy emits them (e.g. ["dns", "https", "k8s-api",
+// "tailscale"]), so UIs and tests can check intent without indexing raw
+// rules. Rules repeated per CIDR (custom-cidr, ollama-registry, ...) appear
+// once per CIDR, and spec.security.networkPolicy.additionalEgress entries are
+// labeled "additional".
+func EgressRuleSummary(instance *openclawv1alpha1.OpenClawInstance) []string {
+	var labels []string
+	for _, r := range buildLabeledEgressRules(instance) {
+		labels = append(labels, r.label)
+	}
+	return labels
+}
+
+// buildLabeledEgressRules creates the egress rules along with their labels
+func buildLabeledEgressRules(instance *openclawv1alpha1.OpenClawInstance) []labeledEgressRule {
+	var rules []labeledEgressRule
+	add := func(label string, rule networkingv1.NetworkPolicyEgressRule) {
+		rules = append(rules, labeledEgressRule{label: label, rule: rule})
+	}
 
 	// Allow DNS if enabled (default: true)
 	allowDNS := instance.Spec.Security.NetworkPolicy.AllowDNS == nil || *instance.Spec.Security.NetworkPolicy.AllowDNS
 	if allowDNS {
-		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
-			To: []networkingv1.NetworkPolicyPeer{},
+		dnsPeers := []networkingv1.NetworkPolicyPeer{}
+		if instance.Spec.Security.NetworkPolicy.DNSTarget == DNSTargetKubeDNS {
+			dnsPeers = []networkingv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"kubernetes.io/metadata.name": "kube-system"},
+					},
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"k8s-app": "kube-dns"},
+					},
+				},
+			}
+		}
+		add(EgressRuleDNS, networkingv1.NetworkPolicyEgressRule{
+			To: dnsPeers,
 			Ports: []networkingv1.NetworkPolicyPort{
 				{
 					Protocol: Ptr(corev1.ProtocolUDP),
@@ -201,7 +263,7 @@ func buildEgressRules(instance *openclawv1alpha1.OpenClawInstance) []networkingv
 
 	// Allow HTTPS egress for AI APIs (port 443)
 	// This is essential for OpenClaw to communicate with AI providers
-	rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+	add(EgressRuleHTTPS, networkingv1.NetworkPolicyEgressRule{
 		To: []networkingv1.NetworkPolicyPeer{},
 		Ports: []networkingv1.NetworkPolicyPort{
 			{
@@ -216,7 +278,7 @@ func buildEgressRules(instance *openclawv1alpha1.OpenClawInstance) []networkingv
 	// port (e.g., K3s DNATs 443 -> 6443 before NetworkPolicy evaluation).
 	// Tailscale needs this to manage its state secret via the K8s API.
 	if instance.Spec.SelfConfigure.Enabled || instance.Spec.Tailscale.Enabled {
-		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+		add(EgressRuleK8sAPI, networkingv1.NetworkPolicyEgressRule{
 			To: []networkingv1.NetworkPolicyPeer{},
 			Ports: []networkingv1.NetworkPolicyPort{
 				{
@@ -229,7 +291,7 @@ func buildEgressRules(instance *openclawv1alpha1.OpenClawInstance) []networkingv
 
 	// Allow Tailscale STUN and WireGuard egress when enabled
 	if instance.Spec.Tailscale.Enabled {
-		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+		add(EgressRuleTailscale, networkingv1.NetworkPolicyEgressRule{
 			To: []networkingv1.NetworkPolicyPeer{},
 			Ports: []networkingv1.NetworkPolicyPort{
 				{
@@ -249,7 +311,7 @@ func buildEgressRules(instance *openclawv1alpha1.OpenClawInstance) []networkingv
 	// short-circuits self-traffic and doesn't require this rule, but it's
 	// correct to include for portability (e.g. Calico).
 	if instance.Spec.Chromium.Enabled {
-		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+		add(EgressRuleChromium, networkingv1.NetworkPolicyEgressRule{
 			To: []networkingv1.NetworkPolicyPeer{
 				{
 					PodSelector: &metav1.LabelSelector{
@@ -266,9 +328,57 @@ func buildEgressRules(instance *openclawv1alpha1.OpenClawInstance) []networkingv
 		})
 	}
 
+	// Allow HTTPS egress to the Ollama model registry for the init container
+	// that pre-pulls models. NetworkPolicy cannot select registry.ollama.ai by
+	// name, so the registry's address ranges are listed explicitly.
+	if ollama := instance.Spec.Ollama; IsOllamaSidecarEnabled(instance) && len(ollama.Models) > 0 {
+		for _, cidr := range ollama.RegistryCIDRs {
+			add(EgressRuleOllamaRegistry, networkingv1.NetworkPolicyEgressRule{
+				To: []networkingv1.NetworkPolicyPeer{
+					{
+						IPBlock: &networkingv1.IPBlock{
+							CIDR: cidr,
+						},
+					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
+						Protocol: Ptr(corev1.ProtocolTCP),
+						Port:     Ptr(intstr.FromInt(443)),
+					},
+				},
+			})
+		}
+	}
+
+	// Allow egress to an external Ollama server (spec.ollama.external)
+	if IsOllamaExternal(instance) {
+		port := int32(OllamaPort)
+		if p := instance.Spec.Ollama.ExternalPort; p != nil {
+			port = *p
+		}
+		for _, cidr := range instance.Spec.Ollama.ExternalHostCIDRs {
+			add(EgressRuleOllamaExternal, networkingv1.NetworkPolicyEgressRule{
+				To: []networkingv1.NetworkPolicyPeer{
+					{
+						IPBlock: &networkingv1.IPBlock{
+							CIDR: cidr,
+						},
+					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
+						Protocol: Ptr(corev1.ProtocolTCP),
+						Port:     Ptr(intstr.FromInt32(port)),
+					},
+				},
+			})
+		}
+	}
+
 	// Allow additional egress CIDRs if specified
 	for _, cidr := range instance.Spec.Security.NetworkPolicy.AllowedEgressCIDRs {
-		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+		add(EgressRuleCustomCIDR, networkingv1.NetworkPolicyEgressRule{
 			To: []networkingv1.NetworkPolicyPeer{
 				{
 					IPBlock: &networkingv1.IPBlock{
@@ -280,7 +390,9 @@ func buildEgressRules(instance *openclawv1alpha1.OpenClawInstance) []networkingv
 	}
 
 	// Append user-defined additional egress rules
-	rules = append(rules, instance.Spec.Security.NetworkPolicy.AdditionalEgress...)
+	for _, rule := range instance.Spec.Security.NetworkPolicy.AdditionalEgress {
+		add(EgressRuleAdditional, rule)
+	}
 
 	return rules
 }
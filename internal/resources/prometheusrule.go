@@ -17,8 +17,11 @@ limitations under the License.
 package resources
 
 import (
+	"encoding/json"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -27,6 +30,9 @@ import (
 
 const defaultRunbookBaseURL = "https://openclaw.rocks/docs/runbooks"
 
+// RulesConfigMapKey is the data key holding the rule file in the rules ConfigMap
+const RulesConfigMapKey = "openclaw-rules.yaml"
+
 // PrometheusRuleGVK returns the GroupVersionKind for PrometheusRule
 func PrometheusRuleGVK() schema.GroupVersionKind {
 	return schema.GroupVersionKind{
@@ -38,7 +44,21 @@ func PrometheusRuleGVK() schema.GroupVersionKind {
 
 // PrometheusRuleName returns the name of the PrometheusRule
 func PrometheusRuleName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name + "-alerts"
+	return resourceName(instance, "-alerts")
+}
+
+// RulesConfigMapName returns the name of the ConfigMap carrying the rules as a
+// Prometheus rule file
+func RulesConfigMapName(instance *openclawv1alpha1.OpenClawInstance) string {
+	return resourceName(instance, "-rules")
+}
+
+// IsRulesConfigMapEnabled returns true if the rules are also published as a
+// ConfigMap (metrics.rulesAsConfigMap) for Prometheus setups that load rule
+// files through a ConfigMap sidecar instead of PrometheusRule CRDs.
+func IsRulesConfigMapEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	r := instance.Spec.Observability.Metrics.RulesAsConfigMap
+	return r != nil && *r
 }
 
 // BuildPrometheusRule creates an unstructured PrometheusRule for the OpenClawInstance
@@ -56,17 +76,6 @@ func BuildPrometheusRule(instance *openclawv1alpha1.OpenClawInstance) *unstructu
 		}
 	}
 
-	runbookBase := defaultRunbookBaseURL
-	if instance.Spec.Observability.Metrics.PrometheusRule != nil &&
-		instance.Spec.Observability.Metrics.PrometheusRule.RunbookBaseURL != "" {
-		runbookBase = instance.Spec.Observability.Metrics.PrometheusRule.RunbookBaseURL
-	}
-
-	name := instance.Name
-	ns := instance.Namespace
-
-	alerts := buildAlerts(name, ns, runbookBase)
-
 	pr := &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": "monitoring.coreos.com/v1",
@@ -77,12 +86,7 @@ func BuildPrometheusRule(instance *openclawv1alpha1.OpenClawInstance) *unstructu
 				"labels":    toStringInterfaceMap(prLabels),
 			},
 			"spec": map[string]interface{}{
-				"groups": []interface{}{
-					map[string]interface{}{
-						"name":  "openclaw-operator",
-						"rules": alerts,
-					},
-				},
+				"groups": buildRuleGroups(instance),
 			},
 		},
 	}
@@ -90,7 +94,75 @@ func BuildPrometheusRule(instance *openclawv1alpha1.OpenClawInstance) *unstructu
 	return pr
 }
 
-func buildAlerts(name, ns, runbookBase string) []interface{} {
+// BuildRecordingRulesConfigMap creates a ConfigMap holding the same rule
+// groups as BuildPrometheusRule in Prometheus rule file format, labeled
+// prometheus_rule=1 for rule-loading sidecars. JSON is valid YAML, so the
+// file is written as JSON to keep the output deterministic.
+func BuildRecordingRulesConfigMap(instance *openclawv1alpha1.OpenClawInstance) *corev1.ConfigMap {
+	labels := Labels(instance)
+	labels["prometheus_rule"] = "1"
+
+	data, _ := json.MarshalIndent(map[string]interface{}{
+		"groups": buildRuleGroups(instance),
+	}, "", "  ")
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RulesConfigMapName(instance),
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Data: map[string]string{
+			RulesConfigMapKey: string(data),
+		},
+	}
+}
+
+// buildRuleGroups returns the rule groups shared by the PrometheusRule and
+// the rules ConfigMap.
+func buildRuleGroups(instance *openclawv1alpha1.OpenClawInstance) []interface{} {
+	runbookBase := defaultRunbookBaseURL
+	if instance.Spec.Observability.Metrics.PrometheusRule != nil &&
+		instance.Spec.Observability.Metrics.PrometheusRule.RunbookBaseURL != "" {
+		runbookBase = instance.Spec.Observability.Metrics.PrometheusRule.RunbookBaseURL
+	}
+
+	alerts := buildAlerts(instance.Name, StatefulSetName(instance), MainContainerName(instance), instance.Namespace, runbookBase)
+
+	// Apply the configured "for" durations: per-alert overrides win; the
+	// global default only fills in alerts that fire immediately ("0m") and
+	// leaves the built-in durations of the others alone
+	if pr := instance.Spec.Observability.Metrics.PrometheusRule; pr != nil {
+		for _, a := range alerts {
+			alert := a.(map[string]interface{})
+			if pr.DefaultFor != "" && alert["for"] == "0m" {
+				alert["for"] = pr.DefaultFor
+			}
+			if d, ok := pr.AlertFor[alert["alert"].(string)]; ok {
+				alert["for"] = d
+			}
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"name":  "openclaw-operator",
+			"rules": alerts,
+		},
+	}
+}
+
+// BuiltinAlertNames returns the names of the alerts in the generated rules.
+func BuiltinAlertNames() []string {
+	alerts := buildAlerts("", "", "", "", "")
+	names := make([]string, 0, len(alerts))
+	for _, a := range alerts {
+		names = append(names, a.(map[string]interface{})["alert"].(string))
+	}
+	return names
+}
+
+func buildAlerts(name, workload, container, ns, runbookBase string) []interface{} {
 	// Helper to quote a label value in PromQL (avoids sprintfQuotedString lint)
 	q := func(s string) string { return `"` + s + `"` }
 
@@ -121,7 +193,7 @@ func buildAlerts(name, ns, runbookBase string) []interface{} {
 		),
 		buildAlert(
 			"OpenClawPodCrashLooping",
-			`increase(kube_pod_container_status_restarts_total{namespace=`+q(ns)+`,pod=~`+q(name+"-.*")+`,container="openclaw"}[10m]) > 2`,
+			`increase(kube_pod_container_status_restarts_total{namespace=`+q(ns)+`,pod=~`+q(workload+"-.*")+`,container=`+q(container)+`}[10m]) > 2`,
 			"0m",
 			"critical",
 			"OpenClaw pod {{ $labels.pod }} is crash-looping (>2 restarts in 10m).",
@@ -129,7 +201,7 @@ func buildAlerts(name, ns, runbookBase string) []interface{} {
 		),
 		buildAlert(
 			"OpenClawPodOOMKilled",
-			`kube_pod_container_status_last_terminated_reason{reason="OOMKilled",namespace=`+q(ns)+`,pod=~`+q(name+"-.*")+`,container="openclaw"} == 1`,
+			`kube_pod_container_status_last_terminated_reason{reason="OOMKilled",namespace=`+q(ns)+`,pod=~`+q(workload+"-.*")+`,container=`+q(container)+`} == 1`,
 			"0m",
 			"warning",
 			"OpenClaw pod {{ $labels.pod }} was OOM killed. Consider increasing memory limits.",
@@ -137,7 +209,7 @@ func buildAlerts(name, ns, runbookBase string) []interface{} {
 		),
 		buildAlert(
 			"OpenClawPVCNearlyFull",
-			`(kubelet_volume_stats_used_bytes{namespace=`+q(ns)+`,persistentvolumeclaim=~`+q("data-"+name+".*")+`} / kubelet_volume_stats_capacity_bytes{namespace=`+q(ns)+`,persistentvolumeclaim=~`+q("data-"+name+".*")+`}) > 0.80`,
+			`(kubelet_volume_stats_used_bytes{namespace=`+q(ns)+`,persistentvolumeclaim=~`+q("data-"+workload+".*")+`} / kubelet_volume_stats_capacity_bytes{namespace=`+q(ns)+`,persistentvolumeclaim=~`+q("data-"+workload+".*")+`}) > 0.80`,
 			"5m",
 			"warning",
 			"PVC for OpenClaw instance {{ $labels.persistentvolumeclaim }} is over 80% full.",
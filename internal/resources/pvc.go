@@ -42,7 +42,7 @@ func BuildPVC(instance *openclawv1alpha1.OpenClawInstance) *corev1.PersistentVol
 			Namespace: instance.Namespace,
 			Labels:    labels,
 			Annotations: map[string]string{
-				"openclaw.rocks/backup-enabled": "true",
+				AnnotationKey("backup-enabled"): "true",
 			},
 		},
 		Spec: corev1.PersistentVolumeClaimSpec{
@@ -91,3 +91,32 @@ func BuildChromiumPVC(instance *openclawv1alpha1.OpenClawInstance) *corev1.Persi
 
 	return pvc
 }
+
+// BuildLogsPVC creates a PersistentVolumeClaim for the logs volume
+func BuildLogsPVC(instance *openclawv1alpha1.OpenClawInstance) *corev1.PersistentVolumeClaim {
+	labels := Labels(instance)
+
+	size := ParseQuantity(instance.Spec.Storage.Logs.Size, "1Gi")
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LogsPVCName(instance),
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+
+	if instance.Spec.Storage.Logs.StorageClass != nil {
+		pvc.Spec.StorageClassName = instance.Spec.Storage.Logs.StorageClass
+	}
+
+	return pvc
+}
@@ -37,7 +37,7 @@ func BuildServiceAccount(instance *openclawv1alpha1.OpenClawInstance) *corev1.Se
 			Labels:      labels,
 			Annotations: instance.Spec.Security.RBAC.ServiceAccountAnnotations,
 		},
-		AutomountServiceAccountToken: Ptr(instance.Spec.SelfConfigure.Enabled || instance.Spec.Tailscale.Enabled),
+		AutomountServiceAccountToken: Ptr(instance.Spec.SelfConfigure.Enabled || instance.Spec.Tailscale.Enabled || automountServiceAccountToken(instance)),
 	}
 }
 
@@ -46,19 +46,30 @@ func BuildServiceAccount(instance *openclawv1alpha1.OpenClawInstance) *corev1.Se
 func BuildRole(instance *openclawv1alpha1.OpenClawInstance) *rbacv1.Role {
 	labels := Labels(instance)
 
+	// OpenClaw only needs to read its own config
+	configMapVerbs := []string{"get", "watch"}
+	if verbs := instance.Spec.Security.RBAC.ConfigMapVerbs; len(verbs) > 0 {
+		configMapVerbs = append([]string(nil), verbs...)
+	}
+
 	// Base rules - minimal permissions needed by OpenClaw
 	rules := []rbacv1.PolicyRule{
-		// OpenClaw only needs to read its own config
 		{
 			APIGroups:     []string{""},
 			Resources:     []string{"configmaps"},
 			ResourceNames: []string{ConfigMapName(instance)},
-			Verbs:         []string{"get", "watch"},
+			Verbs:         configMapVerbs,
 		},
 	}
 
 	// Self-configure RBAC rules - give the agent access to K8s API
 	if instance.Spec.SelfConfigure.Enabled {
+		readOnly := IsSelfConfigureReadOnly(instance)
+		selfConfigVerbs := []string{"create", "get", "list"}
+		if readOnly {
+			selfConfigVerbs = []string{"get", "list", "watch"}
+		}
+
 		// Read own OpenClawInstance (scoped by resourceNames) + create/read self-config requests
 		rules = append(rules,
 			rbacv1.PolicyRule{
@@ -70,10 +81,24 @@ func BuildRole(instance *openclawv1alpha1.OpenClawInstance) *rbacv1.Role {
 			rbacv1.PolicyRule{
 				APIGroups: []string{"openclaw.rocks"},
 				Resources: []string{"openclawselfconfigs"},
-				Verbs:     []string{"create", "get", "list"},
+				Verbs:     selfConfigVerbs,
 			},
 		)
 
+		// Extra ConfigMaps the agent may read (and optionally update)
+		if cms := instance.Spec.SelfConfigure.AdditionalConfigMaps; len(cms) > 0 {
+			verbs := []string{"get", "list", "watch"}
+			if w := instance.Spec.SelfConfigure.AdditionalConfigMapsWritable; w != nil && *w && !readOnly {
+				verbs = append(verbs, "update", "patch")
+			}
+			rules = append(rules, rbacv1.PolicyRule{
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				ResourceNames: append([]string(nil), cms...),
+				Verbs:         verbs,
+			})
+		}
+
 		// Read own referenced secrets (scoped by resourceNames)
 		if secretNames := selfConfigSecretNames(instance); len(secretNames) > 0 {
 			rules = append(rules, rbacv1.PolicyRule{
@@ -114,6 +139,13 @@ func BuildRole(instance *openclawv1alpha1.OpenClawInstance) *rbacv1.Role {
 	}
 }
 
+// IsSelfConfigureReadOnly returns true when self-configure is limited to
+// reading the instance's own configuration.
+func IsSelfConfigureReadOnly(instance *openclawv1alpha1.OpenClawInstance) bool {
+	ro := instance.Spec.SelfConfigure.ReadOnly
+	return ro != nil && *ro
+}
+
 // selfConfigSecretNames collects all secret names referenced by the instance (deduplicated).
 func selfConfigSecretNames(instance *openclawv1alpha1.OpenClawInstance) []string {
 	seen := make(map[string]bool)
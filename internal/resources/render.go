@@ -0,0 +1,179 @@
+/*
+Copyright 2026 OpenClaw.rocks
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
+)
+
+// RenderAll returns every object the operator would create for the instance,
+// in the order of ManagedResourceNames, so a CLI can print them for a dry
+// run. It calls the same builders and honors the same feature gates as the
+// controller, and fails on the validation errors that would block a
+// reconcile.
+//
+// Rendering is offline: external ConfigMaps (config, workspace) are not
+// resolved, so the config is built from the inline raw config, and the
+// generated gateway token and basic auth password are left empty. Typed
+// objects have their apiVersion and kind set so they print as complete
+// manifests.
+func RenderAll(instance *openclawv1alpha1.OpenClawInstance) ([]runtime.Object, error) {
+	for _, validate := range []func(*openclawv1alpha1.OpenClawInstance) error{
+		ValidateResources, ValidateStorage, ValidatePorts, ValidateConfigOverlay, ValidateExtraConfigMapData,
+	} {
+		if err := validate(instance); err != nil {
+			return nil, err
+		}
+	}
+
+	gatewayTokenSecretName := GatewayTokenSecretName(instance)
+	if instance.Spec.Gateway.ExistingSecret != "" {
+		gatewayTokenSecretName = instance.Spec.Gateway.ExistingSecret
+	}
+	configMap, err := BuildConfigMapStrict(instance, nil, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var objs []runtime.Object
+	add := func(obj runtime.Object) {
+		// Builders return typed nil pointers when there is nothing to create
+		if obj == nil || reflect.ValueOf(obj).IsNil() {
+			return
+		}
+		objs = append(objs, obj)
+	}
+
+	switch {
+	case IsScheduled(instance):
+		add(BuildCronJob(instance, gatewayTokenSecretName, nil, nil, nil))
+	case IsJobMode(instance):
+		add(BuildJob(instance, gatewayTokenSecretName, nil, nil, nil))
+	default:
+		add(BuildStatefulSet(instance, gatewayTokenSecretName, nil, nil, nil))
+	}
+	add(BuildService(instance))
+	add(configMap)
+	add(BuildWorkspaceConfigMap(instance, nil, nil, nil))
+
+	// RBAC
+	if instance.Spec.Security.RBAC.CreateServiceAccount == nil || *instance.Spec.Security.RBAC.CreateServiceAccount {
+		add(BuildServiceAccount(instance))
+		add(BuildRole(instance))
+		add(BuildRoleBinding(instance))
+	}
+
+	// NetworkPolicy (default: enabled)
+	if instance.Spec.Security.NetworkPolicy.Enabled == nil || *instance.Spec.Security.NetworkPolicy.Enabled {
+		add(BuildNetworkPolicy(instance))
+	}
+
+	// Secrets
+	if instance.Spec.Gateway.ExistingSecret == "" {
+		add(BuildGatewayTokenSecret(instance, ""))
+	}
+	if instance.Spec.Tailscale.Enabled {
+		add(BuildTailscaleStateSecret(instance))
+	}
+
+	// Storage
+	if IsPersistenceEnabled(instance) && !IsHPAEnabled(instance) &&
+		instance.Spec.Storage.Persistence.ExistingClaim == "" {
+		add(BuildPVC(instance))
+	}
+	if instance.Spec.Chromium.Enabled && instance.Spec.Chromium.Persistence.Enabled &&
+		instance.Spec.Chromium.Persistence.ExistingClaim == "" {
+		add(BuildChromiumPVC(instance))
+	}
+	if IsLogsPVCEnabled(instance) {
+		add(BuildLogsPVC(instance))
+	}
+	if IsSkillsJobMode(instance) && BuildSkillsScript(instance) != "" {
+		add(BuildSkillsJob(instance))
+	}
+
+	// Availability
+	pdb := instance.Spec.Availability.PodDisruptionBudget
+	if pdb == nil || pdb.Enabled == nil || *pdb.Enabled {
+		add(BuildPDB(instance))
+	}
+	if IsHPAEnabled(instance) {
+		add(BuildHPA(instance))
+	}
+
+	// Namespace policy
+	if IsLimitRangeEnabled(instance) {
+		add(BuildLimitRange(instance))
+	}
+	if IsResourceQuotaEnabled(instance) {
+		add(BuildResourceQuota(instance))
+	}
+
+	// Networking
+	if instance.Spec.Chromium.Enabled {
+		add(BuildChromiumCDPService(instance))
+	}
+	if instance.Spec.Networking.Ingress.Enabled {
+		add(BuildIngress(instance))
+		if ba := instance.Spec.Networking.Ingress.Security.BasicAuth; ba != nil && (ba.Enabled == nil || *ba.Enabled) {
+			if ba.ExistingSecret == "" {
+				add(BuildBasicAuthSecret(instance, ""))
+			}
+			if DetectIngressProvider(instance.Spec.Networking.Ingress.ClassName) == IngressProviderTraefik {
+				add(BuildTraefikBasicAuthMiddleware(instance))
+			}
+		}
+	}
+
+	// Observability
+	metrics := instance.Spec.Observability.Metrics
+	if metrics.ServiceMonitor != nil && metrics.ServiceMonitor.Enabled != nil && *metrics.ServiceMonitor.Enabled {
+		add(BuildServiceMonitor(instance))
+	}
+	if metrics.PrometheusRule != nil && metrics.PrometheusRule.Enabled != nil && *metrics.PrometheusRule.Enabled {
+		add(BuildPrometheusRule(instance))
+	}
+	if IsMetricsIngressEnabled(instance) {
+		add(BuildMetricsIngress(instance))
+	}
+	if IsMetricsServiceEnabled(instance) {
+		add(BuildMetricsService(instance))
+	}
+	if metrics.GrafanaDashboard != nil && metrics.GrafanaDashboard.Enabled != nil && *metrics.GrafanaDashboard.Enabled {
+		add(BuildGrafanaDashboardOperator(instance))
+		add(BuildGrafanaDashboardInstance(instance))
+	}
+
+	for _, obj := range objs {
+		if _, ok := obj.(*unstructured.Unstructured); ok {
+			continue
+		}
+		gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+		if err != nil {
+			return nil, fmt.Errorf("rendering %T: %w", obj, err)
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+	}
+	return objs, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 OpenClaw.rocks
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
+)
+
+// IsResourceQuotaEnabled returns true if the instance manages a namespace
+// ResourceQuota (spec.namespace.resourceQuota.enabled).
+func IsResourceQuotaEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Namespace.ResourceQuota.Enabled != nil && *instance.Spec.Namespace.ResourceQuota.Enabled
+}
+
+// BuildResourceQuota creates a ResourceQuota from
+// spec.namespace.resourceQuota. Only the limits that are set become hard
+// limits; ValidateResources rejects invalid quantities beforehand.
+func BuildResourceQuota(instance *openclawv1alpha1.OpenClawInstance) *corev1.ResourceQuota {
+	spec := instance.Spec.Namespace.ResourceQuota
+	hard := corev1.ResourceList{}
+	for name, value := range map[corev1.ResourceName]string{
+		corev1.ResourceRequestsCPU:    spec.Requests.CPU,
+		corev1.ResourceRequestsMemory: spec.Requests.Memory,
+		corev1.ResourceLimitsCPU:      spec.Limits.CPU,
+		corev1.ResourceLimitsMemory:   spec.Limits.Memory,
+	} {
+		if value == "" {
+			continue
+		}
+		if q, err := resource.ParseQuantity(value); err == nil {
+			hard[name] = q
+		}
+	}
+	if spec.Pods != nil {
+		hard[corev1.ResourcePods] = *resource.NewQuantity(int64(*spec.Pods), resource.DecimalSI)
+	}
+	if spec.PersistentVolumeClaims != nil {
+		hard[corev1.ResourcePersistentVolumeClaims] = *resource.NewQuantity(int64(*spec.PersistentVolumeClaims), resource.DecimalSI)
+	}
+
+	return &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ResourceQuotaName(instance),
+			Namespace: instance.Namespace,
+			Labels:    Labels(instance),
+		},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: hard,
+		},
+	}
+}
@@ -20,10 +20,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"testing"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -105,6 +108,30 @@ func TestSelectorLabels_SubsetOfLabels(t *testing.T) {
 	}
 }
 
+func TestLabels_InstanceUID(t *testing.T) {
+	instance := newTestInstance("uid")
+	if _, ok := Labels(instance)[InstanceUIDLabel]; ok {
+		t.Error("instance-uid label should be omitted when the UID is empty")
+	}
+
+	instance.UID = "0b9f7c1e-3d2a-4c5b-9e8f-1a2b3c4d5e6f"
+	if got := Labels(instance)[InstanceUIDLabel]; got != string(instance.UID) {
+		t.Errorf("instance-uid label = %q, want %q", got, instance.UID)
+	}
+	if _, ok := SelectorLabels(instance)[InstanceUIDLabel]; ok {
+		t.Error("instance-uid label must not be part of the selector labels")
+	}
+
+	// Selectors stay on the stable labels; the pod template carries the UID
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	if _, ok := sts.Spec.Selector.MatchLabels[InstanceUIDLabel]; ok {
+		t.Error("StatefulSet selector must not include the instance-uid label")
+	}
+	if sts.Spec.Template.Labels[InstanceUIDLabel] != string(instance.UID) {
+		t.Error("pod template should carry the instance-uid label")
+	}
+}
+
 func TestGetImage(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -147,6 +174,30 @@ func TestGetImage(t *testing.T) {
 			},
 			expected: "custom.io/img:latest",
 		},
+		{
+			name: "variant suffix on default tag",
+			image: openclawv1alpha1.ImageSpec{
+				Variant: "arm64",
+			},
+			expected: "ghcr.io/openclaw/openclaw:latest-arm64",
+		},
+		{
+			name: "variant suffix on custom tag",
+			image: openclawv1alpha1.ImageSpec{
+				Tag:     "v1.2.3",
+				Variant: "amd64",
+			},
+			expected: "ghcr.io/openclaw/openclaw:v1.2.3-amd64",
+		},
+		{
+			name: "digest takes precedence over variant",
+			image: openclawv1alpha1.ImageSpec{
+				Tag:     "v1.2.3",
+				Variant: "arm64",
+				Digest:  "sha256:abc123",
+			},
+			expected: "ghcr.io/openclaw/openclaw@sha256:abc123",
+		},
 	}
 
 	for _, tt := range tests {
@@ -162,6 +213,85 @@ func TestGetImage(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_PullPolicyNeverPropagates(t *testing.T) {
+	instance := newTestInstance("pull-never")
+	instance.Spec.Image.PullPolicy = corev1.PullNever
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Ollama.Models = []string{"llama3.2"}
+	instance.Spec.WebTerminal.Enabled = true
+	instance.Spec.Tailscale.Enabled = true
+	instance.Spec.Skills = []string{"@anthropic/mcp-server-fetch"}
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	podSpec := sts.Spec.Template.Spec
+
+	all := append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...)
+	for _, c := range all {
+		if c.ImagePullPolicy != corev1.PullNever {
+			t.Errorf("container %q pullPolicy = %q, want Never", c.Name, c.ImagePullPolicy)
+		}
+	}
+}
+
+func TestValidateImage(t *testing.T) {
+	instance := newTestInstance("validate-image")
+	instance.Spec.Image.Tag = "latest"
+
+	// IfNotPresent never warns
+	if warnings, err := ValidateImage(instance); err != nil || len(warnings) != 0 {
+		t.Fatalf("expected no findings for IfNotPresent, got %v, %v", warnings, err)
+	}
+
+	instance.Spec.Image.PullPolicy = corev1.PullNever
+	warnings, err := ValidateImage(instance)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "latest") {
+		t.Errorf("expected latest+Never warning, got %v", warnings)
+	}
+
+	instance.Spec.Image.Tag = "v1.2.3"
+	if warnings, _ := ValidateImage(instance); len(warnings) != 0 {
+		t.Errorf("pinned tag should not warn, got %v", warnings)
+	}
+
+	instance.Spec.Image.Digest = "sha256:" + strings.Repeat("a", 64)
+	if _, err := ValidateImage(instance); err != nil {
+		t.Errorf("valid digest with Never should pass, got %v", err)
+	}
+
+	instance.Spec.Image.Digest = "abc123"
+	if _, err := ValidateImage(instance); err == nil {
+		t.Error("expected error for malformed digest with Never")
+	}
+}
+
+func TestSidecarImageVariants(t *testing.T) {
+	instance := newTestInstance("variants")
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Chromium.Image = openclawv1alpha1.ChromiumImageSpec{Repository: "example.com/chromium", Tag: "v1", Variant: "arm64"}
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Ollama.Image = openclawv1alpha1.OllamaImageSpec{Tag: "0.5.0", Variant: "rocm"}
+	instance.Spec.WebTerminal.Enabled = true
+	instance.Spec.WebTerminal.Image = openclawv1alpha1.WebTerminalImageSpec{Tag: "1.7.7", Variant: "arm64", Digest: "sha256:fff"}
+	instance.Spec.Tailscale.Image = openclawv1alpha1.TailscaleImageSpec{Tag: "v1.80.0", Variant: "arm64"}
+
+	if got := buildChromiumContainer(instance, 0).Image; got != "example.com/chromium:v1-arm64" {
+		t.Errorf("chromium image = %q, want %q", got, "example.com/chromium:v1-arm64")
+	}
+	if got := buildOllamaContainer(instance).Image; got != "ollama/ollama:0.5.0-rocm" {
+		t.Errorf("ollama image = %q, want %q", got, "ollama/ollama:0.5.0-rocm")
+	}
+	if got := buildWebTerminalContainer(instance).Image; got != "tsl0922/ttyd@sha256:fff" {
+		t.Errorf("web terminal image = %q, digest should take precedence over variant", got)
+	}
+	if got := GetTailscaleImage(instance); got != "ghcr.io/tailscale/tailscale:v1.80.0-arm64" {
+		t.Errorf("tailscale image = %q, want %q", got, "ghcr.io/tailscale/tailscale:v1.80.0-arm64")
+	}
+}
+
 func TestNameHelpers(t *testing.T) {
 	instance := newTestInstance("foo")
 
@@ -192,6 +322,63 @@ func TestNameHelpers(t *testing.T) {
 	}
 }
 
+func TestNameHelpers_PrefixSuffix(t *testing.T) {
+	instance := newTestInstance("foo")
+	instance.Spec.NamePrefix = "team-"
+	instance.Spec.NameSuffix = "-blue"
+
+	tests := []struct {
+		name     string
+		fn       func(*openclawv1alpha1.OpenClawInstance) string
+		expected string
+	}{
+		{"StatefulSetName", StatefulSetName, "team-foo-blue"},
+		{"DeploymentName", DeploymentName, "foo"},
+		{"ServiceName", ServiceName, "team-foo-blue"},
+		{"ChromiumCDPServiceName", ChromiumCDPServiceName, "team-foo-cdp-blue"},
+		{"ServiceAccountName", ServiceAccountName, "team-foo-blue"},
+		{"RoleName", RoleName, "team-foo-blue"},
+		{"RoleBindingName", RoleBindingName, "team-foo-blue"},
+		{"ConfigMapName", ConfigMapName, "team-foo-config-blue"},
+		{"WorkspaceConfigMapName", WorkspaceConfigMapName, "team-foo-workspace-blue"},
+		{"PVCName", PVCName, "team-foo-data-blue"},
+		{"ChromiumPVCName", ChromiumPVCName, "team-foo-chromium-data-blue"},
+		{"NetworkPolicyName", NetworkPolicyName, "team-foo-blue"},
+		{"PDBName", PDBName, "team-foo-blue"},
+		{"IngressName", IngressName, "team-foo-blue"},
+		{"GatewayTokenSecretName", GatewayTokenSecretName, "team-foo-gateway-token-blue"},
+		{"TailscaleStateSecretName", TailscaleStateSecretName, "team-foo-ts-state-blue"},
+		{"HPAName", HPAName, "team-foo-blue"},
+		{"ServiceMonitorName", ServiceMonitorName, "team-foo-metrics-blue"},
+		{"LegacyServiceMonitorName", LegacyServiceMonitorName, "team-foo-blue"},
+		{"PrometheusRuleName", PrometheusRuleName, "team-foo-alerts-blue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(instance); got != tt.expected {
+				t.Errorf("%s() = %q, want %q", tt.name, got, tt.expected)
+			}
+		})
+	}
+
+	// Selector labels track the CR name, not the generated object names
+	if got := SelectorLabels(instance)["app.kubernetes.io/instance"]; got != "foo" {
+		t.Errorf("selector instance label = %q, want %q", got, "foo")
+	}
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	if sts.Name != "team-foo-blue" {
+		t.Errorf("StatefulSet name = %q, want %q", sts.Name, "team-foo-blue")
+	}
+	if sts.Spec.ServiceName != "team-foo-blue" {
+		t.Errorf("StatefulSet serviceName = %q, want %q", sts.Spec.ServiceName, "team-foo-blue")
+	}
+	if sts.Spec.Template.Spec.ServiceAccountName != "team-foo-blue" {
+		t.Errorf("pod serviceAccountName = %q, want %q", sts.Spec.Template.Spec.ServiceAccountName, "team-foo-blue")
+	}
+}
+
 func TestServiceAccountName_Default(t *testing.T) {
 	instance := newTestInstance("my-inst")
 	if got := ServiceAccountName(instance); got != "my-inst" {
@@ -731,6 +918,48 @@ func TestBuildStatefulSet_CustomResources(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_GuaranteedResources(t *testing.T) {
+	instance := newTestInstance("res-guaranteed")
+	instance.Spec.Resources = openclawv1alpha1.ResourcesSpec{
+		Requests:   openclawv1alpha1.ResourceList{CPU: "1"},
+		Limits:     openclawv1alpha1.ResourceList{Memory: "8Gi"},
+		Guaranteed: Ptr(true),
+	}
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Chromium.Resources.Guaranteed = Ptr(true)
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	main := sts.Spec.Template.Spec.Containers[0]
+	if !equality.Semantic.DeepEqual(main.Resources.Limits, main.Resources.Requests) {
+		t.Errorf("limits %v should mirror requests %v", main.Resources.Limits, main.Resources.Requests)
+	}
+	// The CPU request wins; memory has only a limit, which is used for both
+	if cpu := main.Resources.Limits[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("1")) != 0 {
+		t.Errorf("cpu = %v, want 1", cpu.String())
+	}
+	if mem := main.Resources.Requests[corev1.ResourceMemory]; mem.Cmp(resource.MustParse("8Gi")) != 0 {
+		t.Errorf("memory = %v, want 8Gi", mem.String())
+	}
+
+	// Sidecars honor their own guaranteed setting, with defaults as requests
+	var chromium corev1.Container
+	for _, c := range sts.Spec.Template.Spec.InitContainers {
+		if c.Name == "chromium" {
+			chromium = c
+		}
+	}
+	if !equality.Semantic.DeepEqual(chromium.Resources.Limits, chromium.Resources.Requests) {
+		t.Errorf("chromium limits %v should mirror requests %v", chromium.Resources.Limits, chromium.Resources.Requests)
+	}
+
+	// Without guaranteed the Burstable defaults stay
+	instance.Spec.Resources.Guaranteed = nil
+	main = BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.Containers[0]
+	if cpu := main.Resources.Limits[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("2000m")) != 0 {
+		t.Errorf("default cpu limit = %v, want 2000m", cpu.String())
+	}
+}
+
 func TestBuildStatefulSet_ImageDigest(t *testing.T) {
 	instance := newTestInstance("digest-test")
 	instance.Spec.Image = openclawv1alpha1.ImageSpec{
@@ -1266,6 +1495,92 @@ func TestBuildStatefulSet_TopologySpreadConstraints_Empty(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_SpreadAcrossNodes(t *testing.T) {
+	instance := newTestInstance("spread")
+
+	// A single replica needs no spread
+	if tsc := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.TopologySpreadConstraints; len(tsc) != 0 {
+		t.Errorf("expected no constraints for one replica, got %v", tsc)
+	}
+
+	instance.Spec.Availability.AutoScaling = &openclawv1alpha1.AutoScalingSpec{Enabled: Ptr(true)}
+	zone := corev1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+	}
+	instance.Spec.Availability.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{zone}
+
+	tsc := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.TopologySpreadConstraints
+	if len(tsc) != 2 {
+		t.Fatalf("expected zone + hostname constraints, got %d", len(tsc))
+	}
+	if !equality.Semantic.DeepEqual(tsc[0], zone) {
+		t.Errorf("user constraint should come first unchanged, got %+v", tsc[0])
+	}
+	host := tsc[1]
+	if host.TopologyKey != corev1.LabelHostname || host.MaxSkew != 1 || host.WhenUnsatisfiable != corev1.ScheduleAnyway {
+		t.Errorf("unexpected hostname constraint %+v", host)
+	}
+	if host.LabelSelector == nil || !maps.Equal(host.LabelSelector.MatchLabels, SelectorLabels(instance)) {
+		t.Errorf("hostname constraint should select the instance pods, got %v", host.LabelSelector)
+	}
+	if len(instance.Spec.Availability.TopologySpreadConstraints) != 1 {
+		t.Error("spec constraints should not be mutated")
+	}
+
+	// An explicit hostname constraint wins
+	userHost := corev1.TopologySpreadConstraint{MaxSkew: 2, TopologyKey: corev1.LabelHostname, WhenUnsatisfiable: corev1.DoNotSchedule}
+	instance.Spec.Availability.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{userHost}
+	tsc = BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.TopologySpreadConstraints
+	if len(tsc) != 1 || tsc[0].MaxSkew != 2 {
+		t.Errorf("expected only the user's hostname constraint, got %+v", tsc)
+	}
+
+	// Opt-out
+	instance.Spec.Availability.TopologySpreadConstraints = nil
+	instance.Spec.Availability.SpreadAcrossNodes = Ptr(false)
+	if tsc := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.TopologySpreadConstraints; len(tsc) != 0 {
+		t.Errorf("expected no constraints when disabled, got %v", tsc)
+	}
+}
+
+func TestBuildStatefulSet_ReadinessGates(t *testing.T) {
+	instance := newTestInstance("readiness-gates")
+	if gates := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.ReadinessGates; len(gates) != 0 {
+		t.Errorf("expected no readiness gates by default, got %v", gates)
+	}
+
+	instance.Spec.Availability.ReadinessGates = []corev1.PodReadinessGate{
+		{ConditionType: "example.com/registered"},
+	}
+	gates := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.ReadinessGates
+	if len(gates) != 1 || gates[0].ConditionType != "example.com/registered" {
+		t.Errorf("readiness gates = %v, want [example.com/registered]", gates)
+	}
+}
+
+func TestBuildStatefulSet_Subdomain(t *testing.T) {
+	instance := newTestInstance("subdomain")
+	if sub := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.Subdomain; sub != "" {
+		t.Errorf("expected no subdomain by default, got %q", sub)
+	}
+
+	instance.Spec.Availability.Subdomain = "agents"
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	if sub := sts.Spec.Template.Spec.Subdomain; sub != "agents" {
+		t.Errorf("subdomain = %q, want %q", sub, "agents")
+	}
+	if sts.Spec.ServiceName != ServiceName(instance) {
+		t.Errorf("serviceName = %q, want %q", sts.Spec.ServiceName, ServiceName(instance))
+	}
+
+	instance.Spec.Mode = WorkloadModeJob
+	if sub := BuildJob(instance, "", nil, nil, nil).Spec.Template.Spec.Subdomain; sub != "agents" {
+		t.Errorf("job subdomain = %q, want %q", sub, "agents")
+	}
+}
+
 func TestBuildStatefulSet_RuntimeClassName(t *testing.T) {
 	instance := newTestInstance("rtc-test")
 	instance.Spec.Availability.RuntimeClassName = Ptr("kata-fc")
@@ -1292,6 +1607,28 @@ func TestBuildStatefulSet_RuntimeClassName_Unset(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_StatefulSetAnnotations(t *testing.T) {
+	instance := newTestInstance("sts-annotations")
+	if ann := BuildStatefulSet(instance, "", nil, nil, nil).Annotations; len(ann) != 0 {
+		t.Errorf("StatefulSet should have no annotations by default, got %v", ann)
+	}
+
+	const key = "argocd.argoproj.io/sync-options"
+	instance.Spec.StatefulSetAnnotations = map[string]string{key: "Replace=true"}
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+
+	if sts.Annotations[key] != "Replace=true" {
+		t.Errorf("StatefulSet annotation %s = %q, want Replace=true", key, sts.Annotations[key])
+	}
+	if _, ok := sts.Spec.Template.Annotations[key]; ok {
+		t.Error("StatefulSet annotations should not be copied to the pod template")
+	}
+	sts.Annotations["other"] = "x"
+	if _, ok := instance.Spec.StatefulSetAnnotations["other"]; ok {
+		t.Error("StatefulSet annotations should not alias the spec map")
+	}
+}
+
 func TestBuildStatefulSet_PodAnnotations_UserAnnotationsPresent(t *testing.T) {
 	instance := newTestInstance("pod-ann-test")
 	instance.Spec.PodAnnotations = map[string]string{
@@ -1338,101 +1675,518 @@ func TestBuildStatefulSet_PodAnnotations_NilStillHasConfigHash(t *testing.T) {
 	}
 }
 
-func TestBuildStatefulSet_EnvAndEnvFrom(t *testing.T) {
-	instance := newTestInstance("env-test")
-	instance.Spec.Env = []corev1.EnvVar{
-		{Name: "MY_VAR", Value: "my-value"},
+func TestBuildStatefulSet_EnableServiceLinks(t *testing.T) {
+	instance := newTestInstance("svc-links")
+	got := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.EnableServiceLinks
+	if got == nil || *got {
+		t.Errorf("enableServiceLinks should be explicitly false by default, got %v", got)
 	}
-	instance.Spec.EnvFrom = []corev1.EnvFromSource{
-		{
-			SecretRef: &corev1.SecretEnvSource{
-				LocalObjectReference: corev1.LocalObjectReference{Name: "api-keys"},
-			},
-		},
+
+	instance.Spec.EnableServiceLinks = Ptr(true)
+	got = BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.EnableServiceLinks
+	if got == nil || !*got {
+		t.Error("enableServiceLinks should be true when enabled")
 	}
+}
 
-	sts := BuildStatefulSet(instance, "", nil, nil, nil)
-	main := sts.Spec.Template.Spec.Containers[0]
+func TestBuildStatefulSet_ShareProcessNamespace(t *testing.T) {
+	instance := newTestInstance("share-pid")
+	if got := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.ShareProcessNamespace; got != nil {
+		t.Errorf("shareProcessNamespace should be unset by default, got %v", *got)
+	}
 
-	names := envNames(main.Env)
-	expectedPrefix := []string{"HOME", "OPENCLAW_DISABLE_BONJOUR", "OPENCLAW_GATEWAY_HANDSHAKE_TIMEOUT_MS", "NPM_CONFIG_PREFIX", "NPM_CONFIG_CACHE", "PIP_USER"}
-	for i, want := range expectedPrefix {
-		if i >= len(names) || names[i] != want {
-			t.Fatalf("env vars should start with %v, got %v", expectedPrefix, names)
-		}
+	instance.Spec.Security.ShareProcessNamespace = Ptr(true)
+	got := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.ShareProcessNamespace
+	if got == nil || !*got {
+		t.Error("shareProcessNamespace should be true when enabled")
 	}
-	// User-defined vars come after operator-injected vars
-	if names[len(names)-1] != "MY_VAR" {
-		t.Errorf("user-defined MY_VAR should be last, got %v", names)
+}
+
+func TestBuildStatefulSet_RestartAtAnnotation(t *testing.T) {
+	instance := newTestInstance("restart-at")
+	before := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Annotations
+	if _, ok := before["openclaw.rocks/restart-at"]; ok {
+		t.Error("restart-at should not be set without the instance annotation")
 	}
-	if len(main.EnvFrom) != 1 || main.EnvFrom[0].SecretRef.Name != "api-keys" {
-		t.Error("envFrom not passed through")
+
+	instance.Annotations = map[string]string{"openclaw.rocks/restart-at": "2026-01-02T03:04:05Z"}
+	ann := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Annotations
+	if ann["openclaw.rocks/restart-at"] != "2026-01-02T03:04:05Z" {
+		t.Errorf("restart-at = %q, want it copied from the instance", ann["openclaw.rocks/restart-at"])
+	}
+	if ann["openclaw.rocks/config-hash"] != before["openclaw.rocks/config-hash"] {
+		t.Error("restart-at must not change the config hash")
 	}
 }
 
-// ---------------------------------------------------------------------------
-// service.go tests
-// ---------------------------------------------------------------------------
+func TestBuildStatefulSet_ProbesExecCommand(t *testing.T) {
+	instance := newTestInstance("exec-probe")
+	main := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.Containers[0]
+	for name, p := range map[string]*corev1.Probe{"liveness": main.LivenessProbe, "readiness": main.ReadinessProbe, "startup": main.StartupProbe} {
+		if p.HTTPGet == nil || p.Exec != nil {
+			t.Errorf("%s: expected default HTTP GET handler, got %+v", name, p.ProbeHandler)
+		}
+	}
 
-func TestBuildService_Default(t *testing.T) {
-	instance := newTestInstance("svc-test")
-	svc := BuildService(instance)
+	cmd := []string{"/usr/local/bin/curl", "-fsS", "http://127.0.0.1:18789/healthz"}
+	instance.Spec.Probes = &openclawv1alpha1.ProbesSpec{
+		ExecCommand: cmd,
+		Liveness:    &openclawv1alpha1.ProbeSpec{PeriodSeconds: Ptr(int32(20))},
+	}
+	main = BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.Containers[0]
+	for name, p := range map[string]*corev1.Probe{"liveness": main.LivenessProbe, "readiness": main.ReadinessProbe, "startup": main.StartupProbe} {
+		if p.HTTPGet != nil || p.Exec == nil {
+			t.Fatalf("%s: expected exec handler, got %+v", name, p.ProbeHandler)
+		}
+		if strings.Join(p.Exec.Command, " ") != strings.Join(cmd, " ") {
+			t.Errorf("%s: exec command = %v, want %v", name, p.Exec.Command, cmd)
+		}
+	}
+	if main.LivenessProbe.PeriodSeconds != 20 {
+		t.Errorf("liveness periodSeconds = %d, want 20 (timing overrides still apply)", main.LivenessProbe.PeriodSeconds)
+	}
+}
 
-	if svc.Name != "svc-test" {
-		t.Errorf("service name = %q, want %q", svc.Name, "svc-test")
+func TestBuildStatefulSet_GatewayProxyPreStopDrain(t *testing.T) {
+	findProxy := func(sts *appsv1.StatefulSet) *corev1.Container {
+		for i := range sts.Spec.Template.Spec.Containers {
+			if sts.Spec.Template.Spec.Containers[i].Name == "gateway-proxy" {
+				return &sts.Spec.Template.Spec.Containers[i]
+			}
+		}
+		t.Fatal("gateway-proxy container not found")
+		return nil
 	}
-	if svc.Namespace != "test-ns" {
-		t.Errorf("service namespace = %q, want %q", svc.Namespace, "test-ns")
+
+	instance := newTestInstance("proxy-drain")
+	proxy := findProxy(BuildStatefulSet(instance, "", nil, nil, nil))
+	if proxy.Lifecycle == nil || proxy.Lifecycle.PreStop == nil || proxy.Lifecycle.PreStop.Exec == nil {
+		t.Fatal("expected a preStop exec hook on the gateway-proxy container")
 	}
-	if svc.Spec.Type != corev1.ServiceTypeClusterIP {
-		t.Errorf("service type = %v, want ClusterIP", svc.Spec.Type)
+	if got := proxy.Lifecycle.PreStop.Exec.Command; len(got) != 2 || got[0] != "sleep" || got[1] != "5" {
+		t.Errorf("preStop command = %v, want [sleep 5]", got)
 	}
 
-	// Labels
-	if svc.Labels["app.kubernetes.io/name"] != "openclaw" {
-		t.Error("service missing app label")
+	instance.Spec.Networking.GatewayProxy.DrainSeconds = Ptr(int32(12))
+	proxy = findProxy(BuildStatefulSet(instance, "", nil, nil, nil))
+	if got := proxy.Lifecycle.PreStop.Exec.Command; got[1] != "12" {
+		t.Errorf("preStop sleep = %s, want 12", got[1])
 	}
 
-	// Selector
-	sel := svc.Spec.Selector
-	if sel["app.kubernetes.io/name"] != "openclaw" || sel["app.kubernetes.io/instance"] != "svc-test" {
-		t.Error("service selector does not match expected values")
+	instance.Spec.Networking.GatewayProxy.DrainSeconds = Ptr(int32(0))
+	proxy = findProxy(BuildStatefulSet(instance, "", nil, nil, nil))
+	if proxy.Lifecycle != nil {
+		t.Errorf("expected no lifecycle hook with drainSeconds=0, got %+v", proxy.Lifecycle)
+	}
+}
+
+func TestBuildStatefulSet_GatewayProxyExtraArgsAndEnv(t *testing.T) {
+	findProxy := func(sts *appsv1.StatefulSet) *corev1.Container {
+		for i := range sts.Spec.Template.Spec.Containers {
+			if sts.Spec.Template.Spec.Containers[i].Name == "gateway-proxy" {
+				return &sts.Spec.Template.Spec.Containers[i]
+			}
+		}
+		t.Fatal("gateway-proxy container not found")
+		return nil
 	}
 
-	// Ports - should have gateway, canvas, and metrics (metrics enabled by default)
-	if len(svc.Spec.Ports) != 3 {
-		t.Fatalf("expected 3 ports, got %d", len(svc.Spec.Ports))
+	instance := newTestInstance("proxy-extra")
+	proxy := findProxy(BuildStatefulSet(instance, "", nil, nil, nil))
+	if len(proxy.Args) != 0 || len(proxy.Env) != 0 {
+		t.Errorf("expected no args/env by default, got args=%v env=%v", proxy.Args, proxy.Env)
 	}
 
-	assertServicePortWithTarget(t, svc.Spec.Ports, "gateway", int32(GatewayPort), int32(GatewayProxyPort))
-	assertServicePortWithTarget(t, svc.Spec.Ports, "canvas", int32(CanvasPort), int32(CanvasProxyPort))
-	assertServicePort(t, svc.Spec.Ports, "metrics", DefaultMetricsPort)
+	instance.Spec.Networking.GatewayProxy.ExtraArgs = []string{"-e", "stderr"}
+	instance.Spec.Networking.GatewayProxy.ExtraEnv = []corev1.EnvVar{{Name: "NGINX_DEBUG", Value: "1"}}
+	proxy = findProxy(BuildStatefulSet(instance, "", nil, nil, nil))
+
+	wantArgs := []string{"nginx", "-g", "daemon off;", "-e", "stderr"}
+	if !slices.Equal(proxy.Args, wantArgs) {
+		t.Errorf("args = %v, want %v", proxy.Args, wantArgs)
+	}
+	if len(proxy.Env) != 1 || proxy.Env[0].Name != "NGINX_DEBUG" || proxy.Env[0].Value != "1" {
+		t.Errorf("env = %v, want [NGINX_DEBUG=1]", proxy.Env)
+	}
 }
 
-func TestBuildService_WithChromium(t *testing.T) {
-	instance := newTestInstance("svc-chromium")
+func TestBuildStatefulSet_CustomContainerName(t *testing.T) {
+	instance := newTestInstance("container-name")
+	instance.Spec.ContainerName = "agent"
 	instance.Spec.Chromium.Enabled = true
 
-	svc := BuildService(instance)
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	containers := sts.Spec.Template.Spec.Containers
+	if containers[0].Name != "agent" {
+		t.Errorf("main container name = %q, want %q", containers[0].Name, "agent")
+	}
+	if containers[0].LivenessProbe == nil || containers[0].ReadinessProbe == nil {
+		t.Error("probes should be unaffected by a custom container name")
+	}
 
-	if len(svc.Spec.Ports) != 4 {
-		t.Fatalf("expected 4 ports with chromium, got %d", len(svc.Spec.Ports))
+	names := map[string]bool{}
+	for _, c := range containers {
+		names[c.Name] = true
+	}
+	for _, c := range sts.Spec.Template.Spec.InitContainers {
+		names[c.Name] = true
+	}
+	if names["openclaw"] {
+		t.Error("no container should keep the default name when containerName is set")
+	}
+	if !names["chromium"] {
+		t.Error("chromium sidecar name should be unaffected")
 	}
 
-	assertServicePortWithTarget(t, svc.Spec.Ports, "gateway", int32(GatewayPort), int32(GatewayProxyPort))
-	assertServicePortWithTarget(t, svc.Spec.Ports, "canvas", int32(CanvasPort), int32(CanvasProxyPort))
-	assertServicePort(t, svc.Spec.Ports, "chromium", int32(ChromiumPort))
-	assertServicePort(t, svc.Spec.Ports, "metrics", DefaultMetricsPort)
+	if got := MainContainerName(newTestInstance("default")); got != "openclaw" {
+		t.Errorf("MainContainerName() default = %q, want %q", got, "openclaw")
+	}
 }
 
-func TestBuildService_LoadBalancer(t *testing.T) {
-	instance := newTestInstance("svc-lb")
-	instance.Spec.Networking.Service.Type = corev1.ServiceTypeLoadBalancer
+func TestBuildStatefulSet_ExtraPodLabels(t *testing.T) {
+	instance := newTestInstance("pod-labels")
+	instance.Spec.ExtraPodLabels = map[string]string{
+		"team":                   "platform",
+		"app.kubernetes.io/name": "override-attempt",
+	}
 
-	svc := BuildService(instance)
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
 
-	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
-		t.Errorf("service type = %v, want LoadBalancer", svc.Spec.Type)
+	podLabels := sts.Spec.Template.Labels
+	if podLabels["team"] != "platform" {
+		t.Errorf("expected extra pod label team=platform, got %v", podLabels)
+	}
+	if podLabels["app.kubernetes.io/name"] != AppName {
+		t.Errorf("operator label must win on conflict, got %q", podLabels["app.kubernetes.io/name"])
+	}
+	if _, ok := sts.Spec.Selector.MatchLabels["team"]; ok {
+		t.Error("extra pod labels must not appear in the selector")
+	}
+	if _, ok := sts.Labels["team"]; ok {
+		t.Error("extra pod labels must not appear on the StatefulSet metadata")
+	}
+	for k, v := range sts.Spec.Selector.MatchLabels {
+		if podLabels[k] != v {
+			t.Errorf("selector label %s=%s does not match pod template (%q)", k, v, podLabels[k])
+		}
+	}
+}
+
+func TestSetAnnotationPrefix_ConfigHashKey(t *testing.T) {
+	orig := AnnotationPrefix
+	t.Cleanup(func() { AnnotationPrefix = orig })
+
+	if err := SetAnnotationPrefix("claw.example.com/"); err != nil {
+		t.Fatalf("SetAnnotationPrefix: %v", err)
+	}
+
+	instance := newTestInstance("ann-prefix")
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	ann := sts.Spec.Template.Annotations
+
+	if _, ok := ann["claw.example.com/config-hash"]; !ok {
+		t.Errorf("expected config-hash under custom prefix, got annotations %v", ann)
+	}
+	if _, ok := ann["openclaw.rocks/config-hash"]; ok {
+		t.Error("default-prefixed config-hash should not be present with a custom prefix")
+	}
+
+	pvc := BuildPVC(instance)
+	if pvc.Annotations["claw.example.com/backup-enabled"] != "true" {
+		t.Errorf("expected backup-enabled under custom prefix, got %v", pvc.Annotations)
+	}
+}
+
+func TestBuildStatefulSet_ImagePrePull(t *testing.T) {
+	instance := newTestInstance("prepull")
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Tailscale.Enabled = true
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	if _, ok := sts.Spec.Template.Annotations[PrePullAnnotationKey()]; ok {
+		t.Error("pre-pull annotation should not be set by default")
+	}
+
+	instance.Spec.Image.PrePull = Ptr(true)
+	sts = BuildStatefulSet(instance, "", nil, nil, nil)
+	got := strings.Split(sts.Spec.Template.Annotations["openclaw.rocks/prepull-images"], ",")
+
+	want := map[string]bool{}
+	for _, containers := range [][]corev1.Container{sts.Spec.Template.Spec.InitContainers, sts.Spec.Template.Spec.Containers} {
+		for _, c := range containers {
+			want[c.Image] = true
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("annotation lists %d images, want %d distinct: %v", len(got), len(want), got)
+	}
+	for _, img := range got {
+		if !want[img] {
+			t.Errorf("annotation lists %q, which no container uses", img)
+		}
+	}
+	for _, img := range []string{GetImage(instance), GetTailscaleImage(instance)} {
+		if !strings.Contains(sts.Spec.Template.Annotations["openclaw.rocks/prepull-images"], img) {
+			t.Errorf("annotation should include %q, got %v", img, got)
+		}
+	}
+
+	orig := PrePullAnnotation
+	t.Cleanup(func() { PrePullAnnotation = orig })
+	SetPrePullAnnotation("prepuller.example.com/images")
+	sts = BuildStatefulSet(instance, "", nil, nil, nil)
+	if _, ok := sts.Spec.Template.Annotations["prepuller.example.com/images"]; !ok {
+		t.Errorf("expected custom pre-pull annotation key, got %v", sts.Spec.Template.Annotations)
+	}
+}
+
+func TestAllImages(t *testing.T) {
+	instance := newTestInstance("all-images")
+	got := AllImages(instance)
+	if !slices.Contains(got, GetImage(instance)) {
+		t.Fatalf("minimal instance images = %v, want the main image", got)
+	}
+	for _, img := range got {
+		if strings.Contains(img, "chromium") || strings.Contains(img, "ollama") || strings.Contains(img, "tailscale") {
+			t.Errorf("minimal instance should not reference %q", img)
+		}
+	}
+
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Ollama.Models = []string{"llama3.2"}
+	instance.Spec.Tailscale.Enabled = true
+	instance.Spec.RuntimeDeps.Python = true
+	instance.Spec.RuntimeDeps.Pnpm = true
+	got = AllImages(instance)
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	want := map[string]bool{}
+	for _, containers := range [][]corev1.Container{sts.Spec.Template.Spec.InitContainers, sts.Spec.Template.Spec.Containers} {
+		for _, c := range containers {
+			want[c.Image] = true
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("AllImages returned %d images, StatefulSet references %d: %v", len(got), len(want), got)
+	}
+	for _, img := range got {
+		if !want[img] {
+			t.Errorf("AllImages returned %q, which the StatefulSet does not reference", img)
+		}
+	}
+	for _, img := range []string{GetImage(instance), GetTailscaleImage(instance), UvImage} {
+		if !slices.Contains(got, img) {
+			t.Errorf("AllImages should include %q, got %v", img, got)
+		}
+	}
+}
+
+func TestSetAnnotationPrefix_IgnoresEmpty(t *testing.T) {
+	orig := AnnotationPrefix
+	t.Cleanup(func() { AnnotationPrefix = orig })
+
+	if err := SetAnnotationPrefix(""); err != nil {
+		t.Fatalf("SetAnnotationPrefix: %v", err)
+	}
+
+	if AnnotationKey("config-hash") != "openclaw.rocks/config-hash" {
+		t.Errorf("AnnotationKey = %q, want default prefix after empty override", AnnotationKey("config-hash"))
+	}
+}
+
+func TestSetAnnotationPrefix_RejectsInvalid(t *testing.T) {
+	orig := AnnotationPrefix
+	t.Cleanup(func() { AnnotationPrefix = orig })
+
+	for _, prefix := range []string{"Claw.Example.com", "claw_example.com", "claw example", "-claw.example.com"} {
+		if err := SetAnnotationPrefix(prefix); err == nil {
+			t.Errorf("expected error for prefix %q", prefix)
+		}
+	}
+	if AnnotationPrefix != orig {
+		t.Errorf("AnnotationPrefix = %q, want it unchanged after invalid overrides", AnnotationPrefix)
+	}
+}
+
+func TestSetDefaultOpenClawImageTag(t *testing.T) {
+	orig := DefaultOpenClawImageTag
+	t.Cleanup(func() { DefaultOpenClawImageTag = orig })
+
+	SetDefaultOpenClawImageTag("")
+	if DefaultOpenClawImageTag != DefaultImageTag {
+		t.Errorf("empty value should be ignored, got %q", DefaultOpenClawImageTag)
+	}
+
+	SetDefaultOpenClawImageTag("2026.3.1")
+	instance := newTestInstance("default-tag")
+	if got := GetImage(instance); got != "ghcr.io/openclaw/openclaw:2026.3.1" {
+		t.Errorf("GetImage() = %q, want the configured default tag", got)
+	}
+	if got := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.Containers[0].Image; got != "ghcr.io/openclaw/openclaw:2026.3.1" {
+		t.Errorf("main container image = %q, want the configured default tag", got)
+	}
+
+	// A user-specified tag still wins
+	instance.Spec.Image.Tag = "v1.2.3"
+	if got := GetImage(instance); got != "ghcr.io/openclaw/openclaw:v1.2.3" {
+		t.Errorf("GetImage() = %q, want the user tag", got)
+	}
+
+	// Sidecar images keep their own defaults
+	instance.Spec.Tailscale.Enabled = true
+	if got := GetTailscaleImage(instance); !strings.HasSuffix(got, ":"+DefaultImageTag) {
+		t.Errorf("GetTailscaleImage() = %q, should not use the OpenClaw default tag", got)
+	}
+}
+
+func TestSetManagedBy_AppliesToBuilders(t *testing.T) {
+	orig := ManagedByValue
+	t.Cleanup(func() { ManagedByValue = orig })
+
+	SetManagedBy("acme-platform")
+
+	instance := newTestInstance("managed-by")
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	objects := map[string]map[string]string{
+		"StatefulSet":   sts.Labels,
+		"PodTemplate":   sts.Spec.Template.Labels,
+		"Service":       BuildService(instance).Labels,
+		"ConfigMap":     BuildConfigMap(instance, "", nil).Labels,
+		"Role":          BuildRole(instance).Labels,
+		"NetworkPolicy": BuildNetworkPolicy(instance).Labels,
+		"PVC":           BuildPVC(instance).Labels,
+	}
+	for kind, labels := range objects {
+		if got := labels["app.kubernetes.io/managed-by"]; got != "acme-platform" {
+			t.Errorf("%s managed-by label = %q, want %q", kind, got, "acme-platform")
+		}
+	}
+
+	if _, ok := sts.Spec.Selector.MatchLabels["app.kubernetes.io/managed-by"]; ok {
+		t.Error("selector labels must not include managed-by")
+	}
+}
+
+func TestSetManagedBy_IgnoresEmpty(t *testing.T) {
+	orig := ManagedByValue
+	t.Cleanup(func() { ManagedByValue = orig })
+
+	SetManagedBy("")
+
+	if got := Labels(newTestInstance("x"))["app.kubernetes.io/managed-by"]; got != "openclaw-operator" {
+		t.Errorf("managed-by = %q, want default after empty override", got)
+	}
+}
+
+func TestBuildStatefulSet_EnvAndEnvFrom(t *testing.T) {
+	instance := newTestInstance("env-test")
+	instance.Spec.Env = []corev1.EnvVar{
+		{Name: "MY_VAR", Value: "my-value"},
+	}
+	instance.Spec.EnvFrom = []corev1.EnvFromSource{
+		{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api-keys"},
+			},
+		},
+	}
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	main := sts.Spec.Template.Spec.Containers[0]
+
+	names := envNames(main.Env)
+	expectedPrefix := []string{"HOME", "OPENCLAW_DISABLE_BONJOUR", "OPENCLAW_GATEWAY_HANDSHAKE_TIMEOUT_MS", "NPM_CONFIG_PREFIX", "NPM_CONFIG_CACHE", "PIP_USER"}
+	for i, want := range expectedPrefix {
+		if i >= len(names) || names[i] != want {
+			t.Fatalf("env vars should start with %v, got %v", expectedPrefix, names)
+		}
+	}
+	// User-defined vars come after operator-injected vars
+	if names[len(names)-1] != "MY_VAR" {
+		t.Errorf("user-defined MY_VAR should be last, got %v", names)
+	}
+	if len(main.EnvFrom) != 1 || main.EnvFrom[0].SecretRef.Name != "api-keys" {
+		t.Error("envFrom not passed through")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// service.go tests
+// ---------------------------------------------------------------------------
+
+func TestBuildService_Default(t *testing.T) {
+	instance := newTestInstance("svc-test")
+	svc := BuildService(instance)
+
+	if svc.Name != "svc-test" {
+		t.Errorf("service name = %q, want %q", svc.Name, "svc-test")
+	}
+	if svc.Namespace != "test-ns" {
+		t.Errorf("service namespace = %q, want %q", svc.Namespace, "test-ns")
+	}
+	if svc.Spec.Type != corev1.ServiceTypeClusterIP {
+		t.Errorf("service type = %v, want ClusterIP", svc.Spec.Type)
+	}
+
+	// Labels
+	if svc.Labels["app.kubernetes.io/name"] != "openclaw" {
+		t.Error("service missing app label")
+	}
+
+	// Selector
+	sel := svc.Spec.Selector
+	if sel["app.kubernetes.io/name"] != "openclaw" || sel["app.kubernetes.io/instance"] != "svc-test" {
+		t.Error("service selector does not match expected values")
+	}
+
+	// Ports - should have gateway, canvas, and metrics (metrics enabled by default)
+	if len(svc.Spec.Ports) != 3 {
+		t.Fatalf("expected 3 ports, got %d", len(svc.Spec.Ports))
+	}
+
+	assertServicePortWithTarget(t, svc.Spec.Ports, "gateway", int32(GatewayPort), int32(GatewayProxyPort))
+	assertServicePortWithTarget(t, svc.Spec.Ports, "canvas", int32(CanvasPort), int32(CanvasProxyPort))
+	assertServicePort(t, svc.Spec.Ports, "metrics", DefaultMetricsPort)
+}
+
+func TestBuildService_WithChromium(t *testing.T) {
+	instance := newTestInstance("svc-chromium")
+	instance.Spec.Chromium.Enabled = true
+
+	svc := BuildService(instance)
+
+	if len(svc.Spec.Ports) != 4 {
+		t.Fatalf("expected 4 ports with chromium, got %d", len(svc.Spec.Ports))
+	}
+
+	assertServicePortWithTarget(t, svc.Spec.Ports, "gateway", int32(GatewayPort), int32(GatewayProxyPort))
+	assertServicePortWithTarget(t, svc.Spec.Ports, "canvas", int32(CanvasPort), int32(CanvasProxyPort))
+	assertServicePort(t, svc.Spec.Ports, "chromium", int32(ChromiumPort))
+	assertServicePort(t, svc.Spec.Ports, "metrics", DefaultMetricsPort)
+}
+
+func TestBuildService_TrafficDistribution(t *testing.T) {
+	instance := newTestInstance("svc-traffic")
+	if td := BuildService(instance).Spec.TrafficDistribution; td != nil {
+		t.Errorf("trafficDistribution should be unset by default, got %q", *td)
+	}
+
+	instance.Spec.Networking.Service.TrafficDistribution = Ptr(corev1.ServiceTrafficDistributionPreferClose)
+	td := BuildService(instance).Spec.TrafficDistribution
+	if td == nil || *td != "PreferClose" {
+		t.Errorf("trafficDistribution = %v, want PreferClose", td)
+	}
+}
+
+func TestBuildService_LoadBalancer(t *testing.T) {
+	instance := newTestInstance("svc-lb")
+	instance.Spec.Networking.Service.Type = corev1.ServiceTypeLoadBalancer
+
+	svc := BuildService(instance)
+
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		t.Errorf("service type = %v, want LoadBalancer", svc.Spec.Type)
 	}
 }
 
@@ -1447,6 +2201,202 @@ func TestBuildService_NodePort(t *testing.T) {
 	}
 }
 
+func TestBuildService_AppProtocol(t *testing.T) {
+	instance := newTestInstance("svc-appproto")
+	for _, p := range BuildService(instance).Spec.Ports[:2] {
+		if p.AppProtocol == nil || *p.AppProtocol != "http" {
+			t.Errorf("default port %s appProtocol = %v, want http", p.Name, p.AppProtocol)
+		}
+	}
+
+	instance.Spec.Networking.Service.Ports = []openclawv1alpha1.ServicePortSpec{
+		{Name: "ws", Port: 8080, AppProtocol: Ptr("kubernetes.io/ws")},
+		{Name: "raw", Port: 9000},
+	}
+	ports := BuildService(instance).Spec.Ports
+	if ports[0].AppProtocol == nil || *ports[0].AppProtocol != "kubernetes.io/ws" {
+		t.Errorf("custom port appProtocol = %v, want kubernetes.io/ws", ports[0].AppProtocol)
+	}
+	if ports[1].AppProtocol != nil {
+		t.Errorf("custom port without appProtocol should leave it unset, got %q", *ports[1].AppProtocol)
+	}
+}
+
+func TestBuildService_PublishNotReadyAddresses(t *testing.T) {
+	instance := newTestInstance("svc-not-ready")
+	if BuildService(instance).Spec.PublishNotReadyAddresses {
+		t.Error("publishNotReadyAddresses should default to false")
+	}
+
+	instance.Spec.Networking.Service.PublishNotReadyAddresses = Ptr(true)
+	if !BuildService(instance).Spec.PublishNotReadyAddresses {
+		t.Error("publishNotReadyAddresses should be set when enabled")
+	}
+}
+
+func TestBuildService_MetricsAnnotationScrape(t *testing.T) {
+	instance := newTestInstance("svc-scrape")
+	instance.Spec.Networking.Service.Annotations = map[string]string{"team": "agents"}
+	if _, ok := BuildService(instance).Annotations["prometheus.io/scrape"]; ok {
+		t.Error("scrape annotations should not be set by default")
+	}
+
+	instance.Spec.Observability.Metrics.AnnotationScrape = Ptr(true)
+	instance.Spec.Observability.Metrics.Port = Ptr(int32(9464))
+	svc := BuildService(instance)
+	want := map[string]string{
+		"team":                 "agents",
+		"prometheus.io/scrape": "true",
+		"prometheus.io/port":   "9464",
+		"prometheus.io/path":   "/metrics",
+	}
+	if !maps.Equal(svc.Annotations, want) {
+		t.Errorf("annotations = %v, want %v", svc.Annotations, want)
+	}
+	if len(instance.Spec.Networking.Service.Annotations) != 1 {
+		t.Error("spec annotations should not be modified")
+	}
+
+	instance.Spec.Observability.Metrics.Enabled = Ptr(false)
+	if _, ok := BuildService(instance).Annotations["prometheus.io/scrape"]; ok {
+		t.Error("scrape annotations should not be set with metrics disabled")
+	}
+}
+
+func TestGatewayURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(*openclawv1alpha1.OpenClawInstance)
+		external string
+		want     string
+	}{
+		{
+			name: "service DNS by default",
+			want: "http://gw-url.test-ns.svc:18789",
+		},
+		{
+			name: "first ingress host",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.Networking.Ingress.Enabled = true
+				i.Spec.Networking.Ingress.Hosts = []openclawv1alpha1.IngressHost{{Host: "claw.example.com"}, {Host: "other.example.com"}}
+				i.Spec.Networking.Service.Type = corev1.ServiceTypeLoadBalancer
+			},
+			external: "203.0.113.10",
+			want:     "https://claw.example.com",
+		},
+		{
+			name: "ingress host with path rewrite",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.Networking.Ingress.Enabled = true
+				i.Spec.Networking.Ingress.ClassName = Ptr("nginx")
+				i.Spec.Networking.Ingress.Hosts = []openclawv1alpha1.IngressHost{{Host: "claw.example.com"}}
+				i.Spec.Networking.Ingress.PathRewrite = "/openclaw"
+			},
+			want: "https://claw.example.com/openclaw",
+		},
+		{
+			name: "path rewrite ignored for traefik",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.Networking.Ingress.Enabled = true
+				i.Spec.Networking.Ingress.ClassName = Ptr("traefik")
+				i.Spec.Networking.Ingress.Hosts = []openclawv1alpha1.IngressHost{{Host: "claw.example.com"}}
+				i.Spec.Networking.Ingress.PathRewrite = "/openclaw"
+			},
+			want: "https://claw.example.com",
+		},
+		{
+			name: "custom service port named gateway",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.Networking.Service.Type = corev1.ServiceTypeLoadBalancer
+				i.Spec.Networking.Service.Ports = []openclawv1alpha1.ServicePortSpec{
+					{Name: "canvas", Port: 8080, TargetPort: Ptr(int32(CanvasProxyPort))},
+					{Name: "gateway", Port: 443, TargetPort: Ptr(int32(GatewayProxyPort))},
+				}
+			},
+			external: "203.0.113.10",
+			want:     "http://203.0.113.10:443",
+		},
+		{
+			name: "custom service port targeting the gateway proxy",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.Networking.Service.Ports = []openclawv1alpha1.ServicePortSpec{
+					{Name: "canvas", Port: 8080, TargetPort: Ptr(int32(CanvasProxyPort))},
+					{Name: "web", Port: 80, TargetPort: Ptr(int32(GatewayProxyPort))},
+				}
+			},
+			want: "http://gw-url.test-ns.svc:80",
+		},
+		{
+			name: "load balancer address",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.Networking.Service.Type = corev1.ServiceTypeLoadBalancer
+			},
+			external: "203.0.113.10",
+			want:     "http://203.0.113.10:18789",
+		},
+		{
+			name: "load balancer without address yet",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.Networking.Service.Type = corev1.ServiceTypeLoadBalancer
+			},
+			want: "http://gw-url.test-ns.svc:18789",
+		},
+		{
+			name: "pinned node port",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.Networking.Service.Type = corev1.ServiceTypeNodePort
+				i.Spec.Networking.Service.NodePort = Ptr(int32(30080))
+			},
+			external: "192.0.2.4",
+			want:     "http://192.0.2.4:30080",
+		},
+		{
+			name: "unpinned node port",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.Networking.Service.Type = corev1.ServiceTypeNodePort
+			},
+			external: "192.0.2.4",
+			want:     "http://gw-url.test-ns.svc:18789",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance("gw-url")
+			if tt.mutate != nil {
+				tt.mutate(instance)
+			}
+			if got := GatewayURL(instance, tt.external); got != tt.want {
+				t.Errorf("GatewayURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildService_FixedNodePort(t *testing.T) {
+	instance := newTestInstance("svc-fixed-np")
+	instance.Spec.Networking.Service.Type = corev1.ServiceTypeNodePort
+	instance.Spec.Networking.Service.NodePort = Ptr(int32(30080))
+
+	for _, p := range BuildService(instance).Spec.Ports {
+		want := int32(0)
+		if p.Name == "gateway" {
+			want = 30080
+		}
+		if p.NodePort != want {
+			t.Errorf("port %s nodePort = %d, want %d", p.Name, p.NodePort, want)
+		}
+	}
+
+	// Ignored for other Service types
+	instance.Spec.Networking.Service.Type = corev1.ServiceTypeClusterIP
+	for _, p := range BuildService(instance).Spec.Ports {
+		if p.NodePort != 0 {
+			t.Errorf("port %s nodePort = %d, want 0 for ClusterIP", p.Name, p.NodePort)
+		}
+	}
+}
+
 func TestBuildService_CustomAnnotations(t *testing.T) {
 	instance := newTestInstance("svc-ann")
 	instance.Spec.Networking.Service.Annotations = map[string]string{
@@ -1758,16 +2708,112 @@ func TestBuildNetworkPolicy_CustomCIDRs(t *testing.T) {
 		t.Errorf("second CIDR = %q, want %q", cidrRule2.From[0].IPBlock.CIDR, "192.168.1.0/24")
 	}
 
-	// Egress should include the CIDR rule (DNS + HTTPS + 1 custom)
-	if len(np.Spec.Egress) != 3 {
-		t.Fatalf("expected 3 egress rules, got %d", len(np.Spec.Egress))
+	// Egress should include the CIDR rule (DNS + HTTPS + 1 custom)
+	if len(np.Spec.Egress) != 3 {
+		t.Fatalf("expected 3 egress rules, got %d", len(np.Spec.Egress))
+	}
+	egressCIDR := np.Spec.Egress[2]
+	if len(egressCIDR.To) != 1 || egressCIDR.To[0].IPBlock == nil {
+		t.Fatal("third egress rule should have IPBlock")
+	}
+	if egressCIDR.To[0].IPBlock.CIDR != "172.16.0.0/12" {
+		t.Errorf("egress CIDR = %q, want %q", egressCIDR.To[0].IPBlock.CIDR, "172.16.0.0/12")
+	}
+}
+
+func TestBuildNetworkPolicy_OllamaRegistryCIDRs(t *testing.T) {
+	registryRules := func(np *networkingv1.NetworkPolicy) []string {
+		var cidrs []string
+		for _, r := range np.Spec.Egress {
+			if len(r.To) == 1 && r.To[0].IPBlock != nil && len(r.Ports) == 1 && r.Ports[0].Port.IntValue() == 443 {
+				cidrs = append(cidrs, r.To[0].IPBlock.CIDR)
+			}
+		}
+		return cidrs
+	}
+
+	instance := newTestInstance("np-ollama-registry")
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Ollama.RegistryCIDRs = []string{"104.21.0.0/16", "172.67.0.0/16"}
+
+	// No models: nothing to pull, so no registry egress
+	if got := registryRules(BuildNetworkPolicy(instance)); len(got) != 0 {
+		t.Errorf("expected no registry egress without models, got %v", got)
+	}
+
+	instance.Spec.Ollama.Models = []string{"llama3.2"}
+	got := registryRules(BuildNetworkPolicy(instance))
+	if len(got) != 2 || got[0] != "104.21.0.0/16" || got[1] != "172.67.0.0/16" {
+		t.Errorf("registry egress CIDRs = %v, want %v", got, instance.Spec.Ollama.RegistryCIDRs)
+	}
+
+	instance.Spec.Ollama.Enabled = false
+	if got := registryRules(BuildNetworkPolicy(instance)); len(got) != 0 {
+		t.Errorf("expected no registry egress with Ollama disabled, got %v", got)
+	}
+}
+
+func TestBuildNetworkPolicy_OllamaExternal(t *testing.T) {
+	instance := newTestInstance("np-ollama-external")
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Ollama.External = Ptr(true)
+	instance.Spec.Ollama.ExternalHostCIDRs = []string{"10.20.0.0/16", "192.168.5.10/32"}
+	instance.Spec.Ollama.Models = []string{"llama3.2"}
+	instance.Spec.Ollama.RegistryCIDRs = []string{"104.21.0.0/16"}
+
+	externalRules := func() map[string]int {
+		got := map[string]int{}
+		for _, r := range BuildNetworkPolicy(instance).Spec.Egress {
+			if len(r.To) == 1 && r.To[0].IPBlock != nil && len(r.Ports) == 1 {
+				got[r.To[0].IPBlock.CIDR] = r.Ports[0].Port.IntValue()
+			}
+		}
+		return got
+	}
+
+	got := externalRules()
+	for _, cidr := range instance.Spec.Ollama.ExternalHostCIDRs {
+		if got[cidr] != OllamaPort {
+			t.Errorf("egress to %s = port %d, want %d", cidr, got[cidr], OllamaPort)
+		}
+	}
+	// Models are pulled by the external server, not by init-ollama
+	if _, ok := got["104.21.0.0/16"]; ok {
+		t.Error("registry egress should not be allowed in external mode")
+	}
+
+	instance.Spec.Ollama.ExternalPort = Ptr(int32(8080))
+	if got := externalRules(); got["10.20.0.0/16"] != 8080 {
+		t.Errorf("egress to 10.20.0.0/16 = port %d, want 8080", got["10.20.0.0/16"])
+	}
+
+	instance.Spec.Ollama.External = Ptr(false)
+	if got := externalRules(); got["10.20.0.0/16"] != 0 {
+		t.Error("external egress should only be allowed in external mode")
 	}
-	egressCIDR := np.Spec.Egress[2]
-	if len(egressCIDR.To) != 1 || egressCIDR.To[0].IPBlock == nil {
-		t.Fatal("third egress rule should have IPBlock")
+}
+
+func TestBuildStatefulSet_OllamaExternal(t *testing.T) {
+	instance := newTestInstance("sts-ollama-external")
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Ollama.External = Ptr(true)
+	instance.Spec.Ollama.Models = []string{"llama3.2"}
+
+	podSpec := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec
+	for _, c := range append(podSpec.InitContainers, podSpec.Containers...) {
+		if strings.Contains(c.Name, "ollama") {
+			t.Errorf("container %q should not exist in external mode", c.Name)
+		}
 	}
-	if egressCIDR.To[0].IPBlock.CIDR != "172.16.0.0/12" {
-		t.Errorf("egress CIDR = %q, want %q", egressCIDR.To[0].IPBlock.CIDR, "172.16.0.0/12")
+	for _, v := range podSpec.Volumes {
+		if v.Name == "ollama-models" {
+			t.Error("ollama-models volume should not exist in external mode")
+		}
+	}
+	for _, e := range podSpec.Containers[0].Env {
+		if e.Name == "OLLAMA_HOST" {
+			t.Errorf("OLLAMA_HOST should not be injected in external mode, got %q", e.Value)
+		}
 	}
 }
 
@@ -2006,6 +3052,24 @@ func TestBuildRole_AdditionalRules(t *testing.T) {
 	}
 }
 
+func TestBuildRole_ConfigMapVerbs(t *testing.T) {
+	instance := newTestInstance("role-cm-verbs")
+	instance.Spec.Security.RBAC.ConfigMapVerbs = []string{"get", "list", "watch"}
+
+	role := BuildRole(instance)
+
+	if len(role.Rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(role.Rules))
+	}
+	rule := role.Rules[0]
+	if rule.ResourceNames[0] != ConfigMapName(instance) {
+		t.Errorf("base rule resourceNames = %v, want [%s]", rule.ResourceNames, ConfigMapName(instance))
+	}
+	if !slices.Equal(rule.Verbs, []string{"get", "list", "watch"}) {
+		t.Errorf("base rule verbs = %v, want [get list watch]", rule.Verbs)
+	}
+}
+
 func TestBuildRoleBinding(t *testing.T) {
 	instance := newTestInstance("rb-test")
 	rb := BuildRoleBinding(instance)
@@ -2097,6 +3161,217 @@ func TestBuildConfigMap_Default(t *testing.T) {
 	}
 }
 
+func TestBuildConfigMap_ExtraConfigMapData(t *testing.T) {
+	instance := newTestInstance("cm-extra")
+	instance.Spec.ExtraConfigMapData = map[string]string{
+		"fluent-bit.conf": "[INPUT]\n    Name tail\n",
+		"openclaw.json":   `{"shadowed":true}`,
+	}
+
+	cm := BuildConfigMap(instance, "", nil)
+
+	if cm.Data["fluent-bit.conf"] != "[INPUT]\n    Name tail\n" {
+		t.Errorf("extra key not merged, got %q", cm.Data["fluent-bit.conf"])
+	}
+
+	// The enriched config must still be present and must not be overridden
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(cm.Data["openclaw.json"]), &parsed); err != nil {
+		t.Fatalf("failed to parse openclaw.json: %v", err)
+	}
+	if _, ok := parsed["shadowed"]; ok {
+		t.Error("extraConfigMapData must not override openclaw.json")
+	}
+	if gw, ok := parsed["gateway"].(map[string]interface{}); !ok || gw["bind"] == nil {
+		t.Error("operator enrichment missing from openclaw.json")
+	}
+	if _, ok := cm.Data[NginxConfigKey]; !ok {
+		t.Error("operator-managed nginx config should coexist with extra keys")
+	}
+}
+
+func TestBuildStatefulSet_ExtraConfigMapDataChangesConfigHash(t *testing.T) {
+	instance := newTestInstance("cm-extra-hash")
+	before := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Annotations[AnnotationKey("config-hash")]
+
+	instance.Spec.ExtraConfigMapData = map[string]string{"sidecar.yaml": "a: 1"}
+	after := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Annotations[AnnotationKey("config-hash")]
+	if before == after {
+		t.Error("config hash should change when extraConfigMapData is added")
+	}
+
+	instance.Spec.ExtraConfigMapData["sidecar.yaml"] = "a: 2"
+	changed := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Annotations[AnnotationKey("config-hash")]
+	if changed == after {
+		t.Error("config hash should change when extraConfigMapData values change")
+	}
+}
+
+func TestValidateExtraConfigMapData(t *testing.T) {
+	instance := newTestInstance("cm-extra-validate")
+	instance.Spec.ExtraConfigMapData = map[string]string{"sidecar.yaml": "a: 1"}
+	if err := ValidateExtraConfigMapData(instance); err != nil {
+		t.Fatalf("expected valid keys, got: %v", err)
+	}
+
+	instance.Spec.ExtraConfigMapData = map[string]string{NginxConfigKey: ""}
+	if err := ValidateExtraConfigMapData(instance); err == nil {
+		t.Error("expected error for reserved key")
+	}
+
+	instance.Spec.ExtraConfigMapData = map[string]string{"bad/key": ""}
+	if err := ValidateExtraConfigMapData(instance); err == nil {
+		t.Error("expected error for invalid ConfigMap key")
+	}
+}
+
+func TestBuildConfigMap_TypedChannels(t *testing.T) {
+	instance := newTestInstance("cm-channels")
+	instance.Spec.Channels = map[string]openclawv1alpha1.ChannelSpec{
+		"slack": {
+			Enabled: Ptr(true),
+			Settings: &openclawv1alpha1.RawConfig{
+				RawExtension: runtime.RawExtension{Raw: []byte(`{"botToken":"${SLACK_BOT_TOKEN}","mode":"socket"}`)},
+			},
+		},
+		"discord": {Enabled: Ptr(false)},
+	}
+
+	cm := BuildConfigMap(instance, "", nil)
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(cm.Data["openclaw.json"]), &parsed); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	channels, ok := parsed["channels"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected channels object in config")
+	}
+	slack, ok := channels["slack"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected channels.slack object")
+	}
+	if slack["enabled"] != true {
+		t.Errorf("channels.slack.enabled = %v, want true", slack["enabled"])
+	}
+	if slack["botToken"] != "${SLACK_BOT_TOKEN}" || slack["mode"] != "socket" {
+		t.Errorf("channels.slack settings not merged: %v", slack)
+	}
+	discord, _ := channels["discord"].(map[string]interface{})
+	if discord["enabled"] != false {
+		t.Errorf("channels.discord.enabled = %v, want false", discord["enabled"])
+	}
+}
+
+func TestBuildConfigMap_TypedChannels_RawWins(t *testing.T) {
+	instance := newTestInstance("cm-channels-raw")
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{Raw: []byte(`{"channels":{"slack":{"mode":"http","extra":1},"telegram":{"enabled":true}}}`)},
+	}
+	instance.Spec.Channels = map[string]openclawv1alpha1.ChannelSpec{
+		"slack": {
+			Settings: &openclawv1alpha1.RawConfig{
+				RawExtension: runtime.RawExtension{Raw: []byte(`{"mode":"socket","botToken":"x"}`)},
+			},
+		},
+	}
+
+	cm := BuildConfigMap(instance, "", nil)
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(cm.Data["openclaw.json"]), &parsed); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	channels := parsed["channels"].(map[string]interface{})
+	slack := channels["slack"].(map[string]interface{})
+	if slack["mode"] != "http" {
+		t.Errorf("raw channels.slack.mode should win, got %v", slack["mode"])
+	}
+	if slack["botToken"] != "x" || slack["enabled"] != true {
+		t.Errorf("typed fields should fill gaps, got %v", slack)
+	}
+	if slack["extra"] != float64(1) {
+		t.Errorf("raw-only fields should be preserved, got %v", slack)
+	}
+	if _, ok := channels["telegram"]; !ok {
+		t.Error("raw-only channels should be preserved")
+	}
+}
+
+func TestBuildConfigMap_FeatureFlags(t *testing.T) {
+	instance := newTestInstance("cm-features")
+	instance.Spec.FeatureFlags = map[string]bool{"multiAgent": true, "voice": false}
+
+	var parsed struct {
+		Features map[string]bool `json:"features"`
+	}
+	if err := json.Unmarshal([]byte(BuildConfigMap(instance, "", nil).Data["openclaw.json"]), &parsed); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	if want := map[string]bool{"multiAgent": true, "voice": false}; !maps.Equal(parsed.Features, want) {
+		t.Errorf("features = %v, want %v", parsed.Features, want)
+	}
+}
+
+func TestBuildConfigMap_Immutable(t *testing.T) {
+	instance := newTestInstance("immutable-cm")
+	if cm := BuildConfigMap(instance, "", nil); cm.Immutable != nil {
+		t.Errorf("Immutable should be unset by default, got %v", *cm.Immutable)
+	}
+
+	instance.Spec.Config.Immutable = Ptr(true)
+	cm := BuildConfigMap(instance, "", nil)
+	if cm.Immutable == nil || !*cm.Immutable {
+		t.Errorf("Immutable = %v, want true", cm.Immutable)
+	}
+}
+
+func TestConfigMapNeedsRecreate(t *testing.T) {
+	cm := func(immutable *bool, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{Data: data, Immutable: immutable}
+	}
+	v1 := map[string]string{"openclaw.json": "{}"}
+	v2 := map[string]string{"openclaw.json": `{"a":1}`}
+
+	tests := []struct {
+		name              string
+		existing, desired *corev1.ConfigMap
+		want              bool
+	}{
+		{"mutable existing", cm(nil, v1), cm(Ptr(true), v2), false},
+		{"explicitly mutable existing", cm(Ptr(false), v1), cm(nil, v2), false},
+		{"immutable unchanged", cm(Ptr(true), v1), cm(Ptr(true), map[string]string{"openclaw.json": "{}"}), false},
+		{"immutable data changed", cm(Ptr(true), v1), cm(Ptr(true), v2), true},
+		{"immutable key added", cm(Ptr(true), v1), cm(Ptr(true), map[string]string{"openclaw.json": "{}", "nginx.conf": ""}), true},
+		{"immutable to mutable", cm(Ptr(true), v1), cm(nil, v1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConfigMapNeedsRecreate(tt.existing, tt.desired); got != tt.want {
+				t.Errorf("ConfigMapNeedsRecreate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildConfigMap_FeatureFlags_RawWins(t *testing.T) {
+	instance := newTestInstance("cm-features-raw")
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{Raw: []byte(`{"features":{"multiAgent":false,"canvas":true}}`)},
+	}
+	instance.Spec.FeatureFlags = map[string]bool{"multiAgent": true, "voice": true}
+
+	var parsed struct {
+		Features map[string]bool `json:"features"`
+	}
+	if err := json.Unmarshal([]byte(BuildConfigMap(instance, "", nil).Data["openclaw.json"]), &parsed); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	if want := map[string]bool{"multiAgent": false, "canvas": true, "voice": true}; !maps.Equal(parsed.Features, want) {
+		t.Errorf("features = %v, want %v (raw values win)", parsed.Features, want)
+	}
+}
+
 func TestBuildConfigMap_RawConfig(t *testing.T) {
 	instance := newTestInstance("cm-raw")
 	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
@@ -2301,6 +3576,189 @@ func TestBuildConfigMapFromBytes_JSON5Passthrough(t *testing.T) {
 	}
 }
 
+func TestBuildConfigMapStrict_RejectsInvalidJSON(t *testing.T) {
+	instance := newTestInstance("strict")
+	instance.Spec.Config.Strict = Ptr(true)
+
+	if _, err := BuildConfigMapStrict(instance, []byte("not json"), "tok", nil); err == nil {
+		t.Fatal("expected error for invalid JSON in strict mode")
+	}
+
+	cm, err := BuildConfigMapStrict(instance, []byte(`{"selectedProvider":"anthropic"}`), "tok", nil)
+	if err != nil {
+		t.Fatalf("expected valid JSON to pass in strict mode, got: %v", err)
+	}
+	if !strings.Contains(cm.Data["openclaw.json"], "anthropic") {
+		t.Error("valid config should be preserved in strict mode")
+	}
+}
+
+func TestBuildConfigMapStrict_LenientPassthrough(t *testing.T) {
+	instance := newTestInstance("lenient")
+
+	cm, err := BuildConfigMapStrict(instance, []byte("not json"), "tok", nil)
+	if err != nil {
+		t.Fatalf("lenient mode should not error, got: %v", err)
+	}
+	if cm.Data["openclaw.json"] != "not json" {
+		t.Errorf("lenient mode should pass invalid config through, got %q", cm.Data["openclaw.json"])
+	}
+}
+
+func TestBuildConfigMapStrict_UsesRawWhenBaseNil(t *testing.T) {
+	instance := newTestInstance("strict-raw")
+	instance.Spec.Config.Strict = Ptr(true)
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{Raw: []byte("{broken")},
+	}
+
+	if _, err := BuildConfigMapStrict(instance, nil, "tok", nil); err == nil {
+		t.Fatal("expected error for invalid inline raw config in strict mode")
+	}
+}
+
+func TestBuildConfigMap_ActiveOverlay(t *testing.T) {
+	instance := newTestInstance("overlay")
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{Raw: []byte(`{"selectedProvider":"anthropic","logging":{"level":"info","format":"json"}}`)},
+	}
+	instance.Spec.Config.Overlays = map[string]openclawv1alpha1.RawConfig{
+		"dev":  {RawExtension: runtime.RawExtension{Raw: []byte(`{"logging":{"level":"debug"},"gateway":{"bind":"lan"}}`)}},
+		"prod": {RawExtension: runtime.RawExtension{Raw: []byte(`{"logging":{"level":"warn"}}`)}},
+	}
+	instance.Spec.Config.ActiveOverlay = "dev"
+
+	cm := BuildConfigMap(instance, "tok", nil)
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(cm.Data["openclaw.json"]), &parsed); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	logging := parsed["logging"].(map[string]interface{})
+	if logging["level"] != "debug" {
+		t.Errorf("logging.level = %v, want %q from the dev overlay", logging["level"], "debug")
+	}
+	if logging["format"] != "json" {
+		t.Errorf("logging.format = %v, want %q preserved from base", logging["format"], "json")
+	}
+	if parsed["selectedProvider"] != "anthropic" {
+		t.Errorf("selectedProvider = %v, want base value preserved", parsed["selectedProvider"])
+	}
+
+	// The overlay is applied after enrichment, so it overrides operator values.
+	gw := parsed["gateway"].(map[string]interface{})
+	if gw["bind"] != "lan" {
+		t.Errorf("gateway.bind = %v, want overlay value %q", gw["bind"], "lan")
+	}
+	auth, ok := gw["auth"].(map[string]interface{})
+	if !ok || auth["token"] != "tok" {
+		t.Error("enriched gateway.auth should survive the overlay merge")
+	}
+}
+
+func TestBuildConfigMap_NoActiveOverlay(t *testing.T) {
+	instance := newTestInstance("overlay-none")
+	instance.Spec.Config.Overlays = map[string]openclawv1alpha1.RawConfig{
+		"dev": {RawExtension: runtime.RawExtension{Raw: []byte(`{"logging":{"level":"debug"}}`)}},
+	}
+
+	cm := BuildConfigMap(instance, "", nil)
+
+	if strings.Contains(cm.Data["openclaw.json"], "debug") {
+		t.Error("overlay should not be applied when activeOverlay is empty")
+	}
+}
+
+func TestValidateConfigOverlay(t *testing.T) {
+	instance := newTestInstance("overlay-validate")
+	if err := ValidateConfigOverlay(instance); err != nil {
+		t.Fatalf("expected no error without activeOverlay, got: %v", err)
+	}
+
+	instance.Spec.Config.Overlays = map[string]openclawv1alpha1.RawConfig{
+		"dev": {RawExtension: runtime.RawExtension{Raw: []byte(`{}`)}},
+	}
+	instance.Spec.Config.ActiveOverlay = "dev"
+	if err := ValidateConfigOverlay(instance); err != nil {
+		t.Fatalf("expected no error for known overlay, got: %v", err)
+	}
+
+	instance.Spec.Config.ActiveOverlay = "staging"
+	err := ValidateConfigOverlay(instance)
+	if err == nil {
+		t.Fatal("expected error for unknown overlay")
+	}
+	if !strings.Contains(err.Error(), "staging") {
+		t.Errorf("error should name the unknown overlay, got: %v", err)
+	}
+}
+
+func TestMergeConfigSources_LaterWins(t *testing.T) {
+	first := []byte(`{"mcpServers":{"fetch":{"url":"http://a"}},"selectedProvider":"anthropic","tools":["a","b"]}`)
+	second := []byte(`{"mcpServers":{"search":{"url":"http://b"}},"selectedProvider":"openai","tools":["c"]}`)
+
+	merged, err := MergeConfigSources(first, second)
+	if err != nil {
+		t.Fatalf("MergeConfigSources: %v", err)
+	}
+
+	instance := newTestInstance("merge-sources")
+	cm := BuildConfigMapFromBytes(instance, merged, "tok", nil)
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(cm.Data["openclaw.json"]), &parsed); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	if parsed["selectedProvider"] != "openai" {
+		t.Errorf("selectedProvider = %v, want %q (later source wins)", parsed["selectedProvider"], "openai")
+	}
+	servers, ok := parsed["mcpServers"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected mcpServers object")
+	}
+	if _, ok := servers["fetch"]; !ok {
+		t.Error("mcpServers.fetch from the first source should be preserved")
+	}
+	if _, ok := servers["search"]; !ok {
+		t.Error("mcpServers.search from the second source should be merged in")
+	}
+	tools, ok := parsed["tools"].([]interface{})
+	if !ok || len(tools) != 1 || tools[0] != "c" {
+		t.Errorf("tools = %v, want [c] (arrays are replaced)", parsed["tools"])
+	}
+
+	// Enrichment still runs on the merged result
+	gw, ok := parsed["gateway"].(map[string]interface{})
+	if !ok || gw["bind"] != "loopback" {
+		t.Errorf("expected gateway.bind=loopback after enrichment, got %v", parsed["gateway"])
+	}
+}
+
+func TestMergeConfigSources_InvalidJSON(t *testing.T) {
+	if _, err := MergeConfigSources([]byte(`{}`), []byte(`not json`)); err == nil {
+		t.Fatal("expected error for invalid JSON source")
+	}
+}
+
+func TestExternalConfigRefs_Order(t *testing.T) {
+	instance := newTestInstance("ext-refs")
+	instance.Spec.Config.ConfigMapRef = &openclawv1alpha1.ConfigMapKeySelector{Name: "base"}
+	instance.Spec.Config.ConfigMapRefs = []openclawv1alpha1.ConfigMapKeySelector{
+		{Name: "shared", Key: "models.json"},
+		{Name: "shared", Key: "channels.json"},
+	}
+
+	refs := ExternalConfigRefs(instance)
+	if len(refs) != 3 {
+		t.Fatalf("expected 3 refs, got %d", len(refs))
+	}
+	if refs[0].Name != "base" || refs[1].Key != "models.json" || refs[2].Key != "channels.json" {
+		t.Errorf("unexpected ref order: %+v", refs)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // OTel metrics config injection tests (#356, #373)
 // The operator injects diagnostics.otel (NOT diagnostics.metrics) and adds
@@ -2527,6 +3985,42 @@ func TestEnrichConfigWithGatewayBind_PreservesUserBind(t *testing.T) {
 	}
 }
 
+func TestEnrichConfigWithGatewayBind_TypedBind(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		bind  string
+		proxy bool
+		want  string
+	}{
+		{"typed bind on empty config", `{}`, "lan", true, "lan"},
+		{"typed bind wins over config", `{"gateway":{"bind":"loopback"}}`, "0.0.0.0", true, "0.0.0.0"},
+		{"config bind kept without typed bind", `{"gateway":{"bind":"lan"}}`, "", true, "lan"},
+		{"default without proxy", `{}`, "", false, GatewayBindAllInterfaces},
+		{"typed bind without proxy", `{}`, "lan", false, "lan"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance("bind-typed")
+			instance.Spec.Gateway.Bind = tt.bind
+			instance.Spec.Gateway.Enabled = Ptr(tt.proxy)
+
+			out, err := enrichConfigWithGatewayBind([]byte(tt.input), instance)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var cfg map[string]interface{}
+			if err := json.Unmarshal(out, &cfg); err != nil {
+				t.Fatal(err)
+			}
+			gw := cfg["gateway"].(map[string]interface{})
+			if gw["bind"] != tt.want {
+				t.Errorf("gateway.bind = %v, want %q", gw["bind"], tt.want)
+			}
+		})
+	}
+}
+
 func TestEnrichConfigWithGatewayBind_PreservesOtherFields(t *testing.T) {
 	input := []byte(`{"gateway":{"auth":{"mode":"token","token":"secret"}}}`)
 	instance := newTestInstance("bind-other-fields")
@@ -3373,9 +4867,103 @@ func TestBuildChromiumPVC_CustomSize(t *testing.T) {
 	}
 }
 
-// ---------------------------------------------------------------------------
-// pdb.go tests
-// ---------------------------------------------------------------------------
+// ---------------------------------------------------------------------------
+// pdb.go tests
+// ---------------------------------------------------------------------------
+
+func TestBuildLimitRange(t *testing.T) {
+	instance := newTestInstance("limits")
+	if IsLimitRangeEnabled(instance) {
+		t.Fatal("LimitRange should be disabled by default")
+	}
+	if refSet(ManagedResourceNames(instance))[ResourceRef{Kind: "LimitRange", Name: LimitRangeName(instance)}] {
+		t.Error("LimitRange should not be managed by default")
+	}
+
+	lr := BuildLimitRange(instance)
+	if lr.Name != "limits" || lr.Namespace != "test-ns" {
+		t.Errorf("LimitRange = %s/%s, want test-ns/limits", lr.Namespace, lr.Name)
+	}
+	if len(lr.Spec.Limits) != 1 || lr.Spec.Limits[0].Type != corev1.LimitTypeContainer {
+		t.Fatalf("expected one Container limit item, got %v", lr.Spec.Limits)
+	}
+	item := lr.Spec.Limits[0]
+	for name, want := range map[string]struct {
+		list     corev1.ResourceList
+		cpu, mem string
+	}{
+		"default":        {item.Default, "2", "4Gi"},
+		"defaultRequest": {item.DefaultRequest, "500m", "1Gi"},
+	} {
+		if got := want.list[corev1.ResourceCPU]; got.Cmp(resource.MustParse(want.cpu)) != 0 {
+			t.Errorf("%s cpu = %s, want %s", name, got.String(), want.cpu)
+		}
+		if got := want.list[corev1.ResourceMemory]; got.Cmp(resource.MustParse(want.mem)) != 0 {
+			t.Errorf("%s memory = %s, want %s", name, got.String(), want.mem)
+		}
+	}
+
+	instance.Spec.Namespace.LimitRange.Enabled = Ptr(true)
+	instance.Spec.Resources.Requests.CPU = "250m"
+	instance.Spec.Resources.Limits.Memory = "2Gi"
+	item = BuildLimitRange(instance).Spec.Limits[0]
+	if got := item.DefaultRequest[corev1.ResourceCPU]; got.Cmp(resource.MustParse("250m")) != 0 {
+		t.Errorf("defaultRequest cpu = %s, want 250m", got.String())
+	}
+	if got := item.Default[corev1.ResourceMemory]; got.Cmp(resource.MustParse("2Gi")) != 0 {
+		t.Errorf("default memory = %s, want 2Gi", got.String())
+	}
+	if !refSet(ManagedResourceNames(instance))[ResourceRef{Kind: "LimitRange", Name: LimitRangeName(instance)}] {
+		t.Error("LimitRange should be managed when enabled")
+	}
+}
+
+func TestBuildResourceQuota(t *testing.T) {
+	instance := newTestInstance("quota")
+	if IsResourceQuotaEnabled(instance) {
+		t.Fatal("ResourceQuota should be disabled by default")
+	}
+
+	instance.Spec.Namespace.ResourceQuota = openclawv1alpha1.ResourceQuotaSpec{
+		Enabled:                Ptr(true),
+		Requests:               openclawv1alpha1.ResourceList{CPU: "4", Memory: "8Gi"},
+		Limits:                 openclawv1alpha1.ResourceList{Memory: "16Gi"},
+		Pods:                   Ptr(int32(10)),
+		PersistentVolumeClaims: Ptr(int32(5)),
+	}
+	rq := BuildResourceQuota(instance)
+	if rq.Name != ResourceQuotaName(instance) || rq.Namespace != "test-ns" {
+		t.Errorf("ResourceQuota = %s/%s, want test-ns/%s", rq.Namespace, rq.Name, ResourceQuotaName(instance))
+	}
+
+	want := map[corev1.ResourceName]string{
+		corev1.ResourceRequestsCPU:            "4",
+		corev1.ResourceRequestsMemory:         "8Gi",
+		corev1.ResourceLimitsMemory:           "16Gi",
+		corev1.ResourcePods:                   "10",
+		corev1.ResourcePersistentVolumeClaims: "5",
+	}
+	if len(rq.Spec.Hard) != len(want) {
+		t.Errorf("hard = %v, want only %v (unset limits.cpu must be omitted)", rq.Spec.Hard, want)
+	}
+	for name, v := range want {
+		if got, ok := rq.Spec.Hard[name]; !ok || got.Cmp(resource.MustParse(v)) != 0 {
+			t.Errorf("hard[%s] = %s, want %s", name, got.String(), v)
+		}
+	}
+	if !refSet(ManagedResourceNames(instance))[ResourceRef{Kind: "ResourceQuota", Name: ResourceQuotaName(instance)}] {
+		t.Error("ResourceQuota should be managed when enabled")
+	}
+}
+
+func TestValidateResources_ResourceQuota(t *testing.T) {
+	instance := newTestInstance("quota-invalid")
+	instance.Spec.Namespace.ResourceQuota.Limits.CPU = "lots"
+	err := ValidateResources(instance)
+	if err == nil || !strings.Contains(err.Error(), "spec.namespace.resourceQuota.limits.cpu") {
+		t.Errorf("ValidateResources() error = %v, want an invalid spec.namespace.resourceQuota.limits.cpu error", err)
+	}
+}
 
 func TestBuildPDB_Default(t *testing.T) {
 	instance := newTestInstance("pdb-test")
@@ -3743,262 +5331,838 @@ func TestBuildIngress_CustomPaths(t *testing.T) {
 	// "Prefix" maps to PathTypePrefix
 	// "Exact" maps to PathTypeExact
 
-	if rule.HTTP.Paths[1].PathType == nil {
-		t.Fatal("second path pathType is nil")
+	if rule.HTTP.Paths[1].PathType == nil {
+		t.Fatal("second path pathType is nil")
+	}
+}
+
+func TestBuildIngress_NoHosts(t *testing.T) {
+	instance := newTestInstance("ing-no-hosts")
+	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
+		Enabled: true,
+		// No hosts
+	}
+
+	ing := BuildIngress(instance)
+
+	if len(ing.Spec.Rules) != 0 {
+		t.Errorf("expected 0 rules with no hosts, got %d", len(ing.Spec.Rules))
+	}
+}
+
+func TestBuildIngress_CustomBackendPort(t *testing.T) {
+	instance := newTestInstance("ing-port")
+	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
+		Enabled: true,
+		Hosts: []openclawv1alpha1.IngressHost{
+			{
+				Host: "aibot.example.com",
+				Paths: []openclawv1alpha1.IngressPath{
+					{Path: "/api/messages", PathType: "Prefix", Port: Ptr(int32(3978))},
+				},
+			},
+		},
+	}
+
+	ing := BuildIngress(instance)
+
+	rule := ing.Spec.Rules[0]
+	if len(rule.HTTP.Paths) != 1 {
+		t.Fatalf("expected 1 path, got %d", len(rule.HTTP.Paths))
+	}
+	backend := rule.HTTP.Paths[0].Backend.Service
+	if backend.Port.Number != 3978 {
+		t.Errorf("backend port = %d, want 3978", backend.Port.Number)
+	}
+}
+
+func TestBuildIngress_DefaultBackendPort(t *testing.T) {
+	instance := newTestInstance("ing-default-port")
+	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
+		Enabled: true,
+		Hosts: []openclawv1alpha1.IngressHost{
+			{
+				Host: "test.example.com",
+				Paths: []openclawv1alpha1.IngressPath{
+					{Path: "/", PathType: "Prefix"},
+				},
+			},
+		},
+	}
+
+	ing := BuildIngress(instance)
+
+	backend := ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service
+	if backend.Port.Number != int32(GatewayPort) {
+		t.Errorf("backend port = %d, want %d (GatewayPort)", backend.Port.Number, GatewayPort)
+	}
+}
+
+func TestBuildIngress_MixedPorts(t *testing.T) {
+	instance := newTestInstance("ing-mixed-ports")
+	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
+		Enabled: true,
+		Hosts: []openclawv1alpha1.IngressHost{
+			{
+				Host: "app.example.com",
+				Paths: []openclawv1alpha1.IngressPath{
+					{Path: "/api", PathType: "Prefix", Port: Ptr(int32(3978))},
+					{Path: "/ws", PathType: "Prefix"},
+				},
+			},
+		},
+	}
+
+	ing := BuildIngress(instance)
+
+	paths := ing.Spec.Rules[0].HTTP.Paths
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 paths, got %d", len(paths))
+	}
+	if paths[0].Backend.Service.Port.Number != 3978 {
+		t.Errorf("first path backend port = %d, want 3978", paths[0].Backend.Service.Port.Number)
+	}
+	if paths[1].Backend.Service.Port.Number != int32(GatewayPort) {
+		t.Errorf("second path backend port = %d, want %d (GatewayPort)", paths[1].Backend.Service.Port.Number, GatewayPort)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Provider detection tests
+// ---------------------------------------------------------------------------
+
+func TestDetectIngressProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		class    *string
+		expected IngressProvider
+	}{
+		{"nil className", nil, IngressProviderUnknown},
+		{"nginx", Ptr("nginx"), IngressProviderNginx},
+		{"NGINX uppercase", Ptr("NGINX"), IngressProviderNginx},
+		{"nginx-internal", Ptr("nginx-internal"), IngressProviderNginx},
+		{"traefik", Ptr("traefik"), IngressProviderTraefik},
+		{"Traefik mixed case", Ptr("Traefik"), IngressProviderTraefik},
+		{"traefik-external", Ptr("traefik-external"), IngressProviderTraefik},
+		{"haproxy", Ptr("haproxy"), IngressProviderUnknown},
+		{"empty string", Ptr(""), IngressProviderUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectIngressProvider(tt.class)
+			if got != tt.expected {
+				t.Errorf("DetectIngressProvider(%v) = %q, want %q", tt.class, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildIngress_NginxProvider(t *testing.T) {
+	instance := newTestInstance("ing-nginx")
+	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
+		Enabled:   true,
+		ClassName: Ptr("nginx"),
+		Hosts: []openclawv1alpha1.IngressHost{
+			{Host: "test.example.com"},
+		},
+	}
+
+	ing := BuildIngress(instance)
+	ann := ing.Annotations
+
+	// nginx annotations should be present
+	if ann["nginx.ingress.kubernetes.io/ssl-redirect"] != "true" {
+		t.Error("nginx ssl-redirect should be present")
+	}
+	if ann["nginx.ingress.kubernetes.io/force-ssl-redirect"] != "true" {
+		t.Error("nginx force-ssl-redirect should be present")
+	}
+	if _, ok := ann["nginx.ingress.kubernetes.io/configuration-snippet"]; !ok {
+		t.Error("nginx HSTS snippet should be present")
+	}
+	if ann["nginx.ingress.kubernetes.io/proxy-read-timeout"] != "3600" {
+		t.Error("nginx proxy-read-timeout should be present")
+	}
+
+	// traefik annotations should NOT be present
+	if _, ok := ann["traefik.ingress.kubernetes.io/router.entrypoints"]; ok {
+		t.Error("traefik annotation should not be emitted for nginx provider")
+	}
+	if _, ok := ann["traefik.ingress.kubernetes.io/router.middlewares"]; ok {
+		t.Error("traefik middleware annotation should never be emitted")
+	}
+}
+
+func TestBuildIngress_TraefikProvider(t *testing.T) {
+	instance := newTestInstance("ing-traefik")
+	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
+		Enabled:   true,
+		ClassName: Ptr("traefik"),
+		Hosts: []openclawv1alpha1.IngressHost{
+			{Host: "test.example.com"},
+		},
+	}
+
+	ing := BuildIngress(instance)
+	ann := ing.Annotations
+
+	// traefik annotation should be present
+	if ann["traefik.ingress.kubernetes.io/router.entrypoints"] != "websecure" {
+		t.Errorf("traefik router.entrypoints = %q, want %q", ann["traefik.ingress.kubernetes.io/router.entrypoints"], "websecure")
+	}
+
+	// nginx annotations should NOT be present
+	if _, ok := ann["nginx.ingress.kubernetes.io/ssl-redirect"]; ok {
+		t.Error("nginx ssl-redirect should not be emitted for traefik provider")
+	}
+	if _, ok := ann["nginx.ingress.kubernetes.io/force-ssl-redirect"]; ok {
+		t.Error("nginx force-ssl-redirect should not be emitted for traefik provider")
+	}
+	if _, ok := ann["nginx.ingress.kubernetes.io/configuration-snippet"]; ok {
+		t.Error("nginx HSTS snippet should not be emitted for traefik provider")
+	}
+	if _, ok := ann["nginx.ingress.kubernetes.io/proxy-read-timeout"]; ok {
+		t.Error("nginx proxy-read-timeout should not be emitted for traefik provider")
+	}
+	if _, ok := ann["nginx.ingress.kubernetes.io/proxy-send-timeout"]; ok {
+		t.Error("nginx proxy-send-timeout should not be emitted for traefik provider")
+	}
+	if _, ok := ann["nginx.ingress.kubernetes.io/proxy-http-version"]; ok {
+		t.Error("nginx proxy-http-version should not be emitted for traefik provider")
+	}
+	if _, ok := ann["nginx.ingress.kubernetes.io/upstream-hash-by"]; ok {
+		t.Error("nginx upstream-hash-by should not be emitted for traefik provider")
+	}
+
+	// Old middleware annotation must never appear
+	if _, ok := ann["traefik.ingress.kubernetes.io/router.middlewares"]; ok {
+		t.Error("traefik middleware annotation should never be emitted")
+	}
+}
+
+func TestBuildIngress_UnknownProvider(t *testing.T) {
+	instance := newTestInstance("ing-haproxy")
+	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
+		Enabled:   true,
+		ClassName: Ptr("haproxy"),
+		Hosts: []openclawv1alpha1.IngressHost{
+			{Host: "test.example.com"},
+		},
+	}
+
+	ing := BuildIngress(instance)
+	ann := ing.Annotations
+
+	// No provider-specific annotations for unknown provider
+	if _, ok := ann["nginx.ingress.kubernetes.io/ssl-redirect"]; ok {
+		t.Error("nginx annotations should not be emitted for unknown provider")
+	}
+	if _, ok := ann["traefik.ingress.kubernetes.io/router.entrypoints"]; ok {
+		t.Error("traefik annotations should not be emitted for unknown provider")
+	}
+	if _, ok := ann["nginx.ingress.kubernetes.io/proxy-read-timeout"]; ok {
+		t.Error("nginx WebSocket annotations should not be emitted for unknown provider")
+	}
+}
+
+func TestBuildIngress_TraefikNoRateLimiting(t *testing.T) {
+	instance := newTestInstance("ing-traefik-rl")
+	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
+		Enabled:   true,
+		ClassName: Ptr("traefik"),
+		Hosts: []openclawv1alpha1.IngressHost{
+			{Host: "test.example.com"},
+		},
+		Security: openclawv1alpha1.IngressSecuritySpec{
+			RateLimiting: &openclawv1alpha1.RateLimitingSpec{
+				// Enabled defaults to true
+			},
+		},
+	}
+
+	ing := BuildIngress(instance)
+	ann := ing.Annotations
+
+	// Rate limiting annotation should NOT be emitted for traefik (requires Middleware CRD)
+	if _, ok := ann["nginx.ingress.kubernetes.io/limit-rps"]; ok {
+		t.Error("rate limiting annotation should not be emitted for traefik provider")
 	}
 }
 
-func TestBuildIngress_NoHosts(t *testing.T) {
-	instance := newTestInstance("ing-no-hosts")
+func TestBuildIngress_AllowedSourceRanges_Nginx(t *testing.T) {
+	instance := newTestInstance("ing-allow")
 	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
-		Enabled: true,
-		// No hosts
+		Enabled:   true,
+		ClassName: Ptr("nginx"),
+		Hosts: []openclawv1alpha1.IngressHost{
+			{Host: "test.example.com"},
+		},
+		Security: openclawv1alpha1.IngressSecuritySpec{
+			AllowedSourceRanges: []string{"203.0.113.0/24", "198.51.100.7"},
+		},
 	}
 
 	ing := BuildIngress(instance)
 
-	if len(ing.Spec.Rules) != 0 {
-		t.Errorf("expected 0 rules with no hosts, got %d", len(ing.Spec.Rules))
+	want := "203.0.113.0/24,198.51.100.7"
+	if got := ing.Annotations["nginx.ingress.kubernetes.io/whitelist-source-range"]; got != want {
+		t.Errorf("whitelist-source-range = %q, want %q", got, want)
+	}
+
+	instance.Spec.Networking.Ingress.Security.AllowedSourceRanges = []string{" 10.0.0.0/8", "198.51.100.7 "}
+	ing = BuildIngress(instance)
+	want = "10.0.0.0/8,198.51.100.7"
+	if got := ing.Annotations["nginx.ingress.kubernetes.io/whitelist-source-range"]; got != want {
+		t.Errorf("padded entries: whitelist-source-range = %q, want %q", got, want)
 	}
 }
 
-func TestBuildIngress_CustomBackendPort(t *testing.T) {
-	instance := newTestInstance("ing-port")
+func TestBuildIngress_DefaultPathType(t *testing.T) {
+	instance := newTestInstance("ing-dpt")
 	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
-		Enabled: true,
+		Enabled:         true,
+		DefaultPathType: "ImplementationSpecific",
 		Hosts: []openclawv1alpha1.IngressHost{
 			{
-				Host: "aibot.example.com",
+				Host: "test.example.com",
 				Paths: []openclawv1alpha1.IngressPath{
-					{Path: "/api/messages", PathType: "Prefix", Port: Ptr(int32(3978))},
+					{Path: "/api"},
+					{Path: "/exact", PathType: "Exact"},
 				},
 			},
+			{Host: "bare.example.com"},
 		},
 	}
 
 	ing := BuildIngress(instance)
 
-	rule := ing.Spec.Rules[0]
-	if len(rule.HTTP.Paths) != 1 {
-		t.Fatalf("expected 1 path, got %d", len(rule.HTTP.Paths))
+	paths := ing.Spec.Rules[0].HTTP.Paths
+	if *paths[0].PathType != networkingv1.PathTypeImplementationSpecific {
+		t.Errorf("path without pathType = %q, want ImplementationSpecific", *paths[0].PathType)
 	}
-	backend := rule.HTTP.Paths[0].Backend.Service
-	if backend.Port.Number != 3978 {
-		t.Errorf("backend port = %d, want 3978", backend.Port.Number)
+	if *paths[1].PathType != networkingv1.PathTypeExact {
+		t.Errorf("explicit pathType = %q, want Exact", *paths[1].PathType)
+	}
+	if pt := *ing.Spec.Rules[1].HTTP.Paths[0].PathType; pt != networkingv1.PathTypeImplementationSpecific {
+		t.Errorf("implicit / path = %q, want ImplementationSpecific", pt)
 	}
 }
 
-func TestBuildIngress_DefaultBackendPort(t *testing.T) {
-	instance := newTestInstance("ing-default-port")
+func TestBuildIngress_PathRewrite(t *testing.T) {
+	instance := newTestInstance("ing-rewrite")
 	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
-		Enabled: true,
+		Enabled:     true,
+		ClassName:   Ptr("nginx"),
+		PathRewrite: "/openclaw",
 		Hosts: []openclawv1alpha1.IngressHost{
 			{
 				Host: "test.example.com",
 				Paths: []openclawv1alpha1.IngressPath{
-					{Path: "/", PathType: "Prefix"},
+					{Path: "/"},
+					{Path: "/api/v1.0", PathType: "Exact"},
 				},
 			},
 		},
 	}
 
 	ing := BuildIngress(instance)
+	if ing.Annotations["nginx.ingress.kubernetes.io/rewrite-target"] != "/$1" {
+		t.Errorf("rewrite-target = %q, want /$1", ing.Annotations["nginx.ingress.kubernetes.io/rewrite-target"])
+	}
+	if ing.Annotations["nginx.ingress.kubernetes.io/use-regex"] != "true" {
+		t.Error("use-regex should be enabled for rewritten paths")
+	}
 
-	backend := ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service
-	if backend.Port.Number != int32(GatewayPort) {
-		t.Errorf("backend port = %d, want %d (GatewayPort)", backend.Port.Number, GatewayPort)
+	paths := ing.Spec.Rules[0].HTTP.Paths
+	wantPaths := []string{`/openclaw(?:/|$)(.*)`, `/openclaw(?:/|$)(api/v1\.0.*)`}
+	for i, want := range wantPaths {
+		if paths[i].Path != want {
+			t.Errorf("paths[%d] = %q, want %q", i, paths[i].Path, want)
+		}
+		if *paths[i].PathType != networkingv1.PathTypeImplementationSpecific {
+			t.Errorf("paths[%d] pathType = %q, want ImplementationSpecific", i, *paths[i].PathType)
+		}
+	}
+
+	// traefik needs a Middleware, so the rewrite is a no-op there
+	instance.Spec.Networking.Ingress.ClassName = Ptr("traefik")
+	ing = BuildIngress(instance)
+	if _, ok := ing.Annotations["nginx.ingress.kubernetes.io/rewrite-target"]; ok {
+		t.Error("rewrite-target should not be set for traefik")
+	}
+	if ing.Spec.Rules[0].HTTP.Paths[0].Path != "/" {
+		t.Errorf("traefik path = %q, want unchanged /", ing.Spec.Rules[0].HTTP.Paths[0].Path)
 	}
 }
 
-func TestBuildIngress_MixedPorts(t *testing.T) {
-	instance := newTestInstance("ing-mixed-ports")
-	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
-		Enabled: true,
-		Hosts: []openclawv1alpha1.IngressHost{
-			{
-				Host: "app.example.com",
-				Paths: []openclawv1alpha1.IngressPath{
-					{Path: "/api", PathType: "Prefix", Port: Ptr(int32(3978))},
-					{Path: "/ws", PathType: "Prefix"},
-				},
-			},
-		},
+func TestBuildMetricsIngress(t *testing.T) {
+	instance := newTestInstance("metrics-ing")
+	if IsMetricsIngressEnabled(instance) {
+		t.Error("metrics Ingress should be disabled by default")
 	}
 
-	ing := BuildIngress(instance)
+	instance.Spec.Observability.Metrics.Port = Ptr(int32(9464))
+	instance.Spec.Observability.Metrics.Ingress = &openclawv1alpha1.MetricsIngressSpec{
+		Enabled:     true,
+		ClassName:   Ptr("nginx-internal"),
+		Host:        "metrics.internal.example.com",
+		Annotations: map[string]string{"team": "obs"},
+	}
+	if !IsMetricsIngressEnabled(instance) {
+		t.Fatal("metrics Ingress should be enabled")
+	}
 
+	ing := BuildMetricsIngress(instance)
+	if ing.Name != "metrics-ing-metrics" || ing.Name != MetricsIngressName(instance) {
+		t.Errorf("name = %q, want metrics-ing-metrics", ing.Name)
+	}
+	if ing.Spec.IngressClassName == nil || *ing.Spec.IngressClassName != "nginx-internal" {
+		t.Errorf("className = %v, want nginx-internal", ing.Spec.IngressClassName)
+	}
+	if ing.Annotations["team"] != "obs" {
+		t.Errorf("annotations = %v", ing.Annotations)
+	}
+	if len(ing.Spec.Rules) != 1 || ing.Spec.Rules[0].Host != "metrics.internal.example.com" {
+		t.Fatalf("rules = %+v", ing.Spec.Rules)
+	}
 	paths := ing.Spec.Rules[0].HTTP.Paths
-	if len(paths) != 2 {
-		t.Fatalf("expected 2 paths, got %d", len(paths))
+	if len(paths) != 1 || paths[0].Path != "/metrics" {
+		t.Fatalf("paths = %+v, want only /metrics", paths)
 	}
-	if paths[0].Backend.Service.Port.Number != 3978 {
-		t.Errorf("first path backend port = %d, want 3978", paths[0].Backend.Service.Port.Number)
+	backend := paths[0].Backend.Service
+	if backend.Name != ServiceName(instance) || backend.Port.Number != 9464 {
+		t.Errorf("backend = %s:%d, want %s:9464", backend.Name, backend.Port.Number, ServiceName(instance))
 	}
-	if paths[1].Backend.Service.Port.Number != int32(GatewayPort) {
-		t.Errorf("second path backend port = %d, want %d (GatewayPort)", paths[1].Backend.Service.Port.Number, GatewayPort)
+
+	// No metrics endpoint, nothing to expose
+	instance.Spec.Observability.Metrics.Enabled = Ptr(false)
+	if IsMetricsIngressEnabled(instance) {
+		t.Error("metrics Ingress requires metrics to be enabled")
 	}
 }
 
-// ---------------------------------------------------------------------------
-// Provider detection tests
-// ---------------------------------------------------------------------------
+func TestBuildMetricsService(t *testing.T) {
+	instance := newTestInstance("metrics-svc")
+	if IsMetricsServiceEnabled(instance) {
+		t.Error("metrics Service should be disabled by default")
+	}
 
-func TestDetectIngressProvider(t *testing.T) {
-	tests := []struct {
-		name     string
-		class    *string
-		expected IngressProvider
-	}{
-		{"nil className", nil, IngressProviderUnknown},
-		{"nginx", Ptr("nginx"), IngressProviderNginx},
-		{"NGINX uppercase", Ptr("NGINX"), IngressProviderNginx},
-		{"nginx-internal", Ptr("nginx-internal"), IngressProviderNginx},
-		{"traefik", Ptr("traefik"), IngressProviderTraefik},
-		{"Traefik mixed case", Ptr("Traefik"), IngressProviderTraefik},
-		{"traefik-external", Ptr("traefik-external"), IngressProviderTraefik},
-		{"haproxy", Ptr("haproxy"), IngressProviderUnknown},
-		{"empty string", Ptr(""), IngressProviderUnknown},
+	instance.Spec.Observability.Metrics.Port = Ptr(int32(9464))
+	instance.Spec.Observability.Metrics.SeparateService = Ptr(true)
+	if !IsMetricsServiceEnabled(instance) {
+		t.Fatal("metrics Service should be enabled")
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := DetectIngressProvider(tt.class)
-			if got != tt.expected {
-				t.Errorf("DetectIngressProvider(%v) = %q, want %q", tt.class, got, tt.expected)
-			}
-		})
+	svc := BuildMetricsService(instance)
+	if svc.Name != "metrics-svc-metrics" || svc.Name != MetricsServiceName(instance) {
+		t.Errorf("name = %q, want metrics-svc-metrics", svc.Name)
+	}
+	if svc.Spec.ClusterIP != corev1.ClusterIPNone {
+		t.Errorf("clusterIP = %q, want headless", svc.Spec.ClusterIP)
+	}
+	if !equality.Semantic.DeepEqual(svc.Spec.Selector, SelectorLabels(instance)) {
+		t.Errorf("selector = %v, want %v", svc.Spec.Selector, SelectorLabels(instance))
+	}
+	if len(svc.Spec.Ports) != 1 {
+		t.Fatalf("expected a single port, got %+v", svc.Spec.Ports)
+	}
+	if p := svc.Spec.Ports[0]; p.Port != 9464 || p.TargetPort.IntVal != 9464 || p.Name == "metrics" {
+		t.Errorf("port = %+v, want 9464 not named metrics", p)
+	}
+
+	instance.Spec.Observability.Metrics.Enabled = Ptr(false)
+	if IsMetricsServiceEnabled(instance) {
+		t.Error("metrics Service requires metrics to be enabled")
 	}
 }
 
-func TestBuildIngress_NginxProvider(t *testing.T) {
-	instance := newTestInstance("ing-nginx")
+func TestBuildIngress_DefaultPathTypeUnset(t *testing.T) {
+	instance := newTestInstance("ing-dpt-unset")
 	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
-		Enabled:   true,
-		ClassName: Ptr("nginx"),
+		Enabled: true,
 		Hosts: []openclawv1alpha1.IngressHost{
-			{Host: "test.example.com"},
+			{Host: "test.example.com", Paths: []openclawv1alpha1.IngressPath{{Path: "/api"}}},
 		},
 	}
 
 	ing := BuildIngress(instance)
-	ann := ing.Annotations
 
-	// nginx annotations should be present
-	if ann["nginx.ingress.kubernetes.io/ssl-redirect"] != "true" {
-		t.Error("nginx ssl-redirect should be present")
+	if pt := *ing.Spec.Rules[0].HTTP.Paths[0].PathType; pt != networkingv1.PathTypePrefix {
+		t.Errorf("pathType = %q, want Prefix", pt)
 	}
-	if ann["nginx.ingress.kubernetes.io/force-ssl-redirect"] != "true" {
-		t.Error("nginx force-ssl-redirect should be present")
+}
+
+func TestValidateIngress(t *testing.T) {
+	instance := newTestInstance("ing-validate")
+	instance.Spec.Networking.Ingress.Hosts = []openclawv1alpha1.IngressHost{
+		{Host: "test.example.com", Paths: []openclawv1alpha1.IngressPath{{Path: "/", PathType: "Exact"}}},
 	}
-	if _, ok := ann["nginx.ingress.kubernetes.io/configuration-snippet"]; !ok {
-		t.Error("nginx HSTS snippet should be present")
+	if err := ValidateIngress(instance); err != nil {
+		t.Fatalf("expected valid ingress, got: %v", err)
 	}
-	if ann["nginx.ingress.kubernetes.io/proxy-read-timeout"] != "3600" {
-		t.Error("nginx proxy-read-timeout should be present")
+
+	instance.Spec.Networking.Ingress.Hosts[0].Paths[0].PathType = "Regex"
+	err := ValidateIngress(instance)
+	if err == nil {
+		t.Fatal("expected error for unknown pathType")
+	}
+	if !strings.Contains(err.Error(), "hosts[0].paths[0].pathType") {
+		t.Errorf("error should reference the offending path, got: %v", err)
 	}
 
-	// traefik annotations should NOT be present
-	if _, ok := ann["traefik.ingress.kubernetes.io/router.entrypoints"]; ok {
-		t.Error("traefik annotation should not be emitted for nginx provider")
+	instance.Spec.Networking.Ingress.Hosts[0].Paths[0].PathType = ""
+	instance.Spec.Networking.Ingress.DefaultPathType = "prefix"
+	if err := ValidateIngress(instance); err == nil {
+		t.Fatal("expected error for unknown defaultPathType")
 	}
-	if _, ok := ann["traefik.ingress.kubernetes.io/router.middlewares"]; ok {
-		t.Error("traefik middleware annotation should never be emitted")
+}
+
+func TestValidateIngress_MetricsIngressHost(t *testing.T) {
+	instance := newTestInstance("metrics-ing-validate")
+	instance.Spec.Observability.Metrics.Ingress = &openclawv1alpha1.MetricsIngressSpec{}
+	if err := ValidateIngress(instance); err != nil {
+		t.Fatalf("disabled metrics ingress without host should be valid, got: %v", err)
+	}
+
+	instance.Spec.Observability.Metrics.Ingress.Enabled = true
+	err := ValidateIngress(instance)
+	if err == nil {
+		t.Fatal("expected error for enabled metrics ingress without host")
+	}
+	if !strings.Contains(err.Error(), "observability.metrics.ingress.host") {
+		t.Errorf("error should reference the host field, got: %v", err)
+	}
+
+	instance.Spec.Observability.Metrics.Ingress.Host = "metrics.internal.example.com"
+	if err := ValidateIngress(instance); err != nil {
+		t.Fatalf("expected valid metrics ingress, got: %v", err)
 	}
 }
 
-func TestBuildIngress_TraefikProvider(t *testing.T) {
-	instance := newTestInstance("ing-traefik")
-	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
-		Enabled:   true,
-		ClassName: Ptr("traefik"),
-		Hosts: []openclawv1alpha1.IngressHost{
-			{Host: "test.example.com"},
-		},
+func TestValidateResources(t *testing.T) {
+	instance := newTestInstance("res-validate")
+	instance.Spec.Resources.Limits = openclawv1alpha1.ResourceList{CPU: "2", Memory: "4Gi"}
+	if err := ValidateResources(instance); err != nil {
+		t.Fatalf("expected valid resources, got: %v", err)
 	}
 
-	ing := BuildIngress(instance)
-	ann := ing.Annotations
+	instance.Spec.Ollama.Resources.Limits.Memory = "2GG"
+	err := ValidateResources(instance)
+	if err == nil {
+		t.Fatal("expected error for invalid sidecar quantity")
+	}
+	if !strings.Contains(err.Error(), "spec.ollama.resources.limits.memory") {
+		t.Errorf("error should reference the offending field, got: %v", err)
+	}
 
-	// traefik annotation should be present
-	if ann["traefik.ingress.kubernetes.io/router.entrypoints"] != "websecure" {
-		t.Errorf("traefik router.entrypoints = %q, want %q", ann["traefik.ingress.kubernetes.io/router.entrypoints"], "websecure")
+	// The builder falls back to the default instead of panicking
+	instance.Spec.Ollama.Resources.Limits.Memory = ""
+	instance.Spec.Resources.Requests.Memory = "2GG"
+	if err := ValidateResources(instance); err == nil {
+		t.Fatal("expected error for invalid main container quantity")
+	}
+	main := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.Containers[0]
+	if mem := main.Resources.Requests[corev1.ResourceMemory]; mem.Cmp(resource.MustParse("1Gi")) != 0 {
+		t.Errorf("memory request = %v, want default 1Gi", mem.String())
 	}
+}
 
-	// nginx annotations should NOT be present
-	if _, ok := ann["nginx.ingress.kubernetes.io/ssl-redirect"]; ok {
-		t.Error("nginx ssl-redirect should not be emitted for traefik provider")
+func TestValidateStorage(t *testing.T) {
+	instance := newTestInstance("storage-validate")
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Chromium.Persistence.Enabled = true
+	if err := ValidateStorage(instance); err != nil {
+		t.Fatalf("a single replica may use a RWO claim, got: %v", err)
 	}
-	if _, ok := ann["nginx.ingress.kubernetes.io/force-ssl-redirect"]; ok {
-		t.Error("nginx force-ssl-redirect should not be emitted for traefik provider")
+
+	// Multiple replicas sharing the operator-managed ReadWriteOnce Chromium PVC
+	instance.Spec.Availability.AutoScaling = &openclawv1alpha1.AutoScalingSpec{
+		Enabled:     Ptr(true),
+		MaxReplicas: Ptr(int32(3)),
 	}
-	if _, ok := ann["nginx.ingress.kubernetes.io/configuration-snippet"]; ok {
-		t.Error("nginx HSTS snippet should not be emitted for traefik provider")
+	err := ValidateStorage(instance)
+	if err == nil {
+		t.Fatal("expected error for a RWO claim shared by 3 replicas")
 	}
-	if _, ok := ann["nginx.ingress.kubernetes.io/proxy-read-timeout"]; ok {
-		t.Error("nginx proxy-read-timeout should not be emitted for traefik provider")
+	if !strings.Contains(err.Error(), "chromium.persistence") || !strings.Contains(err.Error(), "ReadWriteMany") {
+		t.Errorf("error should name the claim and the fix, got: %v", err)
 	}
-	if _, ok := ann["nginx.ingress.kubernetes.io/proxy-send-timeout"]; ok {
-		t.Error("nginx proxy-send-timeout should not be emitted for traefik provider")
+
+	// A user-provided (e.g. ReadWriteMany) claim is not checked
+	instance.Spec.Chromium.Persistence.ExistingClaim = "shared-profile"
+	if err := ValidateStorage(instance); err != nil {
+		t.Errorf("expected a user-provided claim to be accepted, got: %v", err)
 	}
-	if _, ok := ann["nginx.ingress.kubernetes.io/proxy-http-version"]; ok {
-		t.Error("nginx proxy-http-version should not be emitted for traefik provider")
+
+	// The data volume uses per-replica volumeClaimTemplates
+	instance.Spec.Chromium.Persistence.ExistingClaim = ""
+	instance.Spec.Chromium.Persistence.Enabled = false
+	if !UsesVolumeClaimTemplates(instance) {
+		t.Fatal("expected volumeClaimTemplates with persistence and HPA")
 	}
-	if _, ok := ann["nginx.ingress.kubernetes.io/upstream-hash-by"]; ok {
-		t.Error("nginx upstream-hash-by should not be emitted for traefik provider")
+	if err := ValidateStorage(instance); err != nil {
+		t.Errorf("expected volumeClaimTemplate config to be valid, got: %v", err)
 	}
+}
 
-	// Old middleware annotation must never appear
-	if _, ok := ann["traefik.ingress.kubernetes.io/router.middlewares"]; ok {
-		t.Error("traefik middleware annotation should never be emitted")
+func TestValidatePorts(t *testing.T) {
+	instance := newTestInstance("ports-clean")
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Networking.Service.Ports = []openclawv1alpha1.ServicePortSpec{
+		{Name: "gateway", Port: 80, TargetPort: Ptr(int32(GatewayPort))},
+		{Name: "dns-tcp", Port: 53, Protocol: corev1.ProtocolTCP},
+		{Name: "dns-udp", Port: 53, Protocol: corev1.ProtocolUDP},
+	}
+	if err := ValidatePorts(instance); err != nil {
+		t.Fatalf("expected clean config to pass, got: %v", err)
 	}
 }
 
-func TestBuildIngress_UnknownProvider(t *testing.T) {
-	instance := newTestInstance("ing-haproxy")
+func TestValidatePorts_ChromiumCollision(t *testing.T) {
+	instance := newTestInstance("ports-chromium")
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Sidecars = []corev1.Container{
+		{
+			Name:  "debug-proxy",
+			Image: "example/proxy:1.0",
+			Ports: []corev1.ContainerPort{{Name: "proxy", ContainerPort: ChromiumPort}},
+		},
+	}
+
+	err := ValidatePorts(instance)
+	if err == nil {
+		t.Fatal("expected error for sidecar port colliding with chromium")
+	}
+	if !strings.Contains(err.Error(), fmt.Sprintf("%d", ChromiumPort)) || !strings.Contains(err.Error(), "debug-proxy") {
+		t.Errorf("error should name the port and container, got: %v", err)
+	}
+}
+
+func TestValidatePorts_DuplicateServicePort(t *testing.T) {
+	instance := newTestInstance("ports-svc")
+	instance.Spec.Networking.Service.Ports = []openclawv1alpha1.ServicePortSpec{
+		{Name: "http", Port: 8080},
+		{Name: "alt", Port: 8080, TargetPort: Ptr(int32(CanvasPort))},
+	}
+	if err := ValidatePorts(instance); err == nil {
+		t.Fatal("expected error for duplicate service port")
+	}
+
+	instance.Spec.Networking.Service.Ports[1] = openclawv1alpha1.ServicePortSpec{Name: "http", Port: 9000}
+	if err := ValidatePorts(instance); err == nil {
+		t.Fatal("expected error for duplicate service port name")
+	}
+}
+
+func TestValidatePorts_ServiceTargetsSidecarPort(t *testing.T) {
+	instance := newTestInstance("ports-target")
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Networking.Service.Ports = []openclawv1alpha1.ServicePortSpec{
+		{Name: "gateway", Port: 80, TargetPort: Ptr(int32(GatewayProxyPort))},
+		{Name: "cdp", Port: ChromiumPort},
+		{Name: "llm", Port: 8080, TargetPort: Ptr(int32(OllamaPort))},
+	}
+
+	err := ValidatePorts(instance)
+	if err == nil {
+		t.Fatal("expected error for a service port targeting the ollama sidecar")
+	}
+	if !strings.Contains(err.Error(), "ports[2]") || !strings.Contains(err.Error(), `"ollama"`) {
+		t.Errorf("error should name the service port and sidecar, got: %v", err)
+	}
+
+	// The same target without the sidecar is a plain custom port
+	instance.Spec.Ollama.Enabled = false
+	if err := ValidatePorts(instance); err != nil {
+		t.Errorf("expected no error without the ollama sidecar, got: %v", err)
+	}
+}
+
+func TestValidatePorts_DuplicateContainerName(t *testing.T) {
+	instance := newTestInstance("ports-dup-name")
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Sidecars = []corev1.Container{
+		{
+			Name:  "chromium",
+			Image: "example/browser:1.0",
+			Ports: []corev1.ContainerPort{{Name: "cdp", ContainerPort: ChromiumPort}},
+		},
+	}
+
+	if err := ValidatePorts(instance); err == nil {
+		t.Fatal("expected error for a sidecar reusing the chromium name and port")
+	}
+}
+
+func TestBuildIngress_AllowedSourceRanges_TraefikOmitted(t *testing.T) {
+	instance := newTestInstance("ing-allow-traefik")
 	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
 		Enabled:   true,
-		ClassName: Ptr("haproxy"),
+		ClassName: Ptr("traefik"),
 		Hosts: []openclawv1alpha1.IngressHost{
 			{Host: "test.example.com"},
 		},
+		Security: openclawv1alpha1.IngressSecuritySpec{
+			AllowedSourceRanges: []string{"203.0.113.0/24"},
+		},
+	}
+
+	ing := BuildIngress(instance)
+
+	if _, ok := ing.Annotations["nginx.ingress.kubernetes.io/whitelist-source-range"]; ok {
+		t.Error("whitelist-source-range annotation should not be emitted for traefik provider")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ManagedResourceNames tests
+// ---------------------------------------------------------------------------
+
+func refSet(refs []ResourceRef) map[ResourceRef]bool {
+	set := make(map[ResourceRef]bool, len(refs))
+	for _, r := range refs {
+		set[r] = true
+	}
+	return set
+}
+
+func TestManagedResourceNames_Minimal(t *testing.T) {
+	instance := newTestInstance("minimal")
+	refs := refSet(ManagedResourceNames(instance))
+
+	want := []ResourceRef{
+		{Kind: "StatefulSet", Name: "minimal"},
+		{Kind: "Service", Name: "minimal"},
+		{Kind: "ConfigMap", Name: "minimal-config"},
+		{Kind: "ConfigMap", Name: "minimal-workspace"},
+		{Kind: "ServiceAccount", Name: "minimal"},
+		{Kind: "Role", Name: "minimal"},
+		{Kind: "RoleBinding", Name: "minimal"},
+		{Kind: "NetworkPolicy", Name: "minimal"},
+		{Kind: "Secret", Name: "minimal-gateway-token"},
+		{Kind: "PersistentVolumeClaim", Name: "minimal-data"},
+		{Kind: "PodDisruptionBudget", Name: "minimal"},
+	}
+	for _, w := range want {
+		if !refs[w] {
+			t.Errorf("missing %s/%s", w.Kind, w.Name)
+		}
+	}
+	if len(refs) != len(want) {
+		t.Errorf("expected %d refs for a minimal instance, got %d: %v", len(want), len(refs), ManagedResourceNames(instance))
+	}
+}
+
+func TestManagedResourceNames_FullyFeatured(t *testing.T) {
+	instance := newTestInstance("full")
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Chromium.Persistence.Enabled = true
+	instance.Spec.Tailscale.Enabled = true
+	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
+		Enabled:   true,
+		ClassName: Ptr("traefik"),
+		Security: openclawv1alpha1.IngressSecuritySpec{
+			BasicAuth: &openclawv1alpha1.IngressBasicAuthSpec{Enabled: Ptr(true)},
+		},
+	}
+	instance.Spec.Observability.Metrics.ServiceMonitor = &openclawv1alpha1.ServiceMonitorSpec{Enabled: Ptr(true)}
+	instance.Spec.Observability.Metrics.PrometheusRule = &openclawv1alpha1.PrometheusRuleSpec{Enabled: Ptr(true)}
+	instance.Spec.Observability.Metrics.GrafanaDashboard = &openclawv1alpha1.GrafanaDashboardSpec{Enabled: Ptr(true)}
+
+	refs := refSet(ManagedResourceNames(instance))
+
+	for _, w := range []ResourceRef{
+		{Kind: "PersistentVolumeClaim", Name: ChromiumPVCName(instance)},
+		{Kind: "Service", Name: ChromiumCDPServiceName(instance)},
+		{Kind: "Secret", Name: TailscaleStateSecretName(instance)},
+		{Kind: "Ingress", Name: IngressName(instance)},
+		{Kind: "Secret", Name: BasicAuthSecretName(instance)},
+		{Kind: "Middleware", Name: "full-basic-auth"},
+		{Kind: "ServiceMonitor", Name: ServiceMonitorName(instance)},
+		{Kind: "PrometheusRule", Name: PrometheusRuleName(instance)},
+		{Kind: "ConfigMap", Name: GrafanaDashboardOperatorName(instance)},
+		{Kind: "ConfigMap", Name: GrafanaDashboardInstanceName(instance)},
+	} {
+		if !refs[w] {
+			t.Errorf("missing %s/%s", w.Kind, w.Name)
+		}
 	}
+}
 
-	ing := BuildIngress(instance)
-	ann := ing.Annotations
+func TestManagedResourceNames_FeatureGates(t *testing.T) {
+	instance := newTestInstance("gated")
+	instance.Spec.Security.NetworkPolicy.Enabled = Ptr(false)
+	instance.Spec.Security.RBAC.CreateServiceAccount = Ptr(false)
+	instance.Spec.Gateway.ExistingSecret = "my-token"
+	instance.Spec.Storage.Persistence.ExistingClaim = "my-pvc"
+	instance.Spec.Availability.AutoScaling = &openclawv1alpha1.AutoScalingSpec{Enabled: Ptr(true)}
 
-	// No provider-specific annotations for unknown provider
-	if _, ok := ann["nginx.ingress.kubernetes.io/ssl-redirect"]; ok {
-		t.Error("nginx annotations should not be emitted for unknown provider")
-	}
-	if _, ok := ann["traefik.ingress.kubernetes.io/router.entrypoints"]; ok {
-		t.Error("traefik annotations should not be emitted for unknown provider")
+	refs := refSet(ManagedResourceNames(instance))
+
+	for _, kind := range []string{"NetworkPolicy", "ServiceAccount", "Role", "RoleBinding", "Secret", "PersistentVolumeClaim", "Ingress"} {
+		for r := range refs {
+			if r.Kind == kind {
+				t.Errorf("did not expect %s/%s when its feature is off or user-provided", r.Kind, r.Name)
+			}
+		}
 	}
-	if _, ok := ann["nginx.ingress.kubernetes.io/proxy-read-timeout"]; ok {
-		t.Error("nginx WebSocket annotations should not be emitted for unknown provider")
+	if !refs[ResourceRef{Kind: "HorizontalPodAutoscaler", Name: HPAName(instance)}] {
+		t.Error("expected HorizontalPodAutoscaler when auto-scaling is enabled")
 	}
 }
 
-func TestBuildIngress_TraefikNoRateLimiting(t *testing.T) {
-	instance := newTestInstance("ing-traefik-rl")
-	instance.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
+func TestRenderAll_MatchesManagedResourceNames(t *testing.T) {
+	full := newTestInstance("full")
+	full.Spec.Chromium.Enabled = true
+	full.Spec.Chromium.Persistence.Enabled = true
+	full.Spec.Tailscale.Enabled = true
+	full.Spec.Networking.Ingress = openclawv1alpha1.IngressSpec{
 		Enabled:   true,
 		ClassName: Ptr("traefik"),
-		Hosts: []openclawv1alpha1.IngressHost{
-			{Host: "test.example.com"},
-		},
 		Security: openclawv1alpha1.IngressSecuritySpec{
-			RateLimiting: &openclawv1alpha1.RateLimitingSpec{
-				// Enabled defaults to true
-			},
+			BasicAuth: &openclawv1alpha1.IngressBasicAuthSpec{Enabled: Ptr(true)},
 		},
 	}
+	full.Spec.Observability.Metrics.ServiceMonitor = &openclawv1alpha1.ServiceMonitorSpec{Enabled: Ptr(true)}
+	full.Spec.Observability.Metrics.PrometheusRule = &openclawv1alpha1.PrometheusRuleSpec{Enabled: Ptr(true)}
+	full.Spec.Observability.Metrics.GrafanaDashboard = &openclawv1alpha1.GrafanaDashboardSpec{Enabled: Ptr(true)}
 
-	ing := BuildIngress(instance)
-	ann := ing.Annotations
+	hpa := newTestInstance("hpa")
+	hpa.Spec.Availability.AutoScaling = &openclawv1alpha1.AutoScalingSpec{Enabled: Ptr(true)}
+	hpa.Spec.Security.NetworkPolicy.Enabled = Ptr(false)
 
-	// Rate limiting annotation should NOT be emitted for traefik (requires Middleware CRD)
-	if _, ok := ann["nginx.ingress.kubernetes.io/limit-rps"]; ok {
-		t.Error("rate limiting annotation should not be emitted for traefik provider")
+	cron := newTestInstance("cron")
+	cron.Spec.Schedule = "0 * * * *"
+
+	for _, instance := range []*openclawv1alpha1.OpenClawInstance{newTestInstance("minimal"), full, hpa, cron} {
+		t.Run(instance.Name, func(t *testing.T) {
+			objs, err := RenderAll(instance)
+			if err != nil {
+				t.Fatalf("RenderAll() error = %v", err)
+			}
+			var got []ResourceRef
+			for _, obj := range objs {
+				kind := obj.GetObjectKind().GroupVersionKind().Kind
+				if kind == "" {
+					t.Errorf("%T rendered without a kind", obj)
+				}
+				got = append(got, ResourceRef{Kind: kind, Name: obj.(metav1.Object).GetName()})
+			}
+			if want := ManagedResourceNames(instance); !equality.Semantic.DeepEqual(got, want) {
+				t.Errorf("RenderAll() objects = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRenderAll_ValidationError(t *testing.T) {
+	instance := newTestInstance("invalid")
+	instance.Spec.Config.ActiveOverlay = "missing"
+	if _, err := RenderAll(instance); err == nil {
+		t.Error("RenderAll() should fail when the instance does not validate")
 	}
 }
 
@@ -4420,6 +6584,71 @@ func TestBuildStatefulSet_ChromiumPersistenceEnabled(t *testing.T) {
 	assertVolumeMount(t, chromium.VolumeMounts, "chromium-data", "/chromium-data")
 }
 
+func TestBuildStatefulSet_ChromiumFSGroup(t *testing.T) {
+	instance := newTestInstance("chromium-fsgroup")
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Chromium.Persistence.Enabled = true
+
+	// No init-chown without an fsGroup
+	for _, c := range BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.InitContainers {
+		if c.Name == "init-chown" {
+			t.Fatal("init-chown should not be added without chromium.fsGroup")
+		}
+	}
+
+	instance.Spec.Chromium.FSGroup = Ptr(int64(2000))
+	instance.Spec.Chromium.Replicas = Ptr(int32(2))
+	initContainers := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.InitContainers
+
+	chown := initContainers[0]
+	if chown.Name != "init-chown" {
+		t.Fatalf("init-chown should run first, got %q", chown.Name)
+	}
+	script := chown.Command[2]
+	for _, want := range []string{
+		"chown -R 65534:2000 /chown/chromium-data",
+		"chown -R 65534:2000 /chown/chromium-data-1",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("init-chown script missing %q: %s", want, script)
+		}
+	}
+	assertVolumeMount(t, chown.VolumeMounts, "chromium-data", "/chown/chromium-data")
+	assertVolumeMount(t, chown.VolumeMounts, "chromium-data-1", "/chown/chromium-data-1")
+	sc := chown.SecurityContext
+	if sc.RunAsUser == nil || *sc.RunAsUser != 0 {
+		t.Error("init-chown should run as root")
+	}
+	if !slices.Equal(sc.Capabilities.Add, []corev1.Capability{"CHOWN", "DAC_READ_SEARCH"}) {
+		t.Errorf("init-chown capabilities = %v", sc.Capabilities.Add)
+	}
+
+	for _, c := range initContainers {
+		if strings.HasPrefix(c.Name, "chromium") {
+			if c.SecurityContext.RunAsGroup == nil || *c.SecurityContext.RunAsGroup != 2000 {
+				t.Errorf("%s RunAsGroup = %v, want 2000", c.Name, c.SecurityContext.RunAsGroup)
+			}
+		}
+	}
+}
+
+func TestBuildStatefulSet_OllamaFSGroup(t *testing.T) {
+	instance := newTestInstance("ollama-fsgroup")
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Ollama.FSGroup = Ptr(int64(3000))
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	chown := sts.Spec.Template.Spec.InitContainers[0]
+	if chown.Name != "init-chown" || chown.Command[2] != "chown -R 0:3000 /chown/ollama-models" {
+		t.Errorf("unexpected init-chown %q: %v", chown.Name, chown.Command)
+	}
+	for _, c := range sts.Spec.Template.Spec.Containers {
+		if c.Name == "ollama" && (c.SecurityContext.RunAsGroup == nil || *c.SecurityContext.RunAsGroup != 3000) {
+			t.Errorf("ollama RunAsGroup = %v, want 3000", c.SecurityContext.RunAsGroup)
+		}
+	}
+}
+
 func TestBuildStatefulSet_ChromiumPersistenceExistingClaim(t *testing.T) {
 	instance := newTestInstance("chromium-existing")
 	instance.Spec.Chromium.Enabled = true
@@ -4480,6 +6709,63 @@ func TestBuildStatefulSet_ConfigMapDefaultMode(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_EntrypointScript(t *testing.T) {
+	instance := newTestInstance("entrypoint")
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	if cmd := sts.Spec.Template.Spec.Containers[0].Command; len(cmd) != 0 {
+		t.Errorf("main container command should be unset by default, got %v", cmd)
+	}
+	if findVolume(sts.Spec.Template.Spec.Volumes, "entrypoint") != nil {
+		t.Error("entrypoint volume should not exist without a script")
+	}
+	hashBefore := sts.Spec.Template.Annotations[AnnotationKey("config-hash")]
+
+	script := "#!/bin/sh\n. /etc/profile\nexec \"$@\"\n"
+	instance.Spec.EntrypointScript = script
+	sts = BuildStatefulSet(instance, "", nil, nil, nil)
+	main := sts.Spec.Template.Spec.Containers[0]
+
+	if want := []string{"/operator-entrypoint/entrypoint.sh"}; !slices.Equal(main.Command, want) {
+		t.Errorf("command = %v, want %v", main.Command, want)
+	}
+	assertVolumeMount(t, main.VolumeMounts, "entrypoint", EntrypointScriptMountPath)
+
+	vol := findVolume(sts.Spec.Template.Spec.Volumes, "entrypoint")
+	if vol == nil || vol.ConfigMap == nil {
+		t.Fatal("entrypoint ConfigMap volume not found")
+	}
+	if vol.ConfigMap.Name != ConfigMapName(instance) {
+		t.Errorf("entrypoint volume ConfigMap = %q, want %q", vol.ConfigMap.Name, ConfigMapName(instance))
+	}
+	if len(vol.ConfigMap.Items) != 1 || vol.ConfigMap.Items[0].Key != EntrypointScriptKey {
+		t.Errorf("entrypoint volume items = %v, want only %s", vol.ConfigMap.Items, EntrypointScriptKey)
+	}
+	if vol.ConfigMap.DefaultMode == nil || *vol.ConfigMap.DefaultMode != 0o755 {
+		t.Errorf("entrypoint volume mode = %v, want 0o755", vol.ConfigMap.DefaultMode)
+	}
+
+	if got := BuildConfigMap(instance, "", nil).Data[EntrypointScriptKey]; got != script {
+		t.Errorf("ConfigMap %s = %q, want %q", EntrypointScriptKey, got, script)
+	}
+	if sts.Spec.Template.Annotations[AnnotationKey("config-hash")] == hashBefore {
+		t.Error("config hash should change with the entrypoint script")
+	}
+}
+
+func TestBuildStatefulSet_ConfigMapFileMode(t *testing.T) {
+	instance := newTestInstance("cm-file-mode")
+	instance.Spec.Config.FileMode = Ptr(int32(0o600))
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	configVol := findVolume(sts.Spec.Template.Spec.Volumes, "config")
+	if configVol == nil || configVol.ConfigMap == nil {
+		t.Fatal("config ConfigMap volume not found")
+	}
+	if configVol.ConfigMap.DefaultMode == nil || *configVol.ConfigMap.DefaultMode != 0o600 {
+		t.Errorf("ConfigMap DefaultMode = %v, want 0o600", configVol.ConfigMap.DefaultMode)
+	}
+}
+
 // TestBuildService_KubernetesDefaults verifies Service builder includes
 // Kubernetes default fields.
 func TestBuildService_KubernetesDefaults(t *testing.T) {
@@ -4730,89 +7016,417 @@ func TestShellQuote(t *testing.T) {
 		{"no quotes", "'no quotes'"},
 		{"a'b'c", "'a'\\''b'\\''c'"},
 	}
-	for _, tt := range tests {
-		got := shellQuote(tt.input)
-		if got != tt.want {
-			t.Errorf("shellQuote(%q) = %q, want %q", tt.input, got, tt.want)
+	for _, tt := range tests {
+		got := shellQuote(tt.input)
+		if got != tt.want {
+			t.Errorf("shellQuote(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// operatorSeedLines is the init script suffix that seeds operator-injected workspace files (always present).
+const operatorSeedLines = "mkdir -p /data/workspace\n[ -f /data/workspace/'BOOTSTRAP.md' ] || cp /workspace-init/'BOOTSTRAP.md' /data/workspace/'BOOTSTRAP.md'\n[ -f /data/workspace/'ENVIRONMENT.md' ] || cp /workspace-init/'ENVIRONMENT.md' /data/workspace/'ENVIRONMENT.md'"
+
+func TestBuildInitScript_ConfigOnly(t *testing.T) {
+	instance := newTestInstance("init-config-only")
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{Raw: []byte(`{}`)},
+	}
+
+	script := BuildInitScript(instance, nil, nil, nil)
+	expected := "cp /config/'openclaw.json' /data/openclaw.json\n" + operatorSeedLines
+	if script != expected {
+		t.Errorf("unexpected script:\ngot:  %q\nwant: %q", script, expected)
+	}
+}
+
+func TestBuildInitScript_CustomConfigFileName(t *testing.T) {
+	instance := newTestInstance("init-config-name")
+	instance.Spec.Config.FileName = "config.json"
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{Raw: []byte(`{}`)},
+	}
+
+	cm := BuildConfigMap(instance, "", nil)
+	if _, ok := cm.Data["config.json"]; !ok {
+		t.Errorf("ConfigMap should use the custom key, got keys %v", cm.Data)
+	}
+	if _, ok := cm.Data["openclaw.json"]; ok {
+		t.Error("ConfigMap should not contain openclaw.json when fileName is set")
+	}
+
+	script := BuildInitScript(instance, nil, nil, nil)
+	expected := "cp /config/'config.json' /data/config.json\n" + operatorSeedLines
+	if script != expected {
+		t.Errorf("unexpected script:\ngot:  %q\nwant: %q", script, expected)
+	}
+
+	restore := buildConfigRestoreCommand(instance)
+	if restore != "cp /operator-config/config.json /home/openclaw/.openclaw/config.json" {
+		t.Errorf("unexpected postStart command: %q", restore)
+	}
+
+	instance.Spec.Config.MergeMode = ConfigMergeModeMerge
+	if restore := buildConfigRestoreCommand(instance); !strings.Contains(restore, `const e="/home/openclaw/.openclaw/config.json"`) {
+		t.Errorf("merge postStart should target the custom file, got: %q", restore)
+	}
+	if script := BuildInitScript(instance, nil, nil, nil); !strings.Contains(script, `const e="/data/config.json"`) {
+		t.Errorf("merge init script should target the custom file, got: %q", script)
+	}
+}
+
+func TestBuildInitScript_WorkspaceOnly(t *testing.T) {
+	instance := newTestInstance("init-ws-only")
+	instance.Spec.Workspace = &openclawv1alpha1.WorkspaceSpec{
+		InitialFiles: map[string]string{
+			"SOUL.md": "content",
+		},
+		InitialDirectories: []string{"memory"},
+	}
+
+	script := BuildInitScript(instance, nil, nil, nil)
+	expected := "cp /config/'openclaw.json' /data/openclaw.json\nmkdir -p /data/workspace/'memory'\nmkdir -p /data/workspace\n[ -f /data/workspace/'BOOTSTRAP.md' ] || cp /workspace-init/'BOOTSTRAP.md' /data/workspace/'BOOTSTRAP.md'\n[ -f /data/workspace/'ENVIRONMENT.md' ] || cp /workspace-init/'ENVIRONMENT.md' /data/workspace/'ENVIRONMENT.md'\n[ -f /data/workspace/'SOUL.md' ] || cp /workspace-init/'SOUL.md' /data/workspace/'SOUL.md'"
+	if script != expected {
+		t.Errorf("unexpected script:\ngot:  %q\nwant: %q", script, expected)
+	}
+}
+
+func TestBuildInitScript_Both(t *testing.T) {
+	instance := newTestInstance("init-both")
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{Raw: []byte(`{}`)},
+	}
+	instance.Spec.Workspace = &openclawv1alpha1.WorkspaceSpec{
+		InitialFiles: map[string]string{
+			"SOUL.md":   "soul",
+			"AGENTS.md": "agents",
+		},
+		InitialDirectories: []string{"memory", "tools"},
+	}
+
+	script := BuildInitScript(instance, nil, nil, nil)
+
+	// Verify all expected lines are present (sorted order, operator files included)
+	lines := strings.Split(script, "\n")
+	if len(lines) != 8 {
+		t.Fatalf("expected 8 lines, got %d:\n%s", len(lines), script)
+	}
+	if lines[0] != "cp /config/'openclaw.json' /data/openclaw.json" {
+		t.Errorf("line 0: %q", lines[0])
+	}
+	if lines[1] != "mkdir -p /data/workspace/'memory'" {
+		t.Errorf("line 1: %q", lines[1])
+	}
+	if lines[2] != "mkdir -p /data/workspace/'tools'" {
+		t.Errorf("line 2: %q", lines[2])
+	}
+	if lines[3] != "mkdir -p /data/workspace" {
+		t.Errorf("line 3: %q", lines[3])
+	}
+	if lines[4] != "[ -f /data/workspace/'AGENTS.md' ] || cp /workspace-init/'AGENTS.md' /data/workspace/'AGENTS.md'" {
+		t.Errorf("line 4: %q", lines[4])
+	}
+	if lines[5] != "[ -f /data/workspace/'BOOTSTRAP.md' ] || cp /workspace-init/'BOOTSTRAP.md' /data/workspace/'BOOTSTRAP.md'" {
+		t.Errorf("line 5: %q", lines[5])
+	}
+	if lines[6] != "[ -f /data/workspace/'ENVIRONMENT.md' ] || cp /workspace-init/'ENVIRONMENT.md' /data/workspace/'ENVIRONMENT.md'" {
+		t.Errorf("line 6: %q", lines[6])
+	}
+	if lines[7] != "[ -f /data/workspace/'SOUL.md' ] || cp /workspace-init/'SOUL.md' /data/workspace/'SOUL.md'" {
+		t.Errorf("line 7: %q", lines[7])
+	}
+}
+
+func TestBuildJob_MainContainerParity(t *testing.T) {
+	instance := newTestInstance("job-mode")
+	instance.Spec.Mode = WorkloadModeJob
+	instance.Spec.Tailscale.Enabled = true
+
+	sts := BuildStatefulSet(instance, "gw-secret", nil, nil, nil)
+	job := BuildJob(instance, "gw-secret", nil, nil, nil)
+
+	if job.Name != StatefulSetName(instance) || job.Namespace != instance.Namespace {
+		t.Errorf("job = %s/%s, want %s/%s", job.Namespace, job.Name, instance.Namespace, StatefulSetName(instance))
+	}
+	podSpec := job.Spec.Template.Spec
+	if podSpec.RestartPolicy != corev1.RestartPolicyOnFailure {
+		t.Errorf("restartPolicy = %q, want OnFailure", podSpec.RestartPolicy)
+	}
+	if len(podSpec.Containers) != 1 {
+		t.Fatalf("job should run only the main container, got %d containers", len(podSpec.Containers))
+	}
+	if !equality.Semantic.DeepEqual(podSpec.Containers[0], sts.Spec.Template.Spec.Containers[0]) {
+		t.Error("job main container should match the StatefulSet main container")
+	}
+	if !equality.Semantic.DeepEqual(podSpec.Volumes, sts.Spec.Template.Spec.Volumes) {
+		t.Error("job volumes should match the StatefulSet volumes")
+	}
+
+	// StatefulSet sidecars become native sidecars after the init containers
+	stsInit := sts.Spec.Template.Spec.InitContainers
+	for i, c := range sts.Spec.Template.Spec.Containers[1:] {
+		got := podSpec.InitContainers[len(stsInit)+i]
+		if got.Name != c.Name {
+			t.Errorf("native sidecar %d = %q, want %q", i, got.Name, c.Name)
+		}
+		if got.RestartPolicy == nil || *got.RestartPolicy != corev1.ContainerRestartPolicyAlways {
+			t.Errorf("sidecar %q should have restartPolicy Always", got.Name)
+		}
+	}
+	if len(podSpec.InitContainers) != len(stsInit)+len(sts.Spec.Template.Spec.Containers)-1 {
+		t.Errorf("init containers = %d, want %d", len(podSpec.InitContainers), len(stsInit)+len(sts.Spec.Template.Spec.Containers)-1)
+	}
+
+	hashKey := AnnotationKey("config-hash")
+	if job.Annotations[hashKey] == "" || job.Annotations[hashKey] != sts.Spec.Template.Annotations[hashKey] {
+		t.Errorf("job config-hash = %q, want pod template hash %q", job.Annotations[hashKey], sts.Spec.Template.Annotations[hashKey])
+	}
+	if job.Spec.Suspend == nil || *job.Spec.Suspend {
+		t.Error("job should not be suspended by default")
+	}
+
+	// The StatefulSet builder is untouched by job-mode mutations
+	if sts.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyAlways {
+		t.Error("StatefulSet restartPolicy should stay Always")
+	}
+}
+
+func TestBuildCronJob(t *testing.T) {
+	instance := newTestInstance("cron-mode")
+	instance.Spec.Schedule = "*/30 * * * *"
+
+	cj := BuildCronJob(instance, "gw-secret", nil, nil, nil)
+	if cj.Name != CronJobName(instance) || cj.Spec.Schedule != "*/30 * * * *" {
+		t.Errorf("cronjob %s schedule = %q", cj.Name, cj.Spec.Schedule)
+	}
+	if cj.Spec.ConcurrencyPolicy != batchv1.ForbidConcurrent {
+		t.Errorf("default concurrencyPolicy = %q, want Forbid", cj.Spec.ConcurrencyPolicy)
+	}
+	if *cj.Spec.SuccessfulJobsHistoryLimit != 3 || *cj.Spec.FailedJobsHistoryLimit != 1 {
+		t.Errorf("history limits = %d/%d, want 3/1", *cj.Spec.SuccessfulJobsHistoryLimit, *cj.Spec.FailedJobsHistoryLimit)
+	}
+
+	// Each run uses the job-mode pod template
+	job := BuildJob(instance, "gw-secret", nil, nil, nil)
+	if !equality.Semantic.DeepEqual(cj.Spec.JobTemplate.Spec.Template, job.Spec.Template) {
+		t.Error("CronJob pod template should match the job-mode pod template")
+	}
+	if cj.Spec.JobTemplate.Spec.Suspend != nil {
+		t.Error("suspension belongs on the CronJob, not its runs")
+	}
+
+	instance.Spec.CronJob = openclawv1alpha1.CronJobSpec{
+		ConcurrencyPolicy:  batchv1.ReplaceConcurrent,
+		HistoryLimit:       Ptr(int32(10)),
+		FailedHistoryLimit: Ptr(int32(0)),
+	}
+	instance.Spec.Suspended = true
+	cj = BuildCronJob(instance, "gw-secret", nil, nil, nil)
+	if cj.Spec.ConcurrencyPolicy != batchv1.ReplaceConcurrent {
+		t.Errorf("concurrencyPolicy = %q, want Replace", cj.Spec.ConcurrencyPolicy)
+	}
+	if *cj.Spec.SuccessfulJobsHistoryLimit != 10 || *cj.Spec.FailedJobsHistoryLimit != 0 {
+		t.Errorf("history limits = %d/%d, want 10/0", *cj.Spec.SuccessfulJobsHistoryLimit, *cj.Spec.FailedJobsHistoryLimit)
+	}
+	if cj.Spec.Suspend == nil || !*cj.Spec.Suspend {
+		t.Error("suspended instance should suspend the CronJob")
+	}
+
+	// A schedule wins over spec.mode
+	instance.Spec.Mode = WorkloadModeJob
+	if IsJobMode(instance) || !IsScheduled(instance) {
+		t.Error("schedule should take precedence over job mode")
+	}
+}
+
+func TestBuildSkillsJob(t *testing.T) {
+	instance := newTestInstance("skills-job")
+	instance.Spec.Skills = []string{"weather", "npm:@openclaw/matrix", "pack:base"}
+	instance.Spec.SkillsInstallMode = SkillsInstallModeJob
+
+	job := BuildSkillsJob(instance)
+	if job == nil {
+		t.Fatal("expected a skills Job")
+	}
+	if job.Name != "skills-job-skills" || job.Namespace != "test-ns" {
+		t.Errorf("job = %s/%s, want test-ns/skills-job-skills", job.Namespace, job.Name)
+	}
+
+	podSpec := job.Spec.Template.Spec
+	if len(podSpec.Containers) != 1 {
+		t.Fatalf("expected 1 container, got %d", len(podSpec.Containers))
+	}
+	c := podSpec.Containers[0]
+	if got := c.Command[2]; got != BuildSkillsScript(instance) {
+		t.Errorf("job script should reuse BuildSkillsScript, got:\n%s", got)
+	}
+	if c.Image != GetImage(instance) {
+		t.Errorf("job image = %q, want %q", c.Image, GetImage(instance))
+	}
+
+	var dataMount *corev1.VolumeMount
+	for i := range c.VolumeMounts {
+		if c.VolumeMounts[i].Name == "data" {
+			dataMount = &c.VolumeMounts[i]
+		}
+	}
+	if dataMount == nil || dataMount.MountPath != "/home/openclaw/.openclaw" {
+		t.Fatalf("expected data volume mounted at /home/openclaw/.openclaw, got %+v", c.VolumeMounts)
+	}
+	var claim string
+	for _, v := range podSpec.Volumes {
+		if v.Name == "data" && v.PersistentVolumeClaim != nil {
+			claim = v.PersistentVolumeClaim.ClaimName
+		}
+	}
+	if claim != PVCName(instance) {
+		t.Errorf("data volume claim = %q, want %q", claim, PVCName(instance))
+	}
+
+	// Job pods must not be selected by the instance Service/PDB
+	sel := SelectorLabels(instance)
+	matches := true
+	for k, v := range sel {
+		if job.Spec.Template.Labels[k] != v {
+			matches = false
+		}
+	}
+	if matches {
+		t.Error("skills Job pod labels must not match the instance selector")
+	}
+
+	instance.Spec.Storage.Persistence.ExistingClaim = "my-data"
+	for _, v := range BuildSkillsJob(instance).Spec.Template.Spec.Volumes {
+		if v.Name == "data" && v.PersistentVolumeClaim.ClaimName != "my-data" {
+			t.Errorf("data volume claim = %q, want existingClaim %q", v.PersistentVolumeClaim.ClaimName, "my-data")
 		}
 	}
 }
 
-// operatorSeedLines is the init script suffix that seeds operator-injected workspace files (always present).
-const operatorSeedLines = "mkdir -p /data/workspace\n[ -f /data/workspace/'BOOTSTRAP.md' ] || cp /workspace-init/'BOOTSTRAP.md' /data/workspace/'BOOTSTRAP.md'\n[ -f /data/workspace/'ENVIRONMENT.md' ] || cp /workspace-init/'ENVIRONMENT.md' /data/workspace/'ENVIRONMENT.md'"
+func TestBuildStatefulSet_SkillsJobMode(t *testing.T) {
+	instance := newTestInstance("skills-job-sts")
+	instance.Spec.Skills = []string{"weather"}
 
-func TestBuildInitScript_ConfigOnly(t *testing.T) {
-	instance := newTestInstance("init-config-only")
-	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
-		RawExtension: runtime.RawExtension{Raw: []byte(`{}`)},
+	hasInitContainer := func(sts *appsv1.StatefulSet, name string) bool {
+		for _, c := range sts.Spec.Template.Spec.InitContainers {
+			if c.Name == name {
+				return true
+			}
+		}
+		return false
 	}
 
-	script := BuildInitScript(instance, nil, nil, nil)
-	expected := "cp /config/'openclaw.json' /data/openclaw.json\n" + operatorSeedLines
-	if script != expected {
-		t.Errorf("unexpected script:\ngot:  %q\nwant: %q", script, expected)
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	if !hasInitContainer(sts, "init-skills") {
+		t.Fatal("default mode should use the init-skills init container")
+	}
+	if _, ok := sts.Spec.Template.Annotations["openclaw.rocks/skills-hash"]; ok {
+		t.Error("skills-hash annotation should only be set in job mode")
 	}
-}
 
-func TestBuildInitScript_WorkspaceOnly(t *testing.T) {
-	instance := newTestInstance("init-ws-only")
-	instance.Spec.Workspace = &openclawv1alpha1.WorkspaceSpec{
-		InitialFiles: map[string]string{
-			"SOUL.md": "content",
-		},
-		InitialDirectories: []string{"memory"},
+	instance.Spec.SkillsInstallMode = SkillsInstallModeJob
+	sts = BuildStatefulSet(instance, "", nil, nil, nil)
+	if hasInitContainer(sts, "init-skills") {
+		t.Error("job mode must not add the init-skills init container")
+	}
+	hash := sts.Spec.Template.Annotations["openclaw.rocks/skills-hash"]
+	if hash == "" || hash != BuildSkillsJob(instance).Annotations["openclaw.rocks/skills-hash"] {
+		t.Errorf("pod skills-hash %q should match the Job annotation", hash)
+	}
+	// Skills are still visible to the main container from the PVC
+	found := false
+	for _, m := range sts.Spec.Template.Spec.Containers[0].VolumeMounts {
+		if m.MountPath == "/app/skills" && m.SubPath == "skills" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("main container should still mount the PVC-backed skills directory")
 	}
 
-	script := BuildInitScript(instance, nil, nil, nil)
-	expected := "cp /config/'openclaw.json' /data/openclaw.json\nmkdir -p /data/workspace/'memory'\nmkdir -p /data/workspace\n[ -f /data/workspace/'BOOTSTRAP.md' ] || cp /workspace-init/'BOOTSTRAP.md' /data/workspace/'BOOTSTRAP.md'\n[ -f /data/workspace/'ENVIRONMENT.md' ] || cp /workspace-init/'ENVIRONMENT.md' /data/workspace/'ENVIRONMENT.md'\n[ -f /data/workspace/'SOUL.md' ] || cp /workspace-init/'SOUL.md' /data/workspace/'SOUL.md'"
-	if script != expected {
-		t.Errorf("unexpected script:\ngot:  %q\nwant: %q", script, expected)
+	instance.Spec.Skills = []string{"weather", "github"}
+	if SkillsHash(instance) == hash {
+		t.Error("skills hash should change when skills change")
+	}
+	instance.Spec.Skills = []string{"pack:base"}
+	if BuildSkillsJob(instance) != nil {
+		t.Error("expected no Job when there are no installable skills")
 	}
 }
 
-func TestBuildInitScript_Both(t *testing.T) {
-	instance := newTestInstance("init-both")
+func TestBuildInitSteps_MatchesLegacyScript(t *testing.T) {
+	instance := newTestInstance("init-steps")
 	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
 		RawExtension: runtime.RawExtension{Raw: []byte(`{}`)},
 	}
 	instance.Spec.Workspace = &openclawv1alpha1.WorkspaceSpec{
-		InitialFiles: map[string]string{
-			"SOUL.md":   "soul",
-			"AGENTS.md": "agents",
+		InitialFiles:       map[string]string{"SOUL.md": "soul"},
+		InitialDirectories: []string{"memory"},
+		AdditionalWorkspaces: []openclawv1alpha1.AdditionalWorkspace{
+			{Name: "work", InitialDirectories: []string{"tools"}},
 		},
-		InitialDirectories: []string{"memory", "tools"},
+	}
+	packs := &ResolvedSkillPacks{
+		Files:       map[string]string{"skills--pack--SKILL.md": "x"},
+		PathMapping: map[string]string{"skills--pack--SKILL.md": "skills/pack/SKILL.md"},
+		Directories: []string{"skills/pack"},
 	}
 
-	script := BuildInitScript(instance, nil, nil, nil)
+	legacy := strings.Join([]string{
+		"cp /config/'openclaw.json' /data/openclaw.json",
+		"mkdir -p /data/workspace/'memory'",
+		"mkdir -p /data/workspace/'skills/pack'",
+		"mkdir -p /data/workspace",
+		"[ -f /data/workspace/'BOOTSTRAP.md' ] || cp /workspace-init/'BOOTSTRAP.md' /data/workspace/'BOOTSTRAP.md'",
+		"[ -f /data/workspace/'ENVIRONMENT.md' ] || cp /workspace-init/'ENVIRONMENT.md' /data/workspace/'ENVIRONMENT.md'",
+		"[ -f /data/workspace/'SOUL.md' ] || cp /workspace-init/'SOUL.md' /data/workspace/'SOUL.md'",
+		"[ -f /data/workspace/'skills/pack/SKILL.md' ] || cp /workspace-init/'skills--pack--SKILL.md' /data/workspace/'skills/pack/SKILL.md'",
+		"mkdir -p /data/'workspace-work'",
+		"mkdir -p /data/'workspace-work'/'tools'",
+		"[ -f /data/'workspace-work'/'ENVIRONMENT.md' ] || cp /workspace-init/'--ws--work--ENVIRONMENT.md' /data/'workspace-work'/'ENVIRONMENT.md'",
+	}, "\n")
 
-	// Verify all expected lines are present (sorted order, operator files included)
-	lines := strings.Split(script, "\n")
-	if len(lines) != 8 {
-		t.Fatalf("expected 8 lines, got %d:\n%s", len(lines), script)
+	if script := BuildInitScript(instance, nil, nil, packs); script != legacy {
+		t.Fatalf("BuildInitScript output changed:\ngot:\n%s\nwant:\n%s", script, legacy)
 	}
-	if lines[0] != "cp /config/'openclaw.json' /data/openclaw.json" {
-		t.Errorf("line 0: %q", lines[0])
+
+	steps := BuildInitSteps(instance, nil, nil, packs)
+	commands := make([]string, len(steps))
+	for i, step := range steps {
+		commands[i] = step.Command
 	}
-	if lines[1] != "mkdir -p /data/workspace/'memory'" {
-		t.Errorf("line 1: %q", lines[1])
+	if joined := strings.Join(commands, "\n"); joined != legacy {
+		t.Errorf("joined steps differ from legacy script:\n%s", joined)
 	}
-	if lines[2] != "mkdir -p /data/workspace/'tools'" {
-		t.Errorf("line 2: %q", lines[2])
+
+	wantKinds := []InitStepKind{
+		InitStepCopyConfig, InitStepMkdir, InitStepMkdir, InitStepMkdir,
+		InitStepCopyFile, InitStepCopyFile, InitStepCopyFile, InitStepCopyFile,
+		InitStepMkdir, InitStepMkdir, InitStepCopyFile,
 	}
-	if lines[3] != "mkdir -p /data/workspace" {
-		t.Errorf("line 3: %q", lines[3])
+	for i, kind := range wantKinds {
+		if steps[i].Kind != kind {
+			t.Errorf("step %d kind = %q, want %q", i, steps[i].Kind, kind)
+		}
 	}
-	if lines[4] != "[ -f /data/workspace/'AGENTS.md' ] || cp /workspace-init/'AGENTS.md' /data/workspace/'AGENTS.md'" {
-		t.Errorf("line 4: %q", lines[4])
+	if steps[0].Source != "/config/openclaw.json" || steps[0].Target != "/data/openclaw.json" {
+		t.Errorf("copy-config step = %+v", steps[0])
 	}
-	if lines[5] != "[ -f /data/workspace/'BOOTSTRAP.md' ] || cp /workspace-init/'BOOTSTRAP.md' /data/workspace/'BOOTSTRAP.md'" {
-		t.Errorf("line 5: %q", lines[5])
+	if steps[7].Source != "/workspace-init/skills--pack--SKILL.md" || steps[7].Target != "/data/workspace/skills/pack/SKILL.md" {
+		t.Errorf("skill pack copy step = %+v", steps[7])
 	}
-	if lines[6] != "[ -f /data/workspace/'ENVIRONMENT.md' ] || cp /workspace-init/'ENVIRONMENT.md' /data/workspace/'ENVIRONMENT.md'" {
-		t.Errorf("line 6: %q", lines[6])
+	if steps[9].Target != "/data/workspace-work/tools" {
+		t.Errorf("additional workspace mkdir target = %q", steps[9].Target)
 	}
-	if lines[7] != "[ -f /data/workspace/'SOUL.md' ] || cp /workspace-init/'SOUL.md' /data/workspace/'SOUL.md'" {
-		t.Errorf("line 7: %q", lines[7])
+
+	instance.Spec.Config.MergeMode = ConfigMergeModeMerge
+	if got := BuildInitSteps(instance, nil, nil, packs)[0].Kind; got != InitStepMerge {
+		t.Errorf("merge mode first step kind = %q, want %q", got, InitStepMerge)
+	}
+	instance.Spec.Config.MergeMode = ""
+	instance.Spec.Config.Format = ConfigFormatJSON5
+	if got := BuildInitSteps(instance, nil, nil, packs)[0].Kind; got != InitStepJSON5Convert {
+		t.Errorf("json5 first step kind = %q, want %q", got, InitStepJSON5Convert)
 	}
 }
 
@@ -5134,6 +7748,47 @@ func TestBuildInitScript_MergeMode(t *testing.T) {
 	}
 }
 
+func TestBuildInitScript_MergeStrategy(t *testing.T) {
+	instance := newTestInstance("init-merge-strategy")
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{Raw: []byte(`{}`)},
+	}
+	instance.Spec.Config.MergeMode = ConfigMergeModeMerge
+
+	// Default (and explicit "deep") keeps the recursive merge
+	for _, strategy := range []string{"", "deep"} {
+		instance.Spec.Config.MergeStrategy = strategy
+		for name, script := range map[string]string{
+			"init":      BuildInitScript(instance, nil, nil, nil),
+			"postStart": buildConfigRestoreCommand(instance),
+		} {
+			if !strings.Contains(script, "dm(r[k],b[k])") {
+				t.Errorf("strategy %q %s: expected recursive deep merge, got %q", strategy, name, script)
+			}
+			if strings.Contains(script, "Object.assign") {
+				t.Errorf("strategy %q %s: deep merge must not use Object.assign", strategy, name)
+			}
+		}
+	}
+
+	instance.Spec.Config.MergeStrategy = ConfigMergeStrategyShallow
+	for name, script := range map[string]string{
+		"init":      BuildInitScript(instance, nil, nil, nil),
+		"postStart": buildConfigRestoreCommand(instance),
+	} {
+		if !strings.Contains(script, "Object.assign({},a,b)") {
+			t.Errorf("shallow %s: expected top-level Object.assign merge, got %q", name, script)
+		}
+		if strings.Contains(script, "dm(r[k],b[k])") {
+			t.Errorf("shallow %s: must not recurse into nested objects", name)
+		}
+		// #120 and #162 regressions still apply
+		if !strings.Contains(script, "copyFileSync") || !strings.Contains(script, "node -e '") {
+			t.Errorf("shallow %s: expected single-quoted node script with copyFileSync, got %q", name, script)
+		}
+	}
+}
+
 func TestBuildStatefulSet_MergeMode_OpenClawImage(t *testing.T) {
 	instance := newTestInstance("merge-oci")
 	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
@@ -5330,6 +7985,82 @@ func TestBuildSkillsScript_WithSkills(t *testing.T) {
 	}
 }
 
+func TestBuildSkillsScript_Prune(t *testing.T) {
+	instance := newTestInstance("skills-prune")
+	instance.Spec.Skills = []string{"@anthropic/weather", "npm:@openclaw/matrix", "pack:base"}
+
+	// Off by default
+	script := BuildSkillsScript(instance)
+	if strings.Contains(script, "_uninstall_skill") || strings.Contains(script, SkillsManifestPath) {
+		t.Errorf("pruning must be off by default, got:\n%s", script)
+	}
+
+	instance.Spec.SkillsPrune = Ptr(true)
+	script = BuildSkillsScript(instance)
+	for _, want := range []string{
+		`npm:*) npm uninstall -g "${1#npm:}"`,
+		`rm -rf "/home/openclaw/.openclaw/skills/$1"`,
+		"_desired='weather\nnpm:@openclaw/matrix'",
+		"done < " + SkillsManifestPath,
+		`grep -qxF -- "$_s" || _uninstall_skill "$_s"`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("prune script missing %q, got:\n%s", want, script)
+		}
+	}
+	if strings.Contains(script, "pack:base") {
+		t.Error("pack: entries must not be tracked in the skills manifest")
+	}
+
+	// Prune runs before installs; the manifest is written last
+	lines := strings.Split(script, "\n")
+	last := lines[len(lines)-1]
+	if last != `printf '%s\n' "$_desired" > `+SkillsManifestPath {
+		t.Errorf("last line should record the manifest, got %q", last)
+	}
+	if strings.Index(script, "_uninstall_skill \"$_s\"") > strings.Index(script, "_install_skill 'weather'") {
+		t.Error("pruning should happen before installing desired skills")
+	}
+
+	instance.Spec.SkillsPrune = Ptr(false)
+	if strings.Contains(BuildSkillsScript(instance), SkillsManifestPath) {
+		t.Error("explicit skillsPrune=false must not prune")
+	}
+}
+
+func TestBuildSkillsScript_PruneLastSkillRemoved(t *testing.T) {
+	instance := newTestInstance("prune-last")
+	instance.Spec.Skills = []string{"pack:base"}
+	instance.Spec.SkillsPrune = Ptr(true)
+
+	script := BuildSkillsScript(instance)
+	for _, want := range []string{
+		"_desired=''",
+		"done < " + SkillsManifestPath,
+		`grep -qxF -- "$_s" || _uninstall_skill "$_s"`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("prune script missing %q, got:\n%s", want, script)
+		}
+	}
+	if strings.Contains(script, "_install_skill '") || strings.Contains(script, "npm install") {
+		t.Errorf("no skill should be installed, got:\n%s", script)
+	}
+	lines := strings.Split(script, "\n")
+	if last := lines[len(lines)-1]; last != "rm -f "+SkillsManifestPath {
+		t.Errorf("manifest should be removed once everything is pruned, got %q", last)
+	}
+
+	if buildSkillsInitContainer(instance) == nil {
+		t.Error("init-skills container must still run to prune the removed skills")
+	}
+
+	instance.Spec.SkillsPrune = nil
+	if script := BuildSkillsScript(instance); script != "" {
+		t.Errorf("expected no script without skills or pruning, got:\n%s", script)
+	}
+}
+
 func TestBuildSkillsScript_MixedPrefixes(t *testing.T) {
 	instance := newTestInstance("mixed-skills")
 	instance.Spec.Skills = []string{
@@ -5437,6 +8168,53 @@ func TestBuildStatefulSet_NoSkills_NoInitSkillsContainer(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_InitSeccompProfile(t *testing.T) {
+	instance := newTestInstance("init-seccomp")
+	instance.Spec.Skills = []string{"@anthropic/mcp-server-fetch"}
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.InitContainers = []corev1.Container{{Name: "user-init", Image: "busybox"}}
+	instance.Spec.Security.InitSeccompProfile = &corev1.SeccompProfile{
+		Type:             corev1.SeccompProfileTypeLocalhost,
+		LocalhostProfile: Ptr("profiles/init.json"),
+	}
+
+	initContainers := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.InitContainers
+	seen := map[string]bool{}
+	for _, c := range initContainers {
+		var profile *corev1.SeccompProfile
+		if c.SecurityContext != nil {
+			profile = c.SecurityContext.SeccompProfile
+		}
+		switch c.Name {
+		case "init-config", "init-skills":
+			seen[c.Name] = true
+			if profile == nil || profile.Type != corev1.SeccompProfileTypeLocalhost ||
+				profile.LocalhostProfile == nil || *profile.LocalhostProfile != "profiles/init.json" {
+				t.Errorf("%s seccomp profile = %+v, want Localhost profiles/init.json", c.Name, profile)
+			}
+		case "chromium":
+			if profile == nil || profile.Type != corev1.SeccompProfileTypeRuntimeDefault {
+				t.Errorf("chromium sidecar seccomp profile = %+v, want RuntimeDefault", profile)
+			}
+		case "user-init":
+			if profile != nil {
+				t.Errorf("user init container seccomp profile should be untouched, got %+v", profile)
+			}
+		}
+	}
+	if !seen["init-config"] || !seen["init-skills"] {
+		t.Fatalf("expected init-config and init-skills, got %v", seen)
+	}
+
+	// Default stays RuntimeDefault
+	instance.Spec.Security.InitSeccompProfile = nil
+	for _, c := range BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.InitContainers {
+		if c.Name == "init-config" && c.SecurityContext.SeccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault {
+			t.Errorf("init-config seccomp profile = %v, want RuntimeDefault", c.SecurityContext.SeccompProfile.Type)
+		}
+	}
+}
+
 func TestBuildStatefulSet_WithSkills_InitSkillsContainer(t *testing.T) {
 	instance := newTestInstance("skills-sts")
 	instance.Spec.Skills = []string{"@anthropic/mcp-server-fetch"}
@@ -5588,6 +8366,23 @@ func TestBuildStatefulSet_WithSkills_SkillsTmpVolume(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_PruneNoSkills_InitMountsResolve(t *testing.T) {
+	instance := newTestInstance("prune-no-skills")
+	instance.Spec.SkillsPrune = Ptr(true)
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	if findVolume(sts.Spec.Template.Spec.Volumes, "skills-tmp") == nil {
+		t.Fatal("skills-tmp volume must exist for the prune-only init-skills container")
+	}
+	for _, c := range sts.Spec.Template.Spec.InitContainers {
+		for _, m := range c.VolumeMounts {
+			if findVolume(sts.Spec.Template.Spec.Volumes, m.Name) == nil {
+				t.Errorf("init container %q mounts missing volume %q", c.Name, m.Name)
+			}
+		}
+	}
+}
+
 func TestBuildStatefulSet_NoSkills_NoSkillsTmpVolume(t *testing.T) {
 	instance := newTestInstance("no-skills-vol")
 
@@ -5787,6 +8582,57 @@ func TestConfigHash_ChangesWithSkills(t *testing.T) {
 	}
 }
 
+func TestConfigHash_ExcludeExtraConfigMapData(t *testing.T) {
+	instance := newTestInstance("hash-exclude-extra")
+	instance.Spec.ConfigHashExclude = []string{"extraConfigMapData"}
+
+	hash1 := calculateConfigHash(instance, nil, nil)
+	instance.Spec.ExtraConfigMapData = map[string]string{"notes.txt": "hello"}
+	hash2 := calculateConfigHash(instance, nil, nil)
+	if hash1 != hash2 {
+		t.Error("config hash should not change with extraConfigMapData when it is excluded")
+	}
+
+	// Other inputs are still hashed
+	instance.Spec.Plugins = []string{"some-plugin"}
+	if calculateConfigHash(instance, nil, nil) == hash2 {
+		t.Error("config hash should still change with plugins")
+	}
+}
+
+func TestBuildStatefulSet_DisableConfigHashAnnotation(t *testing.T) {
+	instance := newTestInstance("no-hash-annotation")
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{Raw: []byte(`{"agents":{"defaults":{"model":"x"}}}`)},
+	}
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	if _, ok := sts.Spec.Template.Annotations[AnnotationKey("config-hash")]; !ok {
+		t.Fatal("config-hash annotation should be present by default")
+	}
+
+	instance.Spec.DisableConfigHashAnnotation = Ptr(true)
+	sts = BuildStatefulSet(instance, "", nil, nil, nil)
+	if _, ok := sts.Spec.Template.Annotations[AnnotationKey("config-hash")]; ok {
+		t.Error("config-hash annotation should be absent when disabled")
+	}
+
+	// The config is still rendered and mounted
+	cm := BuildConfigMap(instance, "", nil)
+	if !strings.Contains(cm.Data[ConfigFileName(instance)], `"model"`) {
+		t.Errorf("config not rendered: %q", cm.Data[ConfigFileName(instance)])
+	}
+	if findVolume(sts.Spec.Template.Spec.Volumes, "config") == nil {
+		t.Error("config volume should still be mounted")
+	}
+
+	// Jobs keep the hash on the Job so spec changes still replace them
+	job := BuildJob(instance, "", nil, nil, nil)
+	if job.Annotations[AnnotationKey("config-hash")] == "" {
+		t.Error("Job should keep the config-hash annotation")
+	}
+}
+
 func TestBuildStatefulSet_SkillsOnly_HasBothInitContainers(t *testing.T) {
 	instance := newTestInstance("skills-only")
 	instance.Spec.Skills = []string{"some-skill"}
@@ -6444,57 +9290,186 @@ func TestBuildStatefulSet_GatewayTokenEnv(t *testing.T) {
 	instance := newTestInstance("gw-env-test")
 	secretName := "gw-env-test-gateway-token"
 
-	sts := BuildStatefulSet(instance, secretName, nil, nil, nil)
+	sts := BuildStatefulSet(instance, secretName, nil, nil, nil)
+
+	main := sts.Spec.Template.Spec.Containers[0]
+	var gwEnv *corev1.EnvVar
+	for i := range main.Env {
+		if main.Env[i].Name == "OPENCLAW_GATEWAY_TOKEN" {
+			gwEnv = &main.Env[i]
+			break
+		}
+	}
+
+	if gwEnv == nil {
+		t.Fatal("OPENCLAW_GATEWAY_TOKEN env var not found")
+	}
+	if gwEnv.ValueFrom == nil || gwEnv.ValueFrom.SecretKeyRef == nil {
+		t.Fatal("OPENCLAW_GATEWAY_TOKEN should use SecretKeyRef")
+	}
+	if gwEnv.ValueFrom.SecretKeyRef.Name != secretName {
+		t.Errorf("secret name = %q, want %q", gwEnv.ValueFrom.SecretKeyRef.Name, secretName)
+	}
+	if gwEnv.ValueFrom.SecretKeyRef.Key != GatewayTokenSecretKey {
+		t.Errorf("secret key = %q, want %q", gwEnv.ValueFrom.SecretKeyRef.Key, GatewayTokenSecretKey)
+	}
+}
+
+func TestBuildStatefulSet_GatewayTokenEnv_UserOverride(t *testing.T) {
+	instance := newTestInstance("gw-override")
+	instance.Spec.Env = []corev1.EnvVar{
+		{Name: "OPENCLAW_GATEWAY_TOKEN", Value: "user-provided-token"},
+	}
+	secretName := "gw-override-gateway-token"
+
+	sts := BuildStatefulSet(instance, secretName, nil, nil, nil)
+
+	main := sts.Spec.Template.Spec.Containers[0]
+	// Count occurrences of OPENCLAW_GATEWAY_TOKEN
+	count := 0
+	for _, env := range main.Env {
+		if env.Name == "OPENCLAW_GATEWAY_TOKEN" {
+			count++
+			// The one present should be the user's value, not a SecretKeyRef
+			if env.Value != "user-provided-token" {
+				t.Errorf("OPENCLAW_GATEWAY_TOKEN value = %q, want %q (user's value)", env.Value, "user-provided-token")
+			}
+			if env.ValueFrom != nil {
+				t.Error("user's OPENCLAW_GATEWAY_TOKEN should not use SecretKeyRef")
+			}
+		}
+	}
+	if count != 1 {
+		t.Errorf("expected exactly 1 OPENCLAW_GATEWAY_TOKEN env var, got %d", count)
+	}
+}
+
+func TestBuildConfigMap_TokenFromEnvOnly(t *testing.T) {
+	instance := newTestInstance("token-env-only")
+	instance.Spec.Gateway.TokenFromEnvOnly = Ptr(true)
+	const token = "abc123secret"
+
+	cm := BuildConfigMap(instance, token, nil)
+	data := cm.Data["openclaw.json"]
+	if strings.Contains(data, token) {
+		t.Errorf("ConfigMap should not contain the gateway token, got: %s", data)
+	}
+	var cfg map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	auth := cfg["gateway"].(map[string]interface{})["auth"].(map[string]interface{})
+	if auth["mode"] != "token" {
+		t.Errorf("gateway.auth.mode = %v, want token", auth["mode"])
+	}
+	if _, ok := auth["token"]; ok {
+		t.Error("gateway.auth.token should be omitted")
+	}
+
+	sts := BuildStatefulSet(instance, GatewayTokenSecretName(instance), nil, nil, nil)
+	found := false
+	for _, env := range sts.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "OPENCLAW_GATEWAY_TOKEN" && env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("OPENCLAW_GATEWAY_TOKEN env var should still be sourced from the Secret")
+	}
+
+	// Default keeps embedding the token
+	instance.Spec.Gateway.TokenFromEnvOnly = nil
+	if cm := BuildConfigMap(instance, token, nil); !strings.Contains(cm.Data["openclaw.json"], token) {
+		t.Error("ConfigMap should embed the gateway token by default")
+	}
+}
+
+func TestBuildConfigMap_GatewayBasicAuth(t *testing.T) {
+	instance := newTestInstance("basic-auth")
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{Raw: []byte(`{"gateway":{"auth":{"mode":"token","allowTailscale":true}}}`)},
+	}
+	instance.Spec.Gateway.AuthMode = GatewayAuthModeBasic
+	instance.Spec.Gateway.BasicAuthSecret = "gw-creds"
+	const token = "abc123secret"
+
+	data := BuildConfigMap(instance, token, nil).Data["openclaw.json"]
+	if strings.Contains(data, token) {
+		t.Errorf("ConfigMap should not contain the gateway token in basic mode, got: %s", data)
+	}
+	var cfg map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	auth := cfg["gateway"].(map[string]interface{})["auth"].(map[string]interface{})
+	if auth["mode"] != "basic" {
+		t.Errorf("gateway.auth.mode = %v, want basic", auth["mode"])
+	}
+	if auth["allowTailscale"] != true {
+		t.Error("other gateway.auth fields should be preserved")
+	}
+
+	// Token auth stays the default
+	instance.Spec.Gateway.AuthMode = ""
+	if data := BuildConfigMap(instance, token, nil).Data["openclaw.json"]; !strings.Contains(data, token) {
+		t.Error("ConfigMap should embed the gateway token when authMode is unset")
+	}
+}
+
+func TestBuildStatefulSet_GatewayBasicAuthEnv(t *testing.T) {
+	instance := newTestInstance("basic-auth-env")
+	instance.Spec.Gateway.AuthMode = GatewayAuthModeBasic
+	instance.Spec.Gateway.BasicAuthSecret = "gw-creds"
+	instance.Spec.Env = []corev1.EnvVar{{Name: "OPENCLAW_GATEWAY_USERNAME", Value: "admin"}}
 
-	main := sts.Spec.Template.Spec.Containers[0]
-	var gwEnv *corev1.EnvVar
-	for i := range main.Env {
-		if main.Env[i].Name == "OPENCLAW_GATEWAY_TOKEN" {
-			gwEnv = &main.Env[i]
-			break
+	sts := BuildStatefulSet(instance, GatewayTokenSecretName(instance), nil, nil, nil)
+	env := map[string]corev1.EnvVar{}
+	for _, e := range sts.Spec.Template.Spec.Containers[0].Env {
+		if _, dup := env[e.Name]; dup {
+			t.Errorf("duplicate env var %s", e.Name)
 		}
+		env[e.Name] = e
 	}
 
-	if gwEnv == nil {
-		t.Fatal("OPENCLAW_GATEWAY_TOKEN env var not found")
+	if _, ok := env["OPENCLAW_GATEWAY_TOKEN"]; ok {
+		t.Error("OPENCLAW_GATEWAY_TOKEN should not be injected in basic mode")
 	}
-	if gwEnv.ValueFrom == nil || gwEnv.ValueFrom.SecretKeyRef == nil {
-		t.Fatal("OPENCLAW_GATEWAY_TOKEN should use SecretKeyRef")
+	if env["OPENCLAW_GATEWAY_USERNAME"].Value != "admin" {
+		t.Error("user-set OPENCLAW_GATEWAY_USERNAME should win")
 	}
-	if gwEnv.ValueFrom.SecretKeyRef.Name != secretName {
-		t.Errorf("secret name = %q, want %q", gwEnv.ValueFrom.SecretKeyRef.Name, secretName)
+	pw := env["OPENCLAW_GATEWAY_PASSWORD"]
+	if pw.ValueFrom == nil || pw.ValueFrom.SecretKeyRef == nil {
+		t.Fatal("OPENCLAW_GATEWAY_PASSWORD should use SecretKeyRef")
 	}
-	if gwEnv.ValueFrom.SecretKeyRef.Key != GatewayTokenSecretKey {
-		t.Errorf("secret key = %q, want %q", gwEnv.ValueFrom.SecretKeyRef.Key, GatewayTokenSecretKey)
+	if ref := pw.ValueFrom.SecretKeyRef; ref.Name != "gw-creds" || ref.Key != "password" {
+		t.Errorf("password ref = %s/%s, want gw-creds/password", ref.Name, ref.Key)
 	}
 }
 
-func TestBuildStatefulSet_GatewayTokenEnv_UserOverride(t *testing.T) {
-	instance := newTestInstance("gw-override")
-	instance.Spec.Env = []corev1.EnvVar{
-		{Name: "OPENCLAW_GATEWAY_TOKEN", Value: "user-provided-token"},
-	}
-	secretName := "gw-override-gateway-token"
+func TestBuildStatefulSet_ExistingSecretCustomKey(t *testing.T) {
+	instance := newTestInstance("existing-secret-key")
+	instance.Spec.Gateway.ExistingSecret = "shared-creds"
+	instance.Spec.Gateway.ExistingSecretKey = "gateway-token"
 
-	sts := BuildStatefulSet(instance, secretName, nil, nil, nil)
+	sts := BuildStatefulSet(instance, "shared-creds", nil, nil, nil)
 
-	main := sts.Spec.Template.Spec.Containers[0]
-	// Count occurrences of OPENCLAW_GATEWAY_TOKEN
-	count := 0
-	for _, env := range main.Env {
-		if env.Name == "OPENCLAW_GATEWAY_TOKEN" {
-			count++
-			// The one present should be the user's value, not a SecretKeyRef
-			if env.Value != "user-provided-token" {
-				t.Errorf("OPENCLAW_GATEWAY_TOKEN value = %q, want %q (user's value)", env.Value, "user-provided-token")
-			}
-			if env.ValueFrom != nil {
-				t.Error("user's OPENCLAW_GATEWAY_TOKEN should not use SecretKeyRef")
-			}
+	var ref *corev1.SecretKeySelector
+	for _, env := range sts.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "OPENCLAW_GATEWAY_TOKEN" && env.ValueFrom != nil {
+			ref = env.ValueFrom.SecretKeyRef
 		}
 	}
-	if count != 1 {
-		t.Errorf("expected exactly 1 OPENCLAW_GATEWAY_TOKEN env var, got %d", count)
+	if ref == nil {
+		t.Fatal("OPENCLAW_GATEWAY_TOKEN should use SecretKeyRef")
+	}
+	if ref.Name != "shared-creds" || ref.Key != "gateway-token" {
+		t.Errorf("secretKeyRef = %s/%s, want shared-creds/gateway-token", ref.Name, ref.Key)
+	}
+
+	// The key only applies to a user-managed Secret
+	instance.Spec.Gateway.ExistingSecret = ""
+	if got := GatewayTokenKey(instance); got != GatewayTokenSecretKey {
+		t.Errorf("GatewayTokenKey() without existingSecret = %q, want %q", got, GatewayTokenSecretKey)
 	}
 }
 
@@ -6637,6 +9612,32 @@ func TestBuildStatefulSet_FSGroupChangePolicy_Always(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_DisableFSGroup(t *testing.T) {
+	instance := newTestInstance("fsgroup-off")
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	if psc := sts.Spec.Template.Spec.SecurityContext; psc.FSGroup == nil || *psc.FSGroup != 1000 {
+		t.Errorf("default FSGroup = %v, want 1000", psc.FSGroup)
+	}
+
+	policy := corev1.FSGroupChangeOnRootMismatch
+	instance.Spec.Security.PodSecurityContext = &openclawv1alpha1.PodSecurityContextSpec{
+		FSGroup:             Ptr(int64(1000)),
+		FSGroupChangePolicy: &policy,
+		DisableFSGroup:      Ptr(true),
+	}
+	sts = BuildStatefulSet(instance, "", nil, nil, nil)
+	psc := sts.Spec.Template.Spec.SecurityContext
+	if psc.FSGroup != nil {
+		t.Errorf("FSGroup should be nil when disabled, got %d", *psc.FSGroup)
+	}
+	if psc.FSGroupChangePolicy != nil {
+		t.Error("FSGroupChangePolicy should be nil when fsGroup is disabled")
+	}
+	if psc.RunAsUser == nil || *psc.RunAsUser != 1000 {
+		t.Error("RunAsUser should be unaffected")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Feature: SA annotations
 // ---------------------------------------------------------------------------
@@ -7345,6 +10346,105 @@ func TestBuildStatefulSet_RuntimeDeps_InitContainerOrder(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_ConfigValidateOnStart(t *testing.T) {
+	instance := newTestInstance("validate-on-start")
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{Raw: []byte(`{"agents":{}}`)},
+	}
+	instance.Spec.Maintenance.Enabled = true
+	instance.Spec.Maintenance.Command = []string{"true"}
+
+	names := func() []string {
+		var out []string
+		for _, c := range BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.InitContainers {
+			out = append(out, c.Name)
+		}
+		return out
+	}
+
+	if slices.Contains(names(), "init-validate") {
+		t.Fatal("init-validate should not be present by default")
+	}
+
+	instance.Spec.Config.ValidateOnStart = Ptr(true)
+	got := names()
+	i := slices.Index(got, "init-validate")
+	if i < 0 {
+		t.Fatalf("init-validate not found in %v", got)
+	}
+	if i == 0 || got[i-1] != "init-config" {
+		t.Errorf("init-validate should run right after init-config, got %v", got)
+	}
+	if i+1 >= len(got) || got[i+1] != "init-maintenance" {
+		t.Errorf("init-validate should run before init-maintenance, got %v", got)
+	}
+
+	validate := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.InitContainers[i]
+	if validate.Image != GetImage(instance) {
+		t.Errorf("init-validate image = %q, want the OpenClaw image", validate.Image)
+	}
+	if validate.Command[0] != "node" || validate.Command[len(validate.Command)-1] != "/data/openclaw.json" {
+		t.Errorf("unexpected init-validate command %v", validate.Command)
+	}
+	if len(validate.VolumeMounts) != 1 || validate.VolumeMounts[0].Name != "data" || !validate.VolumeMounts[0].ReadOnly {
+		t.Errorf("init-validate should mount the data volume read-only, got %v", validate.VolumeMounts)
+	}
+}
+
+func TestBuildStatefulSet_Maintenance(t *testing.T) {
+	instance := newTestInstance("maint")
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{Raw: []byte(`{}`)},
+	}
+	baseHash := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Annotations["openclaw.rocks/config-hash"]
+
+	instance.Spec.Maintenance = openclawv1alpha1.MaintenanceSpec{
+		Enabled: true,
+		Command: []string{"sh", "-c", "openclaw migrate"},
+	}
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	initContainers := sts.Spec.Template.Spec.InitContainers
+
+	// Runs right after config init
+	if len(initContainers) < 2 || initContainers[0].Name != "init-config" || initContainers[1].Name != "init-maintenance" {
+		t.Fatalf("expected init-config then init-maintenance, got %d init containers", len(initContainers))
+	}
+	c := initContainers[1]
+	if c.Image != GetImage(instance) {
+		t.Errorf("image = %q, want the OpenClaw image", c.Image)
+	}
+	if !slices.Equal(c.Command, []string{"sh", "-c", "openclaw migrate"}) {
+		t.Errorf("command = %v", c.Command)
+	}
+	assertVolumeMount(t, c.VolumeMounts, "data", "/home/openclaw/.openclaw")
+	assertVolumeMount(t, c.VolumeMounts, "tmp", "/tmp")
+
+	hash := sts.Spec.Template.Annotations["openclaw.rocks/config-hash"]
+	if hash == baseHash {
+		t.Error("enabling maintenance should change the config hash")
+	}
+
+	// Changing the command re-runs it via a rollout
+	instance.Spec.Maintenance.Command = []string{"sh", "-c", "openclaw migrate --v2"}
+	if BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Annotations["openclaw.rocks/config-hash"] == hash {
+		t.Error("changing the maintenance command should change the config hash")
+	}
+
+	instance.Spec.Maintenance.Image = "migrator:1.0"
+	instance.Spec.Registry = "my-registry.example.com"
+	c = BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.InitContainers[1]
+	if c.Image != "my-registry.example.com/migrator:1.0" {
+		t.Errorf("image = %q, want registry-overridden custom image", c.Image)
+	}
+
+	instance.Spec.Maintenance.Enabled = false
+	for _, c := range BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.InitContainers {
+		if c.Name == "init-maintenance" {
+			t.Error("init-maintenance should not be added when disabled")
+		}
+	}
+}
+
 func TestBuildStatefulSet_RuntimeDeps_Pnpm_CABundle(t *testing.T) {
 	instance := newTestInstance("pnpm-ca")
 	instance.Spec.RuntimeDeps.Pnpm = true
@@ -7485,6 +10585,87 @@ func TestBuildConfigMap_TailscaleServeConfig_Funnel(t *testing.T) {
 	}
 }
 
+func TestBuildConfigMap_TailscaleServeConfig_CustomPort(t *testing.T) {
+	instance := newTestInstance("ts-serve-port")
+	instance.Spec.Tailscale.Enabled = true
+	instance.Spec.Tailscale.Mode = "funnel"
+	instance.Spec.Tailscale.ServePort = Ptr(int32(8443))
+
+	cm := BuildConfigMap(instance, "", nil)
+
+	var cfg struct {
+		TCP         map[string]map[string]interface{} `json:"TCP"`
+		Web         map[string]interface{}            `json:"Web"`
+		AllowFunnel map[string]bool                   `json:"AllowFunnel"`
+	}
+	if err := json.Unmarshal([]byte(cm.Data[TailscaleServeConfigKey]), &cfg); err != nil {
+		t.Fatalf("failed to parse tailscale serve config: %v", err)
+	}
+
+	if _, ok := cfg.TCP["8443"]; !ok || len(cfg.TCP) != 1 {
+		t.Errorf("TCP handlers = %v, want only 8443", cfg.TCP)
+	}
+	if _, ok := cfg.Web["${TS_CERT_DOMAIN}:8443"]; !ok || len(cfg.Web) != 1 {
+		t.Errorf("Web handlers = %v, want only ${TS_CERT_DOMAIN}:8443", cfg.Web)
+	}
+	if !cfg.AllowFunnel["${TS_CERT_DOMAIN}:8443"] {
+		t.Errorf("AllowFunnel = %v, want ${TS_CERT_DOMAIN}:8443", cfg.AllowFunnel)
+	}
+}
+
+func TestBuildConfigMap_TailscaleServeConfig_Canvas(t *testing.T) {
+	instance := newTestInstance("ts-serve-canvas")
+	instance.Spec.Tailscale.Enabled = true
+
+	type serveConfig struct {
+		TCP map[string]map[string]interface{} `json:"TCP"`
+		Web map[string]struct {
+			Handlers map[string]struct {
+				Proxy string `json:"Proxy"`
+			} `json:"Handlers"`
+		} `json:"Web"`
+		AllowFunnel map[string]bool `json:"AllowFunnel"`
+	}
+	parse := func() serveConfig {
+		t.Helper()
+		var cfg serveConfig
+		cm := BuildConfigMap(instance, "", nil)
+		if err := json.Unmarshal([]byte(cm.Data[TailscaleServeConfigKey]), &cfg); err != nil {
+			t.Fatalf("failed to parse tailscale serve config: %v", err)
+		}
+		return cfg
+	}
+
+	// Default: gateway only
+	cfg := parse()
+	if len(cfg.TCP) != 1 || len(cfg.Web) != 1 {
+		t.Errorf("default serve config should only serve the gateway, got TCP=%v Web=%v", cfg.TCP, cfg.Web)
+	}
+
+	instance.Spec.Tailscale.ServeCanvas = Ptr(true)
+	cfg = parse()
+	if _, ok := cfg.TCP["8443"]; !ok {
+		t.Errorf("expected TCP handler for 8443, got %v", cfg.TCP)
+	}
+	canvas := cfg.Web["${TS_CERT_DOMAIN}:8443"].Handlers["/"].Proxy
+	if want := fmt.Sprintf("http://127.0.0.1:%d", CanvasPort); canvas != want {
+		t.Errorf("canvas proxy = %q, want %q", canvas, want)
+	}
+	gateway := cfg.Web["${TS_CERT_DOMAIN}:443"].Handlers["/"].Proxy
+	if want := fmt.Sprintf("http://127.0.0.1:%d", GatewayPort); gateway != want {
+		t.Errorf("gateway proxy = %q, want %q", gateway, want)
+	}
+	if cfg.AllowFunnel != nil {
+		t.Errorf("serve mode should not set AllowFunnel, got %v", cfg.AllowFunnel)
+	}
+
+	instance.Spec.Tailscale.Mode = "funnel"
+	cfg = parse()
+	if !cfg.AllowFunnel["${TS_CERT_DOMAIN}:443"] || !cfg.AllowFunnel["${TS_CERT_DOMAIN}:8443"] {
+		t.Errorf("funnel mode should funnel both ports, got %v", cfg.AllowFunnel)
+	}
+}
+
 func TestBuildConfigMap_TailscaleDisabled_NoServeConfig(t *testing.T) {
 	instance := newTestInstance("ts-disabled-cfg")
 
@@ -8018,6 +11199,54 @@ func TestConfigHash_ChangesWithTailscale(t *testing.T) {
 	}
 }
 
+func TestConfigHash_ChangesWithGatewayBind(t *testing.T) {
+	instance := newTestInstance("hash-bind")
+	hash1 := calculateConfigHash(instance, nil, nil)
+
+	instance.Spec.Gateway.Bind = "lan"
+	hash2 := calculateConfigHash(instance, nil, nil)
+	if hash1 == hash2 {
+		t.Error("config hash should change when gateway.bind is set")
+	}
+
+	instance.Spec.Gateway.Bind = "loopback"
+	if calculateConfigHash(instance, nil, nil) == hash2 {
+		t.Error("config hash should change when gateway.bind changes")
+	}
+}
+
+func TestConfigHash_ChangesWithTokenFromEnvOnly(t *testing.T) {
+	instance := newTestInstance("hash-token-env")
+	hash1 := calculateConfigHash(instance, nil, nil)
+
+	instance.Spec.Gateway.TokenFromEnvOnly = Ptr(false)
+	if calculateConfigHash(instance, nil, nil) != hash1 {
+		t.Error("config hash should not change for an explicit default")
+	}
+
+	instance.Spec.Gateway.TokenFromEnvOnly = Ptr(true)
+	if calculateConfigHash(instance, nil, nil) == hash1 {
+		t.Error("config hash should change when tokenFromEnvOnly is enabled")
+	}
+}
+
+func TestConfigHash_ChangesWithChromiumProfiles(t *testing.T) {
+	instance := newTestInstance("hash-profiles")
+	instance.Spec.Chromium.Enabled = true
+	hash1 := calculateConfigHash(instance, nil, nil)
+
+	instance.Spec.Chromium.Profiles = []openclawv1alpha1.BrowserProfileSpec{{Name: "work", Color: "#FF0000"}}
+	hash2 := calculateConfigHash(instance, nil, nil)
+	if hash1 == hash2 {
+		t.Error("config hash should change when a browser profile is added")
+	}
+
+	instance.Spec.Chromium.Profiles[0].Color = "#00FF00"
+	if calculateConfigHash(instance, nil, nil) == hash2 {
+		t.Error("config hash should change when a browser profile changes")
+	}
+}
+
 func TestBuildConfigMap_TailscaleDefaultMode_ServeConfig(t *testing.T) {
 	instance := newTestInstance("ts-default-mode")
 	instance.Spec.Tailscale.Enabled = true
@@ -8397,43 +11626,235 @@ func TestBuildConfigMap_ChromiumBrowserConfig(t *testing.T) {
 	if !ok {
 		t.Fatal("expected browser key in config when chromium is enabled")
 	}
-
-	if browser["defaultProfile"] != "default" {
-		t.Errorf("browser.defaultProfile = %v, want %q", browser["defaultProfile"], "default")
+
+	if browser["defaultProfile"] != "default" {
+		t.Errorf("browser.defaultProfile = %v, want %q", browser["defaultProfile"], "default")
+	}
+
+	// attachOnly must be true so OpenClaw skips local browser binary detection
+	// and goes straight to the remote CDP connection.
+	attachOnly, ok := browser["attachOnly"].(bool)
+	if !ok || !attachOnly {
+		t.Errorf("browser.attachOnly = %v, want true", browser["attachOnly"])
+	}
+
+	// remoteCdpTimeoutMs gives the browser service time to become ready,
+	// preventing permanent failure when tool registration fires first.
+	timeout, ok := browser["remoteCdpTimeoutMs"].(float64)
+	if !ok || timeout != 30000 {
+		t.Errorf("browser.remoteCdpTimeoutMs = %v, want 30000", browser["remoteCdpTimeoutMs"])
+	}
+
+	profiles, ok := browser["profiles"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected browser.profiles key")
+	}
+
+	// Both "default" and "chrome" profiles must use the env var reference
+	// which resolves to the Chromium sidecar's localhost address at runtime.
+	expectedCDP := "${OPENCLAW_CHROMIUM_CDP}"
+	for _, name := range []string{"default", "chrome"} {
+		p, ok := profiles[name].(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected browser.profiles.%s key", name)
+		}
+		if p["cdpUrl"] != expectedCDP {
+			t.Errorf("browser.profiles.%s.cdpUrl = %v, want %q", name, p["cdpUrl"], expectedCDP)
+		}
+		if p["color"] != "#4285F4" {
+			t.Errorf("browser.profiles.%s.color = %v, want %q", name, p["color"], "#4285F4")
+		}
+	}
+}
+
+func TestBuildStatefulSet_ChromiumCustomCDPEnvName(t *testing.T) {
+	instance := newTestInstance("cr-cdp-env")
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Chromium.CDPEnvName = "BROWSER_CDP_URL"
+
+	main := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.Containers[0]
+	var found bool
+	for _, env := range main.Env {
+		if env.Name == "OPENCLAW_CHROMIUM_CDP" {
+			t.Error("default CDP env var should be replaced by the custom name")
+		}
+		if env.Name == "BROWSER_CDP_URL" {
+			found = true
+			if want := fmt.Sprintf("http://127.0.0.1:%d", ChromiumPort); env.Value != want {
+				t.Errorf("BROWSER_CDP_URL = %q, want %q", env.Value, want)
+			}
+		}
+	}
+	if !found {
+		t.Error("main container should have BROWSER_CDP_URL")
+	}
+
+	// The browser profiles must reference the same env var
+	var parsed struct {
+		Browser struct {
+			Profiles map[string]struct {
+				CDPURL string `json:"cdpUrl"`
+			} `json:"profiles"`
+		} `json:"browser"`
+	}
+	if err := json.Unmarshal([]byte(BuildConfigMap(instance, "", nil).Data["openclaw.json"]), &parsed); err != nil {
+		t.Fatalf("failed to parse config JSON: %v", err)
+	}
+	for _, name := range []string{"default", "chrome"} {
+		if got := parsed.Browser.Profiles[name].CDPURL; got != "${BROWSER_CDP_URL}" {
+			t.Errorf("browser.profiles.%s.cdpUrl = %q, want ${BROWSER_CDP_URL}", name, got)
+		}
+	}
+}
+
+func TestBuildConfigMap_ChromiumProfiles(t *testing.T) {
+	instance := newTestInstance("cr-profiles")
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Chromium.Profiles = []openclawv1alpha1.BrowserProfileSpec{
+		{Name: "default", Color: "#FF0000"},
+		{Name: "research", Color: "#00FF00"},
+		{Name: "remote", CDPURL: "http://browser.example.com:9222"},
+	}
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: runtime.RawExtension{Raw: []byte(`{"browser":{"profiles":{"research":{"color":"#0000FF"}}}}`)},
+	}
+
+	var parsed struct {
+		Browser struct {
+			Profiles map[string]struct {
+				CDPURL string `json:"cdpUrl"`
+				Color  string `json:"color"`
+			} `json:"profiles"`
+		} `json:"browser"`
+	}
+	if err := json.Unmarshal([]byte(BuildConfigMap(instance, "", nil).Data["openclaw.json"]), &parsed); err != nil {
+		t.Fatalf("failed to parse config JSON: %v", err)
+	}
+	profiles := parsed.Browser.Profiles
+
+	tests := []struct {
+		name, color, cdpURL string
+	}{
+		{"default", "#FF0000", "${OPENCLAW_CHROMIUM_CDP}"},
+		{"chrome", "#4285F4", "${OPENCLAW_CHROMIUM_CDP}"},
+		{"research", "#0000FF", "${OPENCLAW_CHROMIUM_CDP}"}, // raw config wins
+		{"remote", "#4285F4", "http://browser.example.com:9222"},
+	}
+	for _, tt := range tests {
+		p, ok := profiles[tt.name]
+		if !ok {
+			t.Errorf("missing browser.profiles.%s", tt.name)
+			continue
+		}
+		if p.Color != tt.color || p.CDPURL != tt.cdpURL {
+			t.Errorf("browser.profiles.%s = {color: %q, cdpUrl: %q}, want {%q, %q}", tt.name, p.Color, p.CDPURL, tt.color, tt.cdpURL)
+		}
+	}
+}
+
+func TestBuildStatefulSet_ChromiumTmpSizeLimit(t *testing.T) {
+	instance := newTestInstance("cr-chromium-tmp")
+	instance.Spec.Chromium.Enabled = true
+
+	findTmp := func() corev1.Volume {
+		t.Helper()
+		for _, v := range BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.Volumes {
+			if v.Name == "chromium-tmp" {
+				return v
+			}
+		}
+		t.Fatal("chromium-tmp volume not found")
+		return corev1.Volume{}
+	}
+
+	if v := findTmp(); v.EmptyDir == nil || v.EmptyDir.SizeLimit != nil {
+		t.Errorf("chromium-tmp should be an unlimited emptyDir by default, got %+v", v.EmptyDir)
+	}
+
+	instance.Spec.Chromium.TmpSizeLimit = "2Gi"
+	v := findTmp()
+	if v.EmptyDir == nil || v.EmptyDir.SizeLimit == nil {
+		t.Fatal("chromium-tmp should have a size limit")
+	}
+	if want := resource.MustParse("2Gi"); v.EmptyDir.SizeLimit.Cmp(want) != 0 {
+		t.Errorf("chromium-tmp sizeLimit = %s, want 2Gi", v.EmptyDir.SizeLimit.String())
+	}
+}
+
+func TestBuildStatefulSet_ChromiumReplicas(t *testing.T) {
+	instance := newTestInstance("cr-chromium-replicas")
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Chromium.Replicas = Ptr(int32(2))
+	instance.Spec.Chromium.Persistence.Enabled = true
+
+	podSpec := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec
+	var chromium []corev1.Container
+	for _, c := range podSpec.InitContainers {
+		if strings.HasPrefix(c.Name, "chromium") {
+			chromium = append(chromium, c)
+		}
+	}
+	if len(chromium) != 2 {
+		t.Fatalf("expected 2 chromium sidecars, got %d", len(chromium))
+	}
+
+	for i, want := range []struct {
+		name, dataVolume string
+		port             int32
+	}{
+		{"chromium-0", "chromium-data", 9222},
+		{"chromium-1", "chromium-data-1", 9224},
+	} {
+		c := chromium[i]
+		if c.Name != want.name {
+			t.Errorf("sidecar %d name = %q, want %q", i, c.Name, want.name)
+		}
+		if len(c.Ports) != 1 || c.Ports[0].ContainerPort != want.port {
+			t.Errorf("%s ports = %v, want %d", c.Name, c.Ports, want.port)
+		}
+		if got := c.StartupProbe.HTTPGet.Port.IntVal; got != want.port {
+			t.Errorf("%s startup probe port = %d, want %d", c.Name, got, want.port)
+		}
+		if !strings.Contains(c.Command[2], fmt.Sprintf("TCP4-LISTEN:%d,", want.port)) {
+			t.Errorf("%s entrypoint should bridge port %d: %q", c.Name, want.port, c.Command[2])
+		}
+		assertVolumeMount(t, c.VolumeMounts, want.dataVolume, "/chromium-data")
+	}
+	if err := ValidatePorts(instance); err != nil {
+		t.Errorf("ValidatePorts() = %v", err)
 	}
 
-	// attachOnly must be true so OpenClaw skips local browser binary detection
-	// and goes straight to the remote CDP connection.
-	attachOnly, ok := browser["attachOnly"].(bool)
-	if !ok || !attachOnly {
-		t.Errorf("browser.attachOnly = %v, want true", browser["attachOnly"])
+	// Only the first sidecar uses the persistent profile
+	for _, v := range podSpec.Volumes {
+		switch v.Name {
+		case "chromium-data":
+			if v.PersistentVolumeClaim == nil {
+				t.Error("chromium-data should be the PVC")
+			}
+		case "chromium-data-1":
+			if v.EmptyDir == nil {
+				t.Error("chromium-data-1 should be an emptyDir")
+			}
+		}
 	}
 
-	// remoteCdpTimeoutMs gives the browser service time to become ready,
-	// preventing permanent failure when tool registration fires first.
-	timeout, ok := browser["remoteCdpTimeoutMs"].(float64)
-	if !ok || timeout != 30000 {
-		t.Errorf("browser.remoteCdpTimeoutMs = %v, want 30000", browser["remoteCdpTimeoutMs"])
+	var parsed struct {
+		Browser struct {
+			Profiles map[string]struct {
+				CDPURL string `json:"cdpUrl"`
+			} `json:"profiles"`
+		} `json:"browser"`
 	}
-
-	profiles, ok := browser["profiles"].(map[string]interface{})
-	if !ok {
-		t.Fatal("expected browser.profiles key")
+	if err := json.Unmarshal([]byte(BuildConfigMap(instance, "", nil).Data["openclaw.json"]), &parsed); err != nil {
+		t.Fatalf("failed to parse config JSON: %v", err)
 	}
-
-	// Both "default" and "chrome" profiles must use the env var reference
-	// which resolves to the Chromium sidecar's localhost address at runtime.
-	expectedCDP := "${OPENCLAW_CHROMIUM_CDP}"
-	for _, name := range []string{"default", "chrome"} {
-		p, ok := profiles[name].(map[string]interface{})
-		if !ok {
-			t.Fatalf("expected browser.profiles.%s key", name)
-		}
-		if p["cdpUrl"] != expectedCDP {
-			t.Errorf("browser.profiles.%s.cdpUrl = %v, want %q", name, p["cdpUrl"], expectedCDP)
-		}
-		if p["color"] != "#4285F4" {
-			t.Errorf("browser.profiles.%s.color = %v, want %q", name, p["color"], "#4285F4")
+	for name, want := range map[string]string{
+		"default":    "${OPENCLAW_CHROMIUM_CDP}",
+		"chromium-0": "${OPENCLAW_CHROMIUM_CDP}",
+		"chromium-1": "http://127.0.0.1:9224",
+	} {
+		if got := parsed.Browser.Profiles[name].CDPURL; got != want {
+			t.Errorf("browser.profiles.%s.cdpUrl = %q, want %q", name, got, want)
 		}
 	}
 }
@@ -8742,6 +12163,35 @@ func TestBuildStatefulSet_OllamaEnabled_WithModels(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_OllamaInitTimeout(t *testing.T) {
+	instance := newTestInstance("ollama-timeout")
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Ollama.Models = []string{"llama3.2"}
+
+	initOllama := func() string {
+		for _, c := range BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.InitContainers {
+			if c.Name == "init-ollama" {
+				return c.Command[2]
+			}
+		}
+		t.Fatal("init-ollama container not found")
+		return ""
+	}
+
+	cmd := initOllama()
+	if !strings.Contains(cmd, "timeout 3600 ollama pull 'llama3.2'") {
+		t.Errorf("pull should be wrapped in the default timeout, got: %s", cmd)
+	}
+	if !strings.Contains(cmd, "if [ $rc -eq 124 ]") || !strings.Contains(cmd, "exit 1") {
+		t.Errorf("a timed-out pull should fail the container, got: %s", cmd)
+	}
+
+	instance.Spec.Ollama.InitTimeoutSeconds = Ptr(int32(600))
+	if cmd := initOllama(); !strings.Contains(cmd, "timeout 600 ollama pull 'llama3.2'") {
+		t.Errorf("pull should use the configured timeout, got: %s", cmd)
+	}
+}
+
 func TestBuildStatefulSet_OllamaEnabled_NoModels(t *testing.T) {
 	instance := newTestInstance("ollama-no-models")
 	instance.Spec.Ollama.Enabled = true
@@ -8807,6 +12257,124 @@ func TestBuildStatefulSet_OllamaEnabled_GPU(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_OllamaEnabled_MIGResourceName(t *testing.T) {
+	instance := newTestInstance("ollama-mig")
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Ollama.GPU = Ptr(int32(1))
+	instance.Spec.Ollama.GPUResourceName = "nvidia.com/mig-1g.5gb"
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+
+	var ollama *corev1.Container
+	for i := range sts.Spec.Template.Spec.Containers {
+		if sts.Spec.Template.Spec.Containers[i].Name == "ollama" {
+			ollama = &sts.Spec.Template.Spec.Containers[i]
+			break
+		}
+	}
+	if ollama == nil {
+		t.Fatal("ollama container not found")
+	}
+
+	migRes := corev1.ResourceName("nvidia.com/mig-1g.5gb")
+	if q, ok := ollama.Resources.Requests[migRes]; !ok || q.String() != "1" {
+		t.Errorf("MIG request = %v (present=%v), want 1", q.String(), ok)
+	}
+	if q, ok := ollama.Resources.Limits[migRes]; !ok || q.String() != "1" {
+		t.Errorf("MIG limit = %v (present=%v), want 1", q.String(), ok)
+	}
+	if _, ok := ollama.Resources.Limits[DefaultGPUResourceName]; ok {
+		t.Error("nvidia.com/gpu should not be set when gpuResourceName overrides it")
+	}
+}
+
+func TestBuildStatefulSet_OllamaRequireGPUNode(t *testing.T) {
+	instance := newTestInstance("ollama-gpu-node")
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Ollama.GPU = Ptr(int32(1))
+	instance.Spec.Ollama.RequireGPUNode = Ptr(true)
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	affinity := sts.Spec.Template.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil ||
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		t.Fatal("expected required nodeAffinity to be injected")
+	}
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) != 1 || len(terms[0].MatchExpressions) != 1 {
+		t.Fatalf("expected 1 term with 1 expression, got %+v", terms)
+	}
+	expr := terms[0].MatchExpressions[0]
+	if expr.Key != DefaultGPUNodeLabel || expr.Operator != corev1.NodeSelectorOpIn ||
+		len(expr.Values) != 1 || expr.Values[0] != "true" {
+		t.Errorf("unexpected GPU node expression: %+v", expr)
+	}
+
+	// Custom label
+	instance.Spec.Ollama.GPUNodeLabel = "example.com/has-gpu"
+	sts = BuildStatefulSet(instance, "", nil, nil, nil)
+	got := sts.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Key
+	if got != "example.com/has-gpu" {
+		t.Errorf("GPU node label = %q, want %q", got, "example.com/has-gpu")
+	}
+
+	// No GPUs requested - no affinity injected
+	instance.Spec.Ollama.GPU = nil
+	sts = BuildStatefulSet(instance, "", nil, nil, nil)
+	if sts.Spec.Template.Spec.Affinity != nil {
+		t.Errorf("expected no affinity without GPUs, got %+v", sts.Spec.Template.Spec.Affinity)
+	}
+}
+
+func TestBuildStatefulSet_OllamaRequireGPUNode_MergesUserAffinity(t *testing.T) {
+	instance := newTestInstance("ollama-gpu-merge")
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Ollama.GPU = Ptr(int32(2))
+	instance.Spec.Ollama.RequireGPUNode = Ptr(true)
+	instance.Spec.Availability.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+					}},
+					{MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"b"}},
+					}},
+				},
+			},
+		},
+		PodAntiAffinity: &corev1.PodAntiAffinity{},
+	}
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	affinity := sts.Spec.Template.Spec.Affinity
+	if affinity.PodAntiAffinity == nil {
+		t.Error("user podAntiAffinity should be preserved")
+	}
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) != 2 {
+		t.Fatalf("expected 2 node selector terms, got %d", len(terms))
+	}
+	for i, term := range terms {
+		if len(term.MatchExpressions) != 2 {
+			t.Fatalf("term %d: expected 2 expressions, got %d", i, len(term.MatchExpressions))
+		}
+		if term.MatchExpressions[0].Key != "topology.kubernetes.io/zone" {
+			t.Errorf("term %d: user expression not preserved: %+v", i, term.MatchExpressions[0])
+		}
+		if term.MatchExpressions[1].Key != DefaultGPUNodeLabel {
+			t.Errorf("term %d: GPU expression missing: %+v", i, term.MatchExpressions[1])
+		}
+	}
+
+	// The spec must not be mutated
+	userTerms := instance.Spec.Availability.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(userTerms[0].MatchExpressions) != 1 {
+		t.Error("buildAffinity mutated the instance spec")
+	}
+}
+
 func TestBuildStatefulSet_OllamaEnabled_ExistingClaim(t *testing.T) {
 	instance := newTestInstance("ollama-pvc")
 	instance.Spec.Ollama.Enabled = true
@@ -9313,6 +12881,90 @@ func TestBuildRole_SelfConfigureDisabled(t *testing.T) {
 	}
 }
 
+func TestBuildRole_SelfConfigureAdditionalConfigMaps(t *testing.T) {
+	instance := newTestInstance("sc-extra-cm")
+	instance.Spec.SelfConfigure = openclawv1alpha1.SelfConfigureSpec{
+		Enabled:              true,
+		AdditionalConfigMaps: []string{"prompt-library", "shared-tools"},
+	}
+
+	role := BuildRole(instance)
+
+	// The base rule for the operator-managed ConfigMap must stay intact
+	if role.Rules[0].ResourceNames[0] != ConfigMapName(instance) {
+		t.Errorf("base configmap rule changed: %+v", role.Rules[0])
+	}
+
+	extraIdx := -1
+	for i, rule := range role.Rules {
+		if i > 0 && len(rule.Resources) == 1 && rule.Resources[0] == "configmaps" {
+			extraIdx = i
+		}
+	}
+	if extraIdx < 0 {
+		t.Fatal("missing additional configmaps rule")
+	}
+	extra := role.Rules[extraIdx]
+	if len(extra.ResourceNames) != 2 || extra.ResourceNames[0] != "prompt-library" || extra.ResourceNames[1] != "shared-tools" {
+		t.Errorf("resourceNames = %v, want [prompt-library shared-tools]", extra.ResourceNames)
+	}
+	verbSet := map[string]bool{}
+	for _, v := range extra.Verbs {
+		verbSet[v] = true
+	}
+	for _, v := range []string{"get", "list", "watch"} {
+		if !verbSet[v] {
+			t.Errorf("missing verb %q", v)
+		}
+	}
+	if verbSet["update"] || verbSet["patch"] {
+		t.Errorf("write verbs should not be granted by default, got %v", extra.Verbs)
+	}
+
+	// Writable opt-in adds update/patch
+	instance.Spec.SelfConfigure.AdditionalConfigMapsWritable = Ptr(true)
+	role = BuildRole(instance)
+	last := role.Rules[len(role.Rules)-2]
+	if last.Resources[0] != "configmaps" {
+		t.Fatalf("expected configmaps rule before secrets rule, got %+v", last)
+	}
+	verbSet = map[string]bool{}
+	for _, v := range last.Verbs {
+		verbSet[v] = true
+	}
+	if !verbSet["update"] || !verbSet["patch"] {
+		t.Errorf("writable configmaps rule verbs = %v, want update and patch", last.Verbs)
+	}
+}
+
+func TestBuildRole_SelfConfigureReadOnly(t *testing.T) {
+	instance := newTestInstance("sc-readonly")
+	instance.Spec.SelfConfigure = openclawv1alpha1.SelfConfigureSpec{
+		Enabled:                      true,
+		ReadOnly:                     Ptr(true),
+		AdditionalConfigMaps:         []string{"prompt-library"},
+		AdditionalConfigMapsWritable: Ptr(true),
+	}
+
+	role := BuildRole(instance)
+
+	readVerbs := map[string]bool{"get": true, "list": true, "watch": true}
+	foundSelfConfigs := false
+	for _, rule := range role.Rules {
+		if len(rule.Resources) > 0 && rule.Resources[0] == "openclawselfconfigs" {
+			foundSelfConfigs = true
+		}
+		for _, v := range rule.Verbs {
+			if !readVerbs[v] {
+				t.Errorf("read-only role has write verb %q on %v", v, rule.Resources)
+			}
+		}
+	}
+	if !foundSelfConfigs {
+		t.Error("read-only role should still allow reading openclawselfconfigs")
+	}
+}
+
 func TestBuildRole_SelfConfigureWithEnvFromSecrets(t *testing.T) {
 	instance := newTestInstance("sc-envfrom")
 	instance.Spec.SelfConfigure = openclawv1alpha1.SelfConfigureSpec{
@@ -9372,6 +13024,69 @@ func TestBuildServiceAccount_SelfConfigureDisabledTokenMount(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_SelfConfigureTokenAudience(t *testing.T) {
+	instance := newTestInstance("sc-audience")
+	instance.Spec.SelfConfigure = openclawv1alpha1.SelfConfigureSpec{
+		Enabled:       true,
+		TokenAudience: "api-gateway.example.com",
+	}
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	podSpec := sts.Spec.Template.Spec
+
+	if podSpec.AutomountServiceAccountToken == nil || *podSpec.AutomountServiceAccountToken {
+		t.Error("AutomountServiceAccountToken should be false when a projected token is used")
+	}
+
+	vol := findVolume(podSpec.Volumes, "sa-token")
+	if vol == nil || vol.Projected == nil {
+		t.Fatal("expected projected sa-token volume")
+	}
+	var tokenProj *corev1.ServiceAccountTokenProjection
+	for _, src := range vol.Projected.Sources {
+		if src.ServiceAccountToken != nil {
+			tokenProj = src.ServiceAccountToken
+		}
+	}
+	if tokenProj == nil {
+		t.Fatal("expected serviceAccountToken projection")
+	}
+	if tokenProj.Audience != "api-gateway.example.com" {
+		t.Errorf("audience = %q, want %q", tokenProj.Audience, "api-gateway.example.com")
+	}
+	if tokenProj.Path != "token" {
+		t.Errorf("token path = %q, want %q", tokenProj.Path, "token")
+	}
+
+	main := podSpec.Containers[0]
+	found := false
+	for _, m := range main.VolumeMounts {
+		if m.Name == "sa-token" {
+			found = true
+			if m.MountPath != ServiceAccountTokenMountPath || !m.ReadOnly {
+				t.Errorf("unexpected sa-token mount: %+v", m)
+			}
+		}
+	}
+	if !found {
+		t.Error("main container should mount the sa-token volume")
+	}
+}
+
+func TestBuildStatefulSet_SelfConfigureNoTokenAudience(t *testing.T) {
+	instance := newTestInstance("sc-no-audience")
+	instance.Spec.SelfConfigure = openclawv1alpha1.SelfConfigureSpec{Enabled: true}
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+
+	if token := sts.Spec.Template.Spec.AutomountServiceAccountToken; token == nil || !*token {
+		t.Error("AutomountServiceAccountToken should stay true without tokenAudience")
+	}
+	if findVolume(sts.Spec.Template.Spec.Volumes, "sa-token") != nil {
+		t.Error("sa-token volume should not exist without tokenAudience")
+	}
+}
+
 func TestBuildStatefulSet_SelfConfigureEnvVars(t *testing.T) {
 	instance := newTestInstance("sc-env")
 	instance.Spec.SelfConfigure = openclawv1alpha1.SelfConfigureSpec{
@@ -9421,6 +13136,30 @@ func TestBuildStatefulSet_SelfConfigureAutoMount(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_RBACAutomountToken(t *testing.T) {
+	instance := newTestInstance("rbac-automount")
+	instance.Spec.Security.RBAC.AutomountToken = Ptr(true)
+
+	if token := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.AutomountServiceAccountToken; token == nil || !*token {
+		t.Errorf("pod AutomountServiceAccountToken = %v, want true with automountToken=true", token)
+	}
+	if token := BuildServiceAccount(instance).AutomountServiceAccountToken; token == nil || !*token {
+		t.Errorf("ServiceAccount AutomountServiceAccountToken = %v, want true with automountToken=true", token)
+	}
+}
+
+func TestBuildStatefulSet_RBACAutomountToken_SelfConfigureWins(t *testing.T) {
+	instance := newTestInstance("rbac-automount-sc")
+	instance.Spec.Security.RBAC.AutomountToken = Ptr(false)
+	instance.Spec.SelfConfigure = openclawv1alpha1.SelfConfigureSpec{
+		Enabled: true,
+	}
+
+	if token := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec.AutomountServiceAccountToken; token == nil || !*token {
+		t.Errorf("pod AutomountServiceAccountToken = %v, want true when self-configure is enabled", token)
+	}
+}
+
 func TestBuildNetworkPolicy_SelfConfigureEgress(t *testing.T) {
 	instance := newTestInstance("sc-netpol")
 	instance.Spec.SelfConfigure = openclawv1alpha1.SelfConfigureSpec{
@@ -9611,6 +13350,95 @@ func TestBuildPrometheusRule_CustomLabels(t *testing.T) {
 	}
 }
 
+func TestBuildPrometheusRule_ForDurations(t *testing.T) {
+	instance := newTestInstance("my-instance")
+	alertFor := func() map[string]string {
+		rules := BuildPrometheusRule(instance).Object["spec"].(map[string]interface{})["groups"].([]interface{})[0].(map[string]interface{})["rules"].([]interface{})
+		got := make(map[string]string, len(rules))
+		for _, r := range rules {
+			rule := r.(map[string]interface{})
+			got[rule["alert"].(string)] = rule["for"].(string)
+		}
+		return got
+	}
+
+	if got := alertFor()["OpenClawPodCrashLooping"]; got != "0m" {
+		t.Errorf("built-in for = %q, want %q", got, "0m")
+	}
+
+	builtin := alertFor()
+
+	instance.Spec.Observability.Metrics.PrometheusRule = &openclawv1alpha1.PrometheusRuleSpec{
+		Enabled:    Ptr(true),
+		DefaultFor: "15m",
+	}
+	for alert, d := range alertFor() {
+		want := builtin[alert]
+		if want == "0m" {
+			want = "15m"
+		}
+		if d != want {
+			t.Errorf("%s for = %q, want %q", alert, d, want)
+		}
+	}
+
+	instance.Spec.Observability.Metrics.PrometheusRule.AlertFor = map[string]string{
+		"OpenClawPodCrashLooping":  "2m",
+		"OpenClawInstanceDegraded": "30m",
+	}
+	got := alertFor()
+	if got["OpenClawPodCrashLooping"] != "2m" {
+		t.Errorf("OpenClawPodCrashLooping for = %q, want override %q", got["OpenClawPodCrashLooping"], "2m")
+	}
+	if got["OpenClawInstanceDegraded"] != "30m" {
+		t.Errorf("OpenClawInstanceDegraded for = %q, want override %q", got["OpenClawInstanceDegraded"], "30m")
+	}
+	if got["OpenClawPodOOMKilled"] != "15m" {
+		t.Errorf("OpenClawPodOOMKilled for = %q, want defaultFor %q", got["OpenClawPodOOMKilled"], "15m")
+	}
+	if got["OpenClawReconcileErrors"] != builtin["OpenClawReconcileErrors"] {
+		t.Errorf("OpenClawReconcileErrors for = %q, want built-in %q", got["OpenClawReconcileErrors"], builtin["OpenClawReconcileErrors"])
+	}
+	if len(got) != len(BuiltinAlertNames()) {
+		t.Errorf("expected %d alerts, got %d", len(BuiltinAlertNames()), len(got))
+	}
+}
+
+func TestBuildRecordingRulesConfigMap(t *testing.T) {
+	instance := newTestInstance("my-instance")
+	if IsRulesConfigMapEnabled(instance) {
+		t.Error("rules ConfigMap should be disabled by default")
+	}
+	instance.Spec.Observability.Metrics.RulesAsConfigMap = Ptr(true)
+	if !IsRulesConfigMapEnabled(instance) {
+		t.Error("rules ConfigMap should be enabled with rulesAsConfigMap=true")
+	}
+
+	cm := BuildRecordingRulesConfigMap(instance)
+
+	if cm.Name != "my-instance-rules" || cm.Namespace != instance.Namespace {
+		t.Errorf("ConfigMap = %s/%s, want %s/my-instance-rules", cm.Namespace, cm.Name, instance.Namespace)
+	}
+	if cm.Labels["prometheus_rule"] != "1" {
+		t.Errorf("prometheus_rule label = %q, want %q", cm.Labels["prometheus_rule"], "1")
+	}
+	if cm.Labels["app.kubernetes.io/name"] != "openclaw" {
+		t.Error("missing standard label")
+	}
+
+	var got, want map[string]interface{}
+	if err := json.Unmarshal([]byte(cm.Data[RulesConfigMapKey]), &got); err != nil {
+		t.Fatalf("rule file is not valid JSON/YAML: %v", err)
+	}
+	specJSON, _ := json.Marshal(BuildPrometheusRule(instance).Object["spec"])
+	if err := json.Unmarshal(specJSON, &want); err != nil {
+		t.Fatalf("failed to round-trip PrometheusRule spec: %v", err)
+	}
+	if !equality.Semantic.DeepEqual(got, want) {
+		t.Errorf("rule file groups differ from the PrometheusRule:\n got: %v\nwant: %v", got, want)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Grafana dashboard tests
 // ---------------------------------------------------------------------------
@@ -9744,6 +13572,20 @@ func TestBuildGrafanaDashboardInstance(t *testing.T) {
 	}
 }
 
+func TestBuildGrafanaDashboardInstance_CustomContainerName(t *testing.T) {
+	instance := newTestInstance("my-instance")
+	instance.Spec.ContainerName = "agent"
+
+	dashJSON := BuildGrafanaDashboardInstance(instance).Data["openclaw-instance.json"]
+	// 10 queries, two of which select the container twice
+	if got := strings.Count(dashJSON, `container=\"agent\"`); got != 12 {
+		t.Errorf("expected 12 container selectors for the custom name, got %d", got)
+	}
+	if strings.Contains(dashJSON, `container=\"openclaw\"`) {
+		t.Error("dashboard should not select the default container name")
+	}
+}
+
 func TestBuildGrafanaDashboard_CustomLabelsAndFolder(t *testing.T) {
 	instance := newTestInstance("my-instance")
 	instance.Spec.Observability.Metrics.GrafanaDashboard = &openclawv1alpha1.GrafanaDashboardSpec{
@@ -10002,6 +13844,45 @@ func TestBuildServiceMonitor_MetricsPort(t *testing.T) {
 	}
 }
 
+func TestBuildServiceMonitor_ScrapeTimeoutAndRelabeling(t *testing.T) {
+	instance := newTestInstance("sm-timeout")
+	instance.Spec.Observability.Metrics.ServiceMonitor = &openclawv1alpha1.ServiceMonitorSpec{
+		Enabled: Ptr(true),
+	}
+
+	ep := BuildServiceMonitor(instance).Object["spec"].(map[string]interface{})["endpoints"].([]interface{})[0].(map[string]interface{})
+	if _, ok := ep["scrapeTimeout"]; ok {
+		t.Error("scrapeTimeout should be omitted by default")
+	}
+
+	// The endpoint port name must match the Service's metrics port
+	found := false
+	for _, p := range BuildService(instance).Spec.Ports {
+		if p.Name == ep["port"] {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Service has no port named %q", ep["port"])
+	}
+
+	relabelings, ok := ep["relabelings"].([]interface{})
+	if !ok || len(relabelings) != 1 {
+		t.Fatalf("expected 1 relabeling, got %v", ep["relabelings"])
+	}
+	r := relabelings[0].(map[string]interface{})
+	if r["action"] != "replace" || r["targetLabel"] != "openclaw_instance" || r["replacement"] != "sm-timeout" {
+		t.Errorf("relabeling = %v, want openclaw_instance=sm-timeout", r)
+	}
+
+	instance.Spec.Observability.Metrics.ServiceMonitor.Interval = "1m"
+	instance.Spec.Observability.Metrics.ServiceMonitor.ScrapeTimeout = "20s"
+	ep = BuildServiceMonitor(instance).Object["spec"].(map[string]interface{})["endpoints"].([]interface{})[0].(map[string]interface{})
+	if ep["interval"] != "1m" || ep["scrapeTimeout"] != "20s" {
+		t.Errorf("interval/scrapeTimeout = %v/%v, want 1m/20s", ep["interval"], ep["scrapeTimeout"])
+	}
+}
+
 func TestBuildService_MetricsPortEnabled(t *testing.T) {
 	instance := newTestInstance("svc-metrics-enabled")
 	// Metrics enabled by default (nil)
@@ -10081,17 +13962,60 @@ func TestBuildStatefulSet_MetricsPortDisabled(t *testing.T) {
 	instance := newTestInstance("sts-metrics-disabled")
 	instance.Spec.Observability.Metrics.Enabled = Ptr(false)
 
-	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+
+	for _, c := range sts.Spec.Template.Spec.Containers {
+		if c.Name == "otel-collector" {
+			t.Error("otel-collector should not be present when metrics is disabled")
+		}
+	}
+
+	main := sts.Spec.Template.Spec.Containers[0]
+	if len(main.Ports) != 2 {
+		t.Errorf("expected 2 ports (gateway, canvas) when metrics disabled, got %d", len(main.Ports))
+	}
+}
+
+func TestMetricsPort_CustomFlowsThroughOnly(t *testing.T) {
+	base := newTestInstance("metrics-9900")
+	custom := newTestInstance("metrics-9900")
+	custom.Spec.Observability.Metrics.Port = Ptr(int32(9900))
 
-	for _, c := range sts.Spec.Template.Spec.Containers {
+	// Service: only the metrics port moves
+	basePorts := BuildService(base).Spec.Ports
+	customPorts := BuildService(custom).Spec.Ports
+	if len(customPorts) != len(basePorts) {
+		t.Fatalf("service ports = %d, want %d", len(customPorts), len(basePorts))
+	}
+	for i := range customPorts {
+		if customPorts[i].Name == "metrics" {
+			assertServicePort(t, customPorts, "metrics", 9900)
+			continue
+		}
+		if !equality.Semantic.DeepEqual(customPorts[i], basePorts[i]) {
+			t.Errorf("service port %q changed: %v, want %v", customPorts[i].Name, customPorts[i], basePorts[i])
+		}
+	}
+
+	// StatefulSet: the otel-collector metrics port moves, other containers do not
+	baseContainers := BuildStatefulSet(base, "", nil, nil, nil).Spec.Template.Spec.Containers
+	customContainers := BuildStatefulSet(custom, "", nil, nil, nil).Spec.Template.Spec.Containers
+	if len(customContainers) != len(baseContainers) {
+		t.Fatalf("containers = %d, want %d", len(customContainers), len(baseContainers))
+	}
+	for i, c := range customContainers {
 		if c.Name == "otel-collector" {
-			t.Error("otel-collector should not be present when metrics is disabled")
+			assertContainerPort(t, c.Ports, "metrics", 9900)
+			continue
+		}
+		if !equality.Semantic.DeepEqual(c.Ports, baseContainers[i].Ports) {
+			t.Errorf("container %q ports changed: %v, want %v", c.Name, c.Ports, baseContainers[i].Ports)
 		}
 	}
 
-	main := sts.Spec.Template.Spec.Containers[0]
-	if len(main.Ports) != 2 {
-		t.Errorf("expected 2 ports (gateway, canvas) when metrics disabled, got %d", len(main.Ports))
+	// The collector config exports on the custom port
+	if cfg := BuildConfigMap(custom, "", nil).Data[OTelCollectorConfigKey]; !strings.Contains(cfg, ":9900") {
+		t.Errorf("otel collector config should listen on 9900:\n%s", cfg)
 	}
 }
 
@@ -10965,6 +14889,27 @@ func TestHasGatewayBindConflict(t *testing.T) {
 		}
 	})
 
+	t.Run("conflict when proxy disabled and typed bind is loopback", func(t *testing.T) {
+		instance := newTestInstance("gw-conflict-typed")
+		instance.Spec.Gateway.Enabled = Ptr(false)
+		instance.Spec.Gateway.Bind = GatewayBindLoopback
+		if !HasGatewayBindConflict(instance) {
+			t.Error("should report conflict when proxy is disabled and spec.gateway.bind is loopback")
+		}
+	})
+
+	t.Run("typed bind overrides config bind", func(t *testing.T) {
+		instance := newTestInstance("gw-conflict-typed-override")
+		instance.Spec.Gateway.Enabled = Ptr(false)
+		instance.Spec.Gateway.Bind = "0.0.0.0"
+		instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+			RawExtension: runtime.RawExtension{Raw: []byte(`{"gateway":{"bind":"loopback"}}`)},
+		}
+		if HasGatewayBindConflict(instance) {
+			t.Error("spec.gateway.bind should take precedence over config gateway.bind")
+		}
+	})
+
 	t.Run("no conflict when proxy disabled and bind is 0.0.0.0", func(t *testing.T) {
 		instance := newTestInstance("gw-conflict-allif")
 		instance.Spec.Gateway.Enabled = Ptr(false)
@@ -11149,6 +15094,90 @@ func TestBuildConfigMap_Idempotent(t *testing.T) {
 	}
 }
 
+func TestBuildNetworkPolicy_DNSTargetKubeDNS(t *testing.T) {
+	instance := newTestInstance("np-kube-dns")
+	if to := BuildNetworkPolicy(instance).Spec.Egress[0].To; len(to) != 0 {
+		t.Errorf("default DNS rule should allow any destination, got %v", to)
+	}
+
+	instance.Spec.Security.NetworkPolicy.DNSTarget = DNSTargetKubeDNS
+	dns := BuildNetworkPolicy(instance).Spec.Egress[0]
+	if len(dns.Ports) != 2 || dns.Ports[0].Port.IntValue() != 53 {
+		t.Fatalf("first egress rule should be DNS, got %+v", dns)
+	}
+	if len(dns.To) != 1 {
+		t.Fatalf("expected one DNS peer, got %d", len(dns.To))
+	}
+	peer := dns.To[0]
+	if peer.NamespaceSelector == nil || peer.NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"] != "kube-system" {
+		t.Errorf("DNS peer namespaceSelector = %v, want kube-system", peer.NamespaceSelector)
+	}
+	if peer.PodSelector == nil || peer.PodSelector.MatchLabels["k8s-app"] != "kube-dns" {
+		t.Errorf("DNS peer podSelector = %v, want k8s-app=kube-dns", peer.PodSelector)
+	}
+}
+
+func TestEgressRuleSummary(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*openclawv1alpha1.OpenClawInstance)
+		want   []string
+	}{
+		{
+			name:   "defaults",
+			mutate: func(*openclawv1alpha1.OpenClawInstance) {},
+			want:   []string{"dns", "https"},
+		},
+		{
+			name: "dns disabled",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.Security.NetworkPolicy.AllowDNS = Ptr(false)
+			},
+			want: []string{"https"},
+		},
+		{
+			name: "tailscale and chromium",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.Tailscale.Enabled = true
+				i.Spec.Chromium.Enabled = true
+			},
+			want: []string{"dns", "https", "k8s-api", "tailscale", "chromium"},
+		},
+		{
+			name: "self-configure with custom egress",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.SelfConfigure.Enabled = true
+				i.Spec.Security.NetworkPolicy.AllowedEgressCIDRs = []string{"10.0.0.0/8", "192.168.0.0/16"}
+				i.Spec.Security.NetworkPolicy.AdditionalEgress = []networkingv1.NetworkPolicyEgressRule{{}}
+			},
+			want: []string{"dns", "https", "k8s-api", "custom-cidr", "custom-cidr", "additional"},
+		},
+		{
+			name: "ollama registry",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.Ollama.Enabled = true
+				i.Spec.Ollama.Models = []string{"llama3.2"}
+				i.Spec.Ollama.RegistryCIDRs = []string{"104.21.0.0/16"}
+			},
+			want: []string{"dns", "https", "ollama-registry"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance("egress-summary")
+			tt.mutate(instance)
+
+			got := EgressRuleSummary(instance)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("EgressRuleSummary() = %v, want %v", got, tt.want)
+			}
+			if n := len(BuildNetworkPolicy(instance).Spec.Egress); n != len(got) {
+				t.Errorf("summary has %d labels but the NetworkPolicy has %d egress rules", len(got), n)
+			}
+		})
+	}
+}
+
 func TestBuildNetworkPolicy_Idempotent(t *testing.T) {
 	instance := newTestInstance("idem-np")
 	n1 := BuildNetworkPolicy(instance)
@@ -11262,6 +15291,39 @@ func TestBuildRBAC_Idempotent(t *testing.T) {
 // Negative / edge case tests
 // ---------------------------------------------------------------------------
 
+func TestPodNodeSelector_OSDefault(t *testing.T) {
+	instance := newTestInstance("os-selector")
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	if got := sts.Spec.Template.Spec.NodeSelector[corev1.LabelOSStable]; got != "linux" {
+		t.Errorf("kubernetes.io/os = %q, want linux", got)
+	}
+
+	instance.Spec.Availability.NodeSelector = map[string]string{"node-type": "gpu"}
+	sts = BuildStatefulSet(instance, "", nil, nil, nil)
+	want := map[string]string{corev1.LabelOSStable: "linux", "node-type": "gpu"}
+	if !equality.Semantic.DeepEqual(sts.Spec.Template.Spec.NodeSelector, want) {
+		t.Errorf("nodeSelector = %v, want %v", sts.Spec.Template.Spec.NodeSelector, want)
+	}
+	if len(instance.Spec.Availability.NodeSelector) != 1 {
+		t.Error("user nodeSelector map was mutated")
+	}
+
+	// A user os entry wins
+	instance.Spec.Availability.NodeSelector = map[string]string{corev1.LabelOSStable: "windows"}
+	if got := PodNodeSelector(instance)[corev1.LabelOSStable]; got != "windows" {
+		t.Errorf("kubernetes.io/os = %q, want user value windows", got)
+	}
+
+	// Toggle off
+	instance.Spec.Availability.OSNodeSelector = Ptr(false)
+	instance.Spec.Availability.NodeSelector = nil
+	sts = BuildStatefulSet(instance, "", nil, nil, nil)
+	if sts.Spec.Template.Spec.NodeSelector != nil {
+		t.Errorf("nodeSelector = %v, want nil when osNodeSelector is false", sts.Spec.Template.Spec.NodeSelector)
+	}
+}
+
 func TestBuildStatefulSet_NilAvailability(t *testing.T) {
 	instance := newTestInstance("nil-avail")
 	// Zero-value AvailabilitySpec - should not panic
@@ -11270,8 +15332,8 @@ func TestBuildStatefulSet_NilAvailability(t *testing.T) {
 		t.Fatal("BuildStatefulSet returned nil for zero-value availability")
 	}
 	podSpec := sts.Spec.Template.Spec
-	if podSpec.NodeSelector != nil {
-		t.Error("expected nil NodeSelector")
+	if len(podSpec.NodeSelector) != 1 || podSpec.NodeSelector[corev1.LabelOSStable] != "linux" {
+		t.Errorf("expected only the default os nodeSelector, got %v", podSpec.NodeSelector)
 	}
 	if podSpec.Tolerations != nil {
 		t.Error("expected nil Tolerations")
@@ -12200,6 +16262,29 @@ func TestBuildStatefulSet_VCT_CustomAccessModes(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_VCT_ExistingClaimWithHPA_Upgrade(t *testing.T) {
+	// Instances that combined HPA with existingClaim have always run with
+	// per-replica VolumeClaimTemplates. The StatefulSet must stay the same on
+	// upgrade, and the storage validation must not block the reconcile.
+	instance := newTestInstance("vct-existing")
+	instance.Spec.Availability.AutoScaling = &openclawv1alpha1.AutoScalingSpec{
+		Enabled:     Ptr(true),
+		MaxReplicas: Ptr(int32(3)),
+	}
+	instance.Spec.Storage.Persistence.ExistingClaim = "legacy-claim"
+
+	if err := ValidateStorage(instance); err != nil {
+		t.Fatalf("HPA + existingClaim should stay valid, got: %v", err)
+	}
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	if len(sts.Spec.VolumeClaimTemplates) != 1 || sts.Spec.VolumeClaimTemplates[0].Name != "data" {
+		t.Fatalf("expected the per-replica data VolumeClaimTemplate, got %v", sts.Spec.VolumeClaimTemplates)
+	}
+	if v := findVolume(sts.Spec.Template.Spec.Volumes, "data"); v != nil {
+		t.Errorf("data volume should come from the VolumeClaimTemplate, got %+v", v.VolumeSource)
+	}
+}
+
 func TestBuildStatefulSet_VCT_StorageClass(t *testing.T) {
 	instance := newTestInstance("vct-sc")
 	instance.Spec.Availability.AutoScaling = &openclawv1alpha1.AutoScalingSpec{
@@ -12314,6 +16399,28 @@ func TestBuildStatefulSet_VCT_HasLabels(t *testing.T) {
 	}
 }
 
+func TestBuildStatefulSet_VCT_LabelsIgnoreManagedBy(t *testing.T) {
+	orig := ManagedByValue
+	t.Cleanup(func() { ManagedByValue = orig })
+
+	instance := newTestInstance("vct-managed-by")
+	instance.Spec.Availability.AutoScaling = &openclawv1alpha1.AutoScalingSpec{
+		Enabled: Ptr(true),
+	}
+	before := BuildStatefulSet(instance, "", nil, nil, nil).Spec.VolumeClaimTemplates[0].Labels
+
+	SetManagedBy("acme-platform")
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	after := sts.Spec.VolumeClaimTemplates[0].Labels
+
+	if !equality.Semantic.DeepEqual(before, after) {
+		t.Errorf("VCT labels changed with managed-by: before %v, after %v", before, after)
+	}
+	if got := sts.Labels["app.kubernetes.io/managed-by"]; got != "acme-platform" {
+		t.Errorf("StatefulSet managed-by = %q, want acme-platform", got)
+	}
+}
+
 func TestBuildStatefulSet_Idempotent_WithHPAAndPersistence(t *testing.T) {
 	instance := newTestInstance("idem-hpa")
 	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
@@ -12591,3 +16698,424 @@ func TestBuildStatefulSet_NoPluginsNoNodePath(t *testing.T) {
 		}
 	}
 }
+
+func TestDefaultedInstance_FillsDefaults(t *testing.T) {
+	instance := newTestInstance("defaults")
+	instance.Spec.Resources.Limits.Memory = "8Gi"
+	instance.Spec.Probes = &openclawv1alpha1.ProbesSpec{
+		Liveness:  &openclawv1alpha1.ProbeSpec{PeriodSeconds: Ptr(int32(20))},
+		Readiness: &openclawv1alpha1.ProbeSpec{Enabled: Ptr(false)},
+	}
+
+	out := DefaultedInstance(instance)
+
+	if out == instance {
+		t.Fatal("expected a copy, got the input")
+	}
+	if instance.Spec.Image.Tag != "" || instance.Spec.Observability.Metrics.Port != nil ||
+		instance.Spec.Probes.Startup != nil || instance.Spec.Probes.Liveness.InitialDelaySeconds != nil {
+		t.Error("input instance was mutated")
+	}
+
+	img := out.Spec.Image
+	if img.Repository != "ghcr.io/openclaw/openclaw" || img.Tag != DefaultImageTag || img.PullPolicy != corev1.PullIfNotPresent {
+		t.Errorf("image defaults = %s:%s (%s)", img.Repository, img.Tag, img.PullPolicy)
+	}
+
+	res := out.Spec.Resources
+	if res.Requests.CPU != "500m" || res.Requests.Memory != "1Gi" || res.Limits.CPU != "2000m" {
+		t.Errorf("resource defaults not filled: %+v", res)
+	}
+	if res.Limits.Memory != "8Gi" {
+		t.Errorf("limits.memory = %q, want user value 8Gi", res.Limits.Memory)
+	}
+
+	live := out.Spec.Probes.Liveness
+	if *live.Enabled != true || *live.InitialDelaySeconds != 30 || *live.PeriodSeconds != 20 ||
+		*live.TimeoutSeconds != 5 || *live.FailureThreshold != 3 {
+		t.Errorf("liveness defaults = %+v", live)
+	}
+	if r := out.Spec.Probes.Readiness; r.InitialDelaySeconds != nil {
+		t.Error("disabled readiness probe should not be filled")
+	}
+	if s := out.Spec.Probes.Startup; s == nil || *s.FailureThreshold != 60 {
+		t.Errorf("startup defaults = %+v", s)
+	}
+
+	if p := out.Spec.Observability.Metrics.Port; p == nil || *p != DefaultMetricsPort {
+		t.Errorf("metrics port = %v, want %d", p, DefaultMetricsPort)
+	}
+}
+
+func TestDefaultedInstance_BuildersUnchanged(t *testing.T) {
+	custom := newTestInstance("custom")
+	custom.Spec.Image.Tag = "v1.2.3"
+	custom.Spec.Resources.Requests.CPU = "1"
+	custom.Spec.Probes = &openclawv1alpha1.ProbesSpec{
+		Startup: &openclawv1alpha1.ProbeSpec{FailureThreshold: Ptr(int32(10))},
+	}
+	custom.Spec.Observability.Metrics.Port = Ptr(int32(9100))
+
+	for _, instance := range []*openclawv1alpha1.OpenClawInstance{newTestInstance("raw"), custom} {
+		defaulted := DefaultedInstance(instance)
+
+		if got, want := BuildStatefulSet(defaulted, "", nil, nil, nil), BuildStatefulSet(instance, "", nil, nil, nil); !equality.Semantic.DeepEqual(got, want) {
+			t.Errorf("%s: StatefulSet differs after defaulting", instance.Name)
+		}
+		if got, want := BuildService(defaulted), BuildService(instance); !equality.Semantic.DeepEqual(got, want) {
+			t.Errorf("%s: Service differs after defaulting", instance.Name)
+		}
+		if got, want := BuildConfigMap(defaulted, "tok", nil), BuildConfigMap(instance, "tok", nil); !equality.Semantic.DeepEqual(got, want) {
+			t.Errorf("%s: ConfigMap differs after defaulting", instance.Name)
+		}
+		if got, want := BuildNetworkPolicy(defaulted), BuildNetworkPolicy(instance); !equality.Semantic.DeepEqual(got, want) {
+			t.Errorf("%s: NetworkPolicy differs after defaulting", instance.Name)
+		}
+	}
+}
+
+func TestDefaultedInstance_GuaranteedResources(t *testing.T) {
+	fromLimits := newTestInstance("guaranteed-limits")
+	fromLimits.Spec.Resources.Guaranteed = Ptr(true)
+	fromLimits.Spec.Resources.Limits.CPU = "3"
+	fromLimits.Spec.Resources.Limits.Memory = "6Gi"
+
+	fromRequests := newTestInstance("guaranteed-requests")
+	fromRequests.Spec.Resources.Guaranteed = Ptr(true)
+	fromRequests.Spec.Resources.Requests.CPU = "1"
+	fromRequests.Spec.Resources.Limits.Memory = "8Gi"
+
+	for _, instance := range []*openclawv1alpha1.OpenClawInstance{fromLimits, fromRequests} {
+		defaulted := DefaultedInstance(instance)
+		res := defaulted.Spec.Resources
+		if res.Requests.CPU != res.Limits.CPU || res.Requests.Memory != res.Limits.Memory {
+			t.Errorf("%s: guaranteed resources should have limits == requests, got %+v", instance.Name, res)
+		}
+
+		want := buildResourceRequirements(instance)
+		if got := buildResourceRequirements(defaulted); !equality.Semantic.DeepEqual(got, want) {
+			t.Errorf("%s: resources differ after defaulting: got %v, want %v", instance.Name, got, want)
+		}
+		if got, want := BuildStatefulSet(defaulted, "", nil, nil, nil), BuildStatefulSet(instance, "", nil, nil, nil); !equality.Semantic.DeepEqual(got, want) {
+			t.Errorf("%s: StatefulSet differs after defaulting", instance.Name)
+		}
+	}
+	if got := DefaultedInstance(fromRequests).Spec.Resources; got.Requests.CPU != "1" || got.Requests.Memory != "8Gi" {
+		t.Errorf("expected requests cpu=1 (user request) and memory=8Gi (user limit), got %+v", got.Requests)
+	}
+}
+
+func TestContainerPorts_MatchesStatefulSet(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*openclawv1alpha1.OpenClawInstance)
+	}{
+		{"defaults", func(*openclawv1alpha1.OpenClawInstance) {}},
+		{"proxy and metrics disabled", func(i *openclawv1alpha1.OpenClawInstance) {
+			i.Spec.Gateway.Enabled = Ptr(false)
+			i.Spec.Observability.Metrics.Enabled = Ptr(false)
+		}},
+		{"chromium ollama web terminal", func(i *openclawv1alpha1.OpenClawInstance) {
+			i.Spec.Chromium.Enabled = true
+			i.Spec.Ollama.Enabled = true
+			i.Spec.WebTerminal.Enabled = true
+		}},
+		{"custom metrics port and sidecar", func(i *openclawv1alpha1.OpenClawInstance) {
+			i.Spec.Observability.Metrics.Port = Ptr(int32(9100))
+			i.Spec.Sidecars = []corev1.Container{{
+				Name:  "exporter",
+				Image: "exporter:latest",
+				Ports: []corev1.ContainerPort{{Name: "exporter", ContainerPort: 9400}},
+			}}
+		}},
+		{"tailscale", func(i *openclawv1alpha1.OpenClawInstance) {
+			i.Spec.Tailscale.Enabled = true
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance("ports")
+			tt.mutate(instance)
+
+			sts := BuildStatefulSet(instance, "", nil, nil, nil)
+			var want []corev1.ContainerPort
+			for _, c := range sts.Spec.Template.Spec.InitContainers {
+				want = append(want, c.Ports...)
+			}
+			for _, c := range sts.Spec.Template.Spec.Containers {
+				want = append(want, c.Ports...)
+			}
+
+			got := ContainerPorts(instance)
+			if !equality.Semantic.DeepEqual(got, want) {
+				t.Errorf("ContainerPorts() = %+v, want %+v", got, want)
+			}
+			hasGateway := false
+			for _, p := range got {
+				if p.ContainerPort == GatewayPort {
+					hasGateway = true
+				}
+			}
+			if !hasGateway {
+				t.Errorf("expected the gateway port, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestProbeDefaults_OllamaModels(t *testing.T) {
+	instance := newTestInstance("probe-defaults")
+	base := ProbeDefaults(instance)
+	if base.Liveness.InitialDelaySeconds != 30 || base.Liveness.FailureThreshold != 3 {
+		t.Errorf("base liveness = %+v, want 30s delay and threshold 3", base.Liveness)
+	}
+
+	// Ollama without models keeps the base defaults
+	instance.Spec.Ollama.Enabled = true
+	if got := ProbeDefaults(instance); got != base {
+		t.Errorf("ollama without models changed defaults: %+v", got)
+	}
+
+	instance.Spec.Ollama.Models = []string{"llama3.2", "nomic-embed-text"}
+	scaled := ProbeDefaults(instance)
+	if scaled.Liveness.InitialDelaySeconds != 150 || scaled.Liveness.FailureThreshold != 6 {
+		t.Errorf("scaled liveness = %+v, want 150s delay and threshold 6", scaled.Liveness)
+	}
+	if scaled.Readiness != base.Readiness || scaled.Startup != base.Startup {
+		t.Error("only the liveness probe should scale")
+	}
+
+	instance.Spec.Ollama.Models = []string{"a", "b", "c", "d", "e", "f", "g"}
+	if got := ProbeDefaults(instance).Liveness.InitialDelaySeconds; got != 300 {
+		t.Errorf("initial delay = %d, want cap of 300", got)
+	}
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	if lp := sts.Spec.Template.Spec.Containers[0].LivenessProbe; lp.InitialDelaySeconds != 300 || lp.FailureThreshold != 6 {
+		t.Errorf("built liveness probe = %d/%d, want 300/6", lp.InitialDelaySeconds, lp.FailureThreshold)
+	}
+
+	// Explicit ProbeSpec values still win
+	instance.Spec.Probes = &openclawv1alpha1.ProbesSpec{
+		Liveness: &openclawv1alpha1.ProbeSpec{InitialDelaySeconds: Ptr(int32(45))},
+	}
+	sts = BuildStatefulSet(instance, "", nil, nil, nil)
+	if lp := sts.Spec.Template.Spec.Containers[0].LivenessProbe; lp.InitialDelaySeconds != 45 || lp.FailureThreshold != 6 {
+		t.Errorf("overridden liveness probe = %d/%d, want 45/6", lp.InitialDelaySeconds, lp.FailureThreshold)
+	}
+}
+
+func TestBuildStatefulSet_ConfigWritableMount(t *testing.T) {
+	instance := newTestInstance("rw-cfg")
+	instance.Spec.Config.WritableMount = Ptr(true)
+
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	main := sts.Spec.Template.Spec.Containers[0]
+
+	assertVolumeMount(t, main.VolumeMounts, "operator-config", "/operator-config")
+	assertVolumeMount(t, main.VolumeMounts, "config", "/operator-config-source")
+	for _, vm := range main.VolumeMounts {
+		if vm.Name == "operator-config" && vm.ReadOnly {
+			t.Error("/operator-config should be writable")
+		}
+		if vm.Name == "config" && !vm.ReadOnly {
+			t.Error("ConfigMap source mount should stay read-only")
+		}
+	}
+
+	vol := findVolume(sts.Spec.Template.Spec.Volumes, "operator-config")
+	if vol == nil || vol.EmptyDir == nil {
+		t.Fatal("expected an emptyDir operator-config volume")
+	}
+
+	if main.Lifecycle == nil || main.Lifecycle.PostStart == nil || main.Lifecycle.PostStart.Exec == nil {
+		t.Fatal("expected a postStart exec hook")
+	}
+	cmd := main.Lifecycle.PostStart.Exec.Command[2]
+	want := "[ -f /operator-config/openclaw.json ] || cp /operator-config-source/openclaw.json /operator-config/openclaw.json || true; cp /operator-config/openclaw.json /home/openclaw/.openclaw/openclaw.json"
+	if cmd != want {
+		t.Errorf("postStart command = %q, want %q", cmd, want)
+	}
+
+	// Default stays read-only without the extra volume
+	instance.Spec.Config.WritableMount = nil
+	sts = BuildStatefulSet(instance, "", nil, nil, nil)
+	if findVolume(sts.Spec.Template.Spec.Volumes, "operator-config") != nil {
+		t.Error("operator-config volume should only exist with writableMount")
+	}
+}
+
+func TestBuildStatefulSet_LogsVolume(t *testing.T) {
+	t.Run("emptyDir", func(t *testing.T) {
+		instance := newTestInstance("logs-empty")
+		instance.Spec.Storage.Logs.Enabled = true
+
+		sts := BuildStatefulSet(instance, "", nil, nil, nil)
+		assertVolumeMount(t, sts.Spec.Template.Spec.Containers[0].VolumeMounts, "logs", "/var/log/openclaw")
+		vol := findVolume(sts.Spec.Template.Spec.Volumes, "logs")
+		if vol == nil || vol.EmptyDir == nil {
+			t.Fatal("expected an emptyDir logs volume")
+		}
+		if IsLogsPVCEnabled(instance) {
+			t.Error("logs PVC should not be enabled without a size")
+		}
+		// Data volume is unaffected
+		if data := findVolume(sts.Spec.Template.Spec.Volumes, "data"); data == nil || data.PersistentVolumeClaim == nil {
+			t.Error("data volume should stay on its own PVC")
+		}
+	})
+
+	t.Run("pvc", func(t *testing.T) {
+		instance := newTestInstance("logs-pvc")
+		instance.Spec.Storage.Logs = openclawv1alpha1.LogsStorageSpec{
+			Enabled:      true,
+			Size:         "5Gi",
+			StorageClass: Ptr("fast"),
+			MountPath:    "/home/openclaw/logs",
+		}
+
+		sts := BuildStatefulSet(instance, "", nil, nil, nil)
+		assertVolumeMount(t, sts.Spec.Template.Spec.Containers[0].VolumeMounts, "logs", "/home/openclaw/logs")
+		vol := findVolume(sts.Spec.Template.Spec.Volumes, "logs")
+		if vol == nil || vol.PersistentVolumeClaim == nil {
+			t.Fatal("expected a PVC-backed logs volume")
+		}
+		if vol.PersistentVolumeClaim.ClaimName != "logs-pvc-logs" {
+			t.Errorf("claim name = %q, want %q", vol.PersistentVolumeClaim.ClaimName, "logs-pvc-logs")
+		}
+
+		pvc := BuildLogsPVC(instance)
+		if pvc.Name != LogsPVCName(instance) {
+			t.Errorf("PVC name = %q, want %q", pvc.Name, LogsPVCName(instance))
+		}
+		if got := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; got.String() != "5Gi" {
+			t.Errorf("PVC size = %s, want 5Gi", got.String())
+		}
+		if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != "fast" {
+			t.Errorf("storage class = %v, want fast", pvc.Spec.StorageClassName)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		instance := newTestInstance("logs-off")
+		instance.Spec.Storage.Logs.Size = "5Gi"
+		sts := BuildStatefulSet(instance, "", nil, nil, nil)
+		if findVolume(sts.Spec.Template.Spec.Volumes, "logs") != nil {
+			t.Error("logs volume should not exist when disabled")
+		}
+	})
+}
+
+func TestBuildStatefulSet_TailscaleReadinessProbe(t *testing.T) {
+	tailscaleProbe := func(instance *openclawv1alpha1.OpenClawInstance) *corev1.Probe {
+		t.Helper()
+		sts := BuildStatefulSet(instance, "", nil, nil, nil)
+		for _, c := range sts.Spec.Template.Spec.Containers {
+			if c.Name == "tailscale" {
+				return c.ReadinessProbe
+			}
+		}
+		t.Fatal("tailscale sidecar container should be present")
+		return nil
+	}
+
+	instance := newTestInstance("ts-probe")
+	instance.Spec.Tailscale.Enabled = true
+
+	probe := tailscaleProbe(instance)
+	if probe == nil || probe.Exec == nil {
+		t.Fatal("expected a default exec readiness probe")
+	}
+	want := []string{"sh", "-c", "test -S " + TailscaleSocketPath}
+	if strings.Join(probe.Exec.Command, " ") != strings.Join(want, " ") {
+		t.Errorf("probe command = %v, want %v", probe.Exec.Command, want)
+	}
+	if probe.PeriodSeconds != 10 || probe.FailureThreshold != 3 {
+		t.Errorf("probe timings = %d/%d, want 10/3", probe.PeriodSeconds, probe.FailureThreshold)
+	}
+
+	instance.Spec.Tailscale.Probes = &openclawv1alpha1.TailscaleProbesSpec{
+		Command:   []string{"tailscale", "status"},
+		Readiness: &openclawv1alpha1.ProbeSpec{PeriodSeconds: Ptr(int32(30))},
+	}
+	probe = tailscaleProbe(instance)
+	if strings.Join(probe.Exec.Command, " ") != "tailscale status" || probe.PeriodSeconds != 30 {
+		t.Errorf("custom probe = %v every %ds", probe.Exec.Command, probe.PeriodSeconds)
+	}
+
+	instance.Spec.Tailscale.Probes.Readiness.Enabled = Ptr(false)
+	if probe := tailscaleProbe(instance); probe != nil {
+		t.Error("readiness probe should be nil when disabled")
+	}
+}
+
+func TestBuildStatefulSet_TailscaleUserspace(t *testing.T) {
+	tailscaleContainer := func(sts *appsv1.StatefulSet) corev1.Container {
+		t.Helper()
+		for _, c := range sts.Spec.Template.Spec.Containers {
+			if c.Name == "tailscale" {
+				return c
+			}
+		}
+		t.Fatal("tailscale sidecar container should be present")
+		return corev1.Container{}
+	}
+	tsUserspace := func(c corev1.Container) string {
+		for _, e := range c.Env {
+			if e.Name == "TS_USERSPACE" {
+				return e.Value
+			}
+		}
+		return ""
+	}
+
+	instance := newTestInstance("ts-userspace")
+	instance.Spec.Tailscale.Enabled = true
+
+	// Default: userspace networking with no added capabilities
+	sts := BuildStatefulSet(instance, "", nil, nil, nil)
+	c := tailscaleContainer(sts)
+	if got := tsUserspace(c); got != "true" {
+		t.Errorf("TS_USERSPACE = %q, want %q", got, "true")
+	}
+	if len(c.SecurityContext.Capabilities.Add) != 0 {
+		t.Errorf("userspace mode should not add capabilities, got %v", c.SecurityContext.Capabilities.Add)
+	}
+	if c.SecurityContext.RunAsUser != nil {
+		t.Errorf("userspace mode should keep the pod user, got runAsUser=%d", *c.SecurityContext.RunAsUser)
+	}
+	if findVolume(sts.Spec.Template.Spec.Volumes, "tailscale-tun") != nil {
+		t.Error("userspace mode should not mount /dev/net/tun")
+	}
+
+	// Kernel networking
+	instance.Spec.Tailscale.Userspace = Ptr(false)
+	sts = BuildStatefulSet(instance, "", nil, nil, nil)
+	c = tailscaleContainer(sts)
+	if got := tsUserspace(c); got != "false" {
+		t.Errorf("TS_USERSPACE = %q, want %q", got, "false")
+	}
+	caps := c.SecurityContext.Capabilities
+	if len(caps.Add) != 1 || caps.Add[0] != "NET_ADMIN" {
+		t.Errorf("capabilities.add = %v, want [NET_ADMIN]", caps.Add)
+	}
+	if len(caps.Drop) != 1 || caps.Drop[0] != "ALL" {
+		t.Errorf("capabilities.drop = %v, want [ALL]", caps.Drop)
+	}
+	sc := c.SecurityContext
+	if sc.RunAsUser == nil || *sc.RunAsUser != 0 {
+		t.Errorf("kernel mode should run as uid 0, got runAsUser=%v", sc.RunAsUser)
+	}
+	if sc.RunAsNonRoot == nil || *sc.RunAsNonRoot {
+		t.Errorf("kernel mode should set runAsNonRoot=false, got %v", sc.RunAsNonRoot)
+	}
+	tun := findVolume(sts.Spec.Template.Spec.Volumes, "tailscale-tun")
+	if tun == nil || tun.HostPath == nil || tun.HostPath.Path != TailscaleTunDevicePath {
+		t.Fatalf("expected hostPath volume for %s, got %+v", TailscaleTunDevicePath, tun)
+	}
+	if *tun.HostPath.Type != corev1.HostPathCharDev {
+		t.Errorf("tun hostPath type = %q, want CharDevice", *tun.HostPath.Type)
+	}
+	assertVolumeMount(t, c.VolumeMounts, "tailscale-tun", TailscaleTunDevicePath)
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package resources
 
 import (
+	"fmt"
+	"maps"
+	"strconv"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -33,25 +37,103 @@ func BuildService(instance *openclawv1alpha1.OpenClawInstance) *corev1.Service {
 	if serviceType == "" {
 		serviceType = corev1.ServiceTypeClusterIP
 	}
+	publishNotReady := instance.Spec.Networking.Service.PublishNotReadyAddresses
+
+	annotations := instance.Spec.Networking.Service.Annotations
+	if IsMetricsAnnotationScrapeEnabled(instance) {
+		annotations = maps.Clone(annotations)
+		if annotations == nil {
+			annotations = make(map[string]string, 3)
+		}
+		annotations["prometheus.io/scrape"] = "true"
+		annotations["prometheus.io/port"] = strconv.Itoa(int(MetricsPort(instance)))
+		annotations["prometheus.io/path"] = "/metrics"
+	}
 
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        ServiceName(instance),
 			Namespace:   instance.Namespace,
 			Labels:      labels,
-			Annotations: instance.Spec.Networking.Service.Annotations,
+			Annotations: annotations,
 		},
 		Spec: corev1.ServiceSpec{
-			Type:            serviceType,
-			Selector:        selectorLabels,
-			SessionAffinity: corev1.ServiceAffinityNone,
-			Ports:           buildServicePorts(instance),
+			Type:                     serviceType,
+			Selector:                 selectorLabels,
+			SessionAffinity:          corev1.ServiceAffinityNone,
+			Ports:                    buildServicePorts(instance),
+			TrafficDistribution:      instance.Spec.Networking.Service.TrafficDistribution,
+			PublishNotReadyAddresses: publishNotReady != nil && *publishNotReady,
 		},
 	}
 
+	// Pin the gateway node port for clusters without a LoadBalancer
+	if np := instance.Spec.Networking.Service.NodePort; np != nil && serviceType == corev1.ServiceTypeNodePort {
+		for i := range service.Spec.Ports {
+			if service.Spec.Ports[i].Name == "gateway" {
+				service.Spec.Ports[i].NodePort = *np
+			}
+		}
+	}
+
 	return service
 }
 
+// GatewayURL returns the best URL to reach the gateway, for status reporting.
+// In order of preference: the first Ingress host over https, the Service's
+// LoadBalancer or pinned NodePort address, and the cluster-internal Service
+// DNS name. externalAddress is the LoadBalancer ingress IP/hostname or a node
+// address for NodePort Services; pass "" when it is not known yet.
+func GatewayURL(instance *openclawv1alpha1.OpenClawInstance, externalAddress string) string {
+	ing := instance.Spec.Networking.Ingress
+	if ing.Enabled && len(ing.Hosts) > 0 && ing.Hosts[0].Host != "" {
+		url := "https://" + ing.Hosts[0].Host
+		if usesIngressPathRewrite(instance) {
+			url += ing.PathRewrite
+		}
+		return url
+	}
+
+	port := gatewayServicePort(instance)
+	svc := instance.Spec.Networking.Service
+	if externalAddress != "" {
+		switch {
+		case svc.Type == corev1.ServiceTypeLoadBalancer:
+			return fmt.Sprintf("http://%s:%d", externalAddress, port)
+		case svc.Type == corev1.ServiceTypeNodePort && svc.NodePort != nil:
+			return fmt.Sprintf("http://%s:%d", externalAddress, *svc.NodePort)
+		}
+	}
+
+	return fmt.Sprintf("http://%s.%s.svc:%d", ServiceName(instance), instance.Namespace, port)
+}
+
+// gatewayServicePort returns the Service port that reaches the gateway:
+// GatewayPort with the default ports, otherwise the custom port named
+// "gateway", then the first one targeting the gateway (or its proxy), then
+// the first custom port.
+func gatewayServicePort(instance *openclawv1alpha1.OpenClawInstance) int32 {
+	ports := instance.Spec.Networking.Service.Ports
+	if len(ports) == 0 {
+		return int32(GatewayPort)
+	}
+	for _, p := range ports {
+		if p.Name == "gateway" {
+			return p.Port
+		}
+	}
+	target := int32(GatewayProxyPort)
+	if !IsGatewayProxyEnabled(instance) {
+		target = int32(GatewayPort)
+	}
+	for _, p := range ports {
+		if (p.TargetPort != nil && *p.TargetPort == target) || (p.TargetPort == nil && p.Port == target) {
+			return p.Port
+		}
+	}
+	return ports[0].Port
+}
+
 // buildServicePorts returns custom ports if specified, otherwise default ports.
 func buildServicePorts(instance *openclawv1alpha1.OpenClawInstance) []corev1.ServicePort {
 	if len(instance.Spec.Networking.Service.Ports) > 0 {
@@ -66,15 +148,22 @@ func buildServicePorts(instance *openclawv1alpha1.OpenClawInstance) []corev1.Ser
 				tp = intstr.FromInt32(*p.TargetPort)
 			}
 			ports = append(ports, corev1.ServicePort{
-				Name:       p.Name,
-				Port:       p.Port,
-				TargetPort: tp,
-				Protocol:   protocol,
+				Name:        p.Name,
+				Port:        p.Port,
+				TargetPort:  tp,
+				Protocol:    protocol,
+				AppProtocol: p.AppProtocol,
 			})
 		}
 		return ports
 	}
+	return buildDefaultServicePorts(instance)
+}
 
+// buildDefaultServicePorts returns the Service ports used when no custom
+// ports are set: gateway, canvas and the enabled Chromium, web terminal and
+// metrics ports.
+func buildDefaultServicePorts(instance *openclawv1alpha1.OpenClawInstance) []corev1.ServicePort {
 	// When the gateway proxy is enabled, route through the proxy ports.
 	// When disabled, target the gateway and canvas ports directly.
 	gwTarget := int32(GatewayProxyPort)
@@ -86,16 +175,18 @@ func buildServicePorts(instance *openclawv1alpha1.OpenClawInstance) []corev1.Ser
 
 	ports := []corev1.ServicePort{
 		{
-			Name:       "gateway",
-			Port:       int32(GatewayPort),
-			TargetPort: intstr.FromInt32(gwTarget),
-			Protocol:   corev1.ProtocolTCP,
+			Name:        "gateway",
+			Port:        int32(GatewayPort),
+			TargetPort:  intstr.FromInt32(gwTarget),
+			Protocol:    corev1.ProtocolTCP,
+			AppProtocol: Ptr("http"),
 		},
 		{
-			Name:       "canvas",
-			Port:       int32(CanvasPort),
-			TargetPort: intstr.FromInt32(canvasTarget),
-			Protocol:   corev1.ProtocolTCP,
+			Name:        "canvas",
+			Port:        int32(CanvasPort),
+			TargetPort:  intstr.FromInt32(canvasTarget),
+			Protocol:    corev1.ProtocolTCP,
+			AppProtocol: Ptr("http"),
 		},
 	}
 
@@ -164,3 +255,32 @@ func BuildChromiumCDPService(instance *openclawv1alpha1.OpenClawInstance) *corev
 		},
 	}
 }
+
+// BuildMetricsService creates a headless Service exposing only the metrics
+// port, for Prometheus federation or scrapers that should not see the
+// gateway ports. The port is named "http-metrics" rather than "metrics" so
+// the ServiceMonitor, which selects Services by the standard labels and the
+// "metrics" port, does not scrape the pod a second time through it.
+func BuildMetricsService(instance *openclawv1alpha1.OpenClawInstance) *corev1.Service {
+	metricsPort := MetricsPort(instance)
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MetricsServiceName(instance),
+			Namespace: instance.Namespace,
+			Labels:    Labels(instance),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: corev1.ClusterIPNone, // headless
+			Selector:  SelectorLabels(instance),
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http-metrics",
+					Port:       metricsPort,
+					TargetPort: intstr.FromInt32(metricsPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+}
@@ -34,7 +34,22 @@ func ServiceMonitorGVK() schema.GroupVersionKind {
 
 // ServiceMonitorName returns the name of the ServiceMonitor
 func ServiceMonitorName(instance *openclawv1alpha1.OpenClawInstance) string {
-	return instance.Name
+	return resourceName(instance, "-metrics")
+}
+
+// LegacyServiceMonitorName returns the name the ServiceMonitor had before it
+// was renamed to ServiceMonitorName (used during migration).
+func LegacyServiceMonitorName(instance *openclawv1alpha1.OpenClawInstance) string {
+	return resourceName(instance, "")
+}
+
+// ServiceMonitorInterval returns the configured scrape interval or the 30s
+// default.
+func ServiceMonitorInterval(instance *openclawv1alpha1.OpenClawInstance) string {
+	if sm := instance.Spec.Observability.Metrics.ServiceMonitor; sm != nil && sm.Interval != "" {
+		return sm.Interval
+	}
+	return "30s"
 }
 
 // BuildServiceMonitor creates an unstructured ServiceMonitor for the OpenClawInstance
@@ -52,14 +67,28 @@ func BuildServiceMonitor(instance *openclawv1alpha1.OpenClawInstance) *unstructu
 		}
 	}
 
-	interval := "30s"
-	if instance.Spec.Observability.Metrics.ServiceMonitor != nil &&
-		instance.Spec.Observability.Metrics.ServiceMonitor.Interval != "" {
-		interval = instance.Spec.Observability.Metrics.ServiceMonitor.Interval
-	}
+	interval := ServiceMonitorInterval(instance)
 
 	selectorLabels := SelectorLabels(instance)
 
+	endpoint := map[string]interface{}{
+		"port":     "metrics",
+		"interval": interval,
+		"path":     "/metrics",
+		// Tag every scraped series with the instance name, so dashboards can
+		// group by instance without relying on pod names
+		"relabelings": []interface{}{
+			map[string]interface{}{
+				"action":      "replace",
+				"targetLabel": "openclaw_instance",
+				"replacement": instance.Name,
+			},
+		},
+	}
+	if sm := instance.Spec.Observability.Metrics.ServiceMonitor; sm != nil && sm.ScrapeTimeout != "" {
+		endpoint["scrapeTimeout"] = sm.ScrapeTimeout
+	}
+
 	sm := &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": "monitoring.coreos.com/v1",
@@ -73,13 +102,7 @@ func BuildServiceMonitor(instance *openclawv1alpha1.OpenClawInstance) *unstructu
 				"selector": map[string]interface{}{
 					"matchLabels": toStringInterfaceMap(selectorLabels),
 				},
-				"endpoints": []interface{}{
-					map[string]interface{}{
-						"port":     "metrics",
-						"interval": interval,
-						"path":     "/metrics",
-					},
-				},
+				"endpoints": []interface{}{endpoint},
 			},
 		},
 	}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 OpenClaw.rocks
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
+)
+
+// IsSkillsJobMode returns true if skills are installed by a Job rather than
+// the init-skills init container.
+func IsSkillsJobMode(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.SkillsInstallMode == SkillsInstallModeJob
+}
+
+// SkillsHash returns a short hash of the skills install script. It changes
+// whenever the set of installable skills changes and is "" when there is
+// nothing to install.
+func SkillsHash(instance *openclawv1alpha1.OpenClawInstance) string {
+	script := BuildSkillsScript(instance)
+	if script == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// BuildSkillsJob creates the Job that installs skills into the data PVC when
+// spec.skillsInstallMode is "job". It runs the same container as the
+// init-skills init container. Returns nil if there are no installable skills.
+func BuildSkillsJob(instance *openclawv1alpha1.OpenClawInstance) *batchv1.Job {
+	container := buildSkillsInitContainer(instance)
+	if container == nil {
+		return nil
+	}
+	container.Name = "install-skills"
+
+	claimName := PVCName(instance)
+	if instance.Spec.Storage.Persistence.ExistingClaim != "" {
+		claimName = instance.Spec.Storage.Persistence.ExistingClaim
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+				},
+			},
+		},
+		{
+			Name: "skills-tmp",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	if v := buildCABundleVolume(instance); v != nil {
+		volumes = append(volumes, *v)
+	}
+
+	// The Job pod must not match the instance selector, otherwise the Service
+	// and PodDisruptionBudget would pick it up.
+	labels := Labels(instance)
+	labels["app.kubernetes.io/name"] = AppName + "-skills"
+	labels["app.kubernetes.io/component"] = "skills-install"
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SkillsJobName(instance),
+			Namespace: instance.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				AnnotationKey("skills-hash"): SkillsHash(instance),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: Ptr(int32(3)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:                corev1.RestartPolicyOnFailure,
+					ServiceAccountName:           ServiceAccountName(instance),
+					AutomountServiceAccountToken: Ptr(false),
+					SecurityContext:              buildPodSecurityContext(instance),
+					Containers:                   []corev1.Container{*container},
+					Volumes:                      volumes,
+					ImagePullSecrets:             instance.Spec.Image.PullSecrets,
+					NodeSelector:                 PodNodeSelector(instance),
+					Tolerations:                  instance.Spec.Availability.Tolerations,
+					// Co-locate with the instance pod so ReadWriteOnce volumes
+					// can be mounted while the StatefulSet keeps running.
+					Affinity: &corev1.Affinity{
+						PodAffinity: &corev1.PodAffinity{
+							PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+								{
+									Weight: 100,
+									PodAffinityTerm: corev1.PodAffinityTerm{
+										LabelSelector: &metav1.LabelSelector{
+											MatchLabels: SelectorLabels(instance),
+										},
+										TopologyKey: "kubernetes.io/hostname",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -21,7 +21,10 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -47,9 +50,10 @@ func BuildStatefulSet(instance *openclawv1alpha1.OpenClawInstance, gatewayTokenS
 
 	sts := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      StatefulSetName(instance),
-			Namespace: instance.Namespace,
-			Labels:    labels,
+			Name:        StatefulSetName(instance),
+			Namespace:   instance.Namespace,
+			Labels:      labels,
+			Annotations: maps.Clone(instance.Spec.StatefulSetAnnotations),
 		},
 		Spec: appsv1.StatefulSetSpec{
 			Replicas:             statefulSetReplicas(instance),
@@ -68,22 +72,26 @@ func BuildStatefulSet(instance *openclawv1alpha1.OpenClawInstance, gatewayTokenS
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      labels,
+					Labels:      buildPodLabels(instance),
 					Annotations: buildPodAnnotations(instance, externalWorkspaceFiles, additionalExternalFiles),
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName:            ServiceAccountName(instance),
 					DeprecatedServiceAccount:      ServiceAccountName(instance),
-					AutomountServiceAccountToken:  Ptr(instance.Spec.SelfConfigure.Enabled || instance.Spec.Tailscale.Enabled),
+					AutomountServiceAccountToken:  Ptr(automountServiceAccountToken(instance)),
 					SecurityContext:               buildPodSecurityContext(instance),
+					ShareProcessNamespace:         instance.Spec.Security.ShareProcessNamespace,
+					EnableServiceLinks:            Ptr(IsServiceLinksEnabled(instance)),
 					InitContainers:                buildInitContainers(instance, externalWorkspaceFiles, additionalExternalFiles, skillPacks),
 					Containers:                    buildContainers(instance, gwSecretName),
 					Volumes:                       buildVolumes(instance, skillPacks),
-					NodeSelector:                  instance.Spec.Availability.NodeSelector,
+					NodeSelector:                  PodNodeSelector(instance),
 					Tolerations:                   instance.Spec.Availability.Tolerations,
-					Affinity:                      instance.Spec.Availability.Affinity,
-					TopologySpreadConstraints:     instance.Spec.Availability.TopologySpreadConstraints,
+					Affinity:                      buildAffinity(instance),
+					TopologySpreadConstraints:     buildTopologySpreadConstraints(instance),
 					RuntimeClassName:              instance.Spec.Availability.RuntimeClassName,
+					ReadinessGates:                instance.Spec.Availability.ReadinessGates,
+					Subdomain:                     instance.Spec.Availability.Subdomain,
 					RestartPolicy:                 corev1.RestartPolicyAlways,
 					DNSPolicy:                     corev1.DNSClusterFirst,
 					SchedulerName:                 corev1.DefaultSchedulerName,
@@ -93,6 +101,11 @@ func BuildStatefulSet(instance *openclawv1alpha1.OpenClawInstance, gatewayTokenS
 		},
 	}
 
+	// List every image for an image pre-puller
+	if IsImagePrePullEnabled(instance) {
+		sts.Spec.Template.Annotations[PrePullAnnotationKey()] = strings.Join(podImages(&sts.Spec.Template.Spec), ",")
+	}
+
 	// Add image pull secrets
 	sts.Spec.Template.Spec.ImagePullSecrets = append(
 		sts.Spec.Template.Spec.ImagePullSecrets,
@@ -101,16 +114,22 @@ func BuildStatefulSet(instance *openclawv1alpha1.OpenClawInstance, gatewayTokenS
 
 	// When persistence is enabled with HPA (multi-replica), use VolumeClaimTemplates
 	// so each replica gets its own PVC instead of sharing a single static PVC.
-	if IsPersistenceEnabled(instance) && IsHPAEnabled(instance) {
+	if UsesVolumeClaimTemplates(instance) {
 		size := ParseQuantity(instance.Spec.Storage.Persistence.Size, "10Gi")
 		accessModes := instance.Spec.Storage.Persistence.AccessModes
 		if len(accessModes) == 0 {
 			accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
 		}
+		// VolumeClaimTemplates are immutable and their PVCs outlive the
+		// instance, so they don't carry the instance UID, and managed-by is
+		// pinned so changing --managed-by doesn't break StatefulSet updates.
+		vctLabels := maps.Clone(labels)
+		delete(vctLabels, InstanceUIDLabel)
+		vctLabels["app.kubernetes.io/managed-by"] = defaultManagedBy
 		vct := corev1.PersistentVolumeClaim{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:   "data",
-				Labels: labels,
+				Labels: vctLabels,
 			},
 			Spec: corev1.PersistentVolumeClaimSpec{
 				AccessModes: accessModes,
@@ -130,16 +149,242 @@ func BuildStatefulSet(instance *openclawv1alpha1.OpenClawInstance, gatewayTokenS
 	return sts
 }
 
+// IsSpreadAcrossNodesEnabled returns true unless spec.availability.spreadAcrossNodes
+// is explicitly false.
+func IsSpreadAcrossNodesEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	s := instance.Spec.Availability.SpreadAcrossNodes
+	return s == nil || *s
+}
+
+// buildTopologySpreadConstraints returns the user's constraints plus, when
+// more than one replica can run, a soft kubernetes.io/hostname spread unless
+// the user already constrains on the hostname.
+func buildTopologySpreadConstraints(instance *openclawv1alpha1.OpenClawInstance) []corev1.TopologySpreadConstraint {
+	constraints := instance.Spec.Availability.TopologySpreadConstraints
+	if !IsSpreadAcrossNodesEnabled(instance) || MaxReplicas(instance) <= 1 {
+		return constraints
+	}
+	for _, c := range constraints {
+		if c.TopologyKey == corev1.LabelHostname {
+			return constraints
+		}
+	}
+	return append(slices.Clone(constraints), corev1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       corev1.LabelHostname,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: SelectorLabels(instance)},
+	})
+}
+
+// buildAffinity returns the user-provided affinity, adding a required GPU node
+// term when spec.ollama.requireGPUNode is set and GPUs are requested. Required
+// node selector terms are ORed, so the GPU expression is added to every
+// existing term to keep the user's constraints intact.
+func buildAffinity(instance *openclawv1alpha1.OpenClawInstance) *corev1.Affinity {
+	ollama := instance.Spec.Ollama
+	requireGPU := IsOllamaSidecarEnabled(instance) && ollama.RequireGPUNode != nil && *ollama.RequireGPUNode &&
+		ollama.GPU != nil && *ollama.GPU > 0
+	if !requireGPU {
+		return instance.Spec.Availability.Affinity
+	}
+
+	label := ollama.GPUNodeLabel
+	if label == "" {
+		label = DefaultGPUNodeLabel
+	}
+	gpuExpr := corev1.NodeSelectorRequirement{
+		Key:      label,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"true"},
+	}
+
+	affinity := &corev1.Affinity{}
+	if instance.Spec.Availability.Affinity != nil {
+		affinity = instance.Spec.Availability.Affinity.DeepCopy()
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil {
+		required = &corev1.NodeSelector{}
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = required
+	}
+	if len(required.NodeSelectorTerms) == 0 {
+		required.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, gpuExpr)
+	}
+	return affinity
+}
+
+// usesProjectedSAToken returns true when self-configure requests a token with
+// a custom audience instead of the automounted one.
+func usesProjectedSAToken(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.SelfConfigure.Enabled && instance.Spec.SelfConfigure.TokenAudience != ""
+}
+
+// automountServiceAccountToken returns whether the pod needs the default
+// service account token. Tailscale always needs it (state Secret access);
+// self-configure needs it unless a projected audience token replaces it.
+// Otherwise security.rbac.automountToken decides, defaulting to false.
+func automountServiceAccountToken(instance *openclawv1alpha1.OpenClawInstance) bool {
+	if instance.Spec.Tailscale.Enabled {
+		return true
+	}
+	if instance.Spec.SelfConfigure.Enabled && !usesProjectedSAToken(instance) {
+		return true
+	}
+	automount := instance.Spec.Security.RBAC.AutomountToken
+	return automount != nil && *automount
+}
+
+// buildProjectedSATokenVolume builds a projected volume mirroring the layout
+// of the automounted token (token, ca.crt, namespace) with a custom audience.
+func buildProjectedSATokenVolume(instance *openclawv1alpha1.OpenClawInstance) corev1.Volume {
+	defaultMode := int32(0o644)
+	return corev1.Volume{
+		Name: "sa-token",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				DefaultMode: &defaultMode,
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          instance.Spec.SelfConfigure.TokenAudience,
+							ExpirationSeconds: Ptr(int64(ServiceAccountTokenExpirationSeconds)),
+							Path:              "token",
+						},
+					},
+					{
+						ConfigMap: &corev1.ConfigMapProjection{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "kube-root-ca.crt"},
+							Items:                []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+						},
+					},
+					{
+						DownwardAPI: &corev1.DownwardAPIProjection{
+							Items: []corev1.DownwardAPIVolumeFile{
+								{
+									Path:     "namespace",
+									FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.namespace"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// IsConfigHashAnnotationDisabled returns true if spec.disableConfigHashAnnotation
+// drops the config-hash annotation from the pod template.
+func IsConfigHashAnnotationDisabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.DisableConfigHashAnnotation != nil && *instance.Spec.DisableConfigHashAnnotation
+}
+
 // buildPodAnnotations builds the pod annotations for the pod template
 func buildPodAnnotations(instance *openclawv1alpha1.OpenClawInstance, externalWorkspaceFiles map[string]string, additionalExternalFiles map[string]map[string]string) map[string]string {
 	annotations := make(map[string]string, len(instance.Spec.PodAnnotations)+1)
 	for k, v := range instance.Spec.PodAnnotations {
 		annotations[k] = v
 	}
-	annotations["openclaw.rocks/config-hash"] = calculateConfigHash(instance, externalWorkspaceFiles, additionalExternalFiles)
+	if !IsConfigHashAnnotationDisabled(instance) {
+		annotations[AnnotationKey("config-hash")] = calculateConfigHash(instance, externalWorkspaceFiles, additionalExternalFiles)
+	}
+	// A restart-at annotation on the instance (any value, typically a
+	// timestamp) rolls the pods without touching the config hash.
+	if restartAt := instance.Annotations[RestartAtAnnotationKey()]; restartAt != "" {
+		annotations[RestartAtAnnotationKey()] = restartAt
+	}
+	// In job mode skills no longer change the pod template, so roll the pods
+	// once the controller has run the new install Job.
+	if IsSkillsJobMode(instance) {
+		if hash := SkillsHash(instance); hash != "" {
+			annotations[AnnotationKey("skills-hash")] = hash
+		}
+	}
 	return annotations
 }
 
+// IsServiceLinksEnabled returns true if Service env vars are injected into the
+// pod (spec.enableServiceLinks). Unlike Kubernetes, the default is false.
+func IsServiceLinksEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.EnableServiceLinks != nil && *instance.Spec.EnableServiceLinks
+}
+
+// IsImagePrePullEnabled returns true if the pod template lists the instance's
+// images for an image pre-puller (spec.image.prePull).
+func IsImagePrePullEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Image.PrePull != nil && *instance.Spec.Image.PrePull
+}
+
+// AllImages returns every image the instance runs, for vulnerability
+// scanning: the main image plus the sidecar, init container and skills Job
+// images of the enabled features, in pod container order. It is derived from
+// BuildStatefulSet so it cannot drift from what the pod actually references.
+// Skill packs and external workspace files only change init scripts, not
+// images, so they are not needed here.
+func AllImages(instance *openclawv1alpha1.OpenClawInstance) []string {
+	spec := BuildStatefulSet(instance, "", nil, nil, nil).Spec.Template.Spec
+	if IsSkillsJobMode(instance) {
+		if job := BuildSkillsJob(instance); job != nil {
+			spec.InitContainers = append(spec.InitContainers, job.Spec.Template.Spec.Containers...)
+		}
+	}
+	return podImages(&spec)
+}
+
+// podImages returns the distinct images referenced by the pod's init and
+// regular containers, in container order.
+func podImages(spec *corev1.PodSpec) []string {
+	seen := map[string]bool{}
+	var images []string
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for _, c := range containers {
+			if c.Image != "" && !seen[c.Image] {
+				seen[c.Image] = true
+				images = append(images, c.Image)
+			}
+		}
+	}
+	return images
+}
+
+// buildPodLabels returns the pod template labels: the standard labels plus
+// spec.extraPodLabels. Extra labels never reach the selector, and operator
+// labels win on conflict so the selector always matches the template.
+func buildPodLabels(instance *openclawv1alpha1.OpenClawInstance) map[string]string {
+	labels := make(map[string]string, len(instance.Spec.ExtraPodLabels)+3)
+	for k, v := range instance.Spec.ExtraPodLabels {
+		labels[k] = v
+	}
+	for k, v := range Labels(instance) {
+		labels[k] = v
+	}
+	return labels
+}
+
+// PodNodeSelector returns the nodeSelector for operator-created pods: the
+// user's spec.availability.nodeSelector plus kubernetes.io/os=linux unless
+// spec.availability.osNodeSelector is false. User entries win.
+func PodNodeSelector(instance *openclawv1alpha1.OpenClawInstance) map[string]string {
+	user := instance.Spec.Availability.NodeSelector
+	if osSel := instance.Spec.Availability.OSNodeSelector; osSel != nil && !*osSel {
+		return user
+	}
+
+	selector := make(map[string]string, len(user)+1)
+	selector[corev1.LabelOSStable] = string(corev1.Linux)
+	for k, v := range user {
+		selector[k] = v
+	}
+	return selector
+}
+
 // buildPodSecurityContext creates the pod-level security context
 func buildPodSecurityContext(instance *openclawv1alpha1.OpenClawInstance) *corev1.PodSecurityContext {
 	psc := &corev1.PodSecurityContext{
@@ -179,6 +424,12 @@ func buildPodSecurityContext(instance *openclawv1alpha1.OpenClawInstance) *corev
 		psc.FSGroup = Ptr(int64(1000))
 	}
 
+	// Some CSI drivers manage ownership themselves and break with fsGroup set
+	if spec != nil && spec.DisableFSGroup != nil && *spec.DisableFSGroup {
+		psc.FSGroup = nil
+		psc.FSGroupChangePolicy = nil
+	}
+
 	return psc
 }
 
@@ -250,7 +501,7 @@ func buildContainers(instance *openclawv1alpha1.OpenClawInstance, gatewayTokenSe
 	// to guarantee it starts before the main container. See buildInitContainers.
 
 	// Add Ollama sidecar if enabled
-	if instance.Spec.Ollama.Enabled {
+	if IsOllamaSidecarEnabled(instance) {
 		containers = append(containers, buildOllamaContainer(instance))
 	}
 
@@ -291,10 +542,35 @@ func buildMainContainerPorts(instance *openclawv1alpha1.OpenClawInstance) []core
 	}
 }
 
+// ContainerPorts returns every container port the StatefulSet pod declares:
+// gateway, canvas, operator sidecars (proxy, metrics, Chromium, Ollama, ...)
+// and user sidecars, in pod spec order. Native sidecars come first, matching
+// their position in initContainers.
+func ContainerPorts(instance *openclawv1alpha1.OpenClawInstance) []corev1.ContainerPort {
+	var ports []corev1.ContainerPort
+	for _, c := range portContainers(instance) {
+		ports = append(ports, c.Ports...)
+	}
+	return ports
+}
+
+// portContainers returns the containers that share the pod network namespace
+// and can declare ports: native sidecars (init containers with restartPolicy
+// Always) followed by the regular containers.
+func portContainers(instance *openclawv1alpha1.OpenClawInstance) []corev1.Container {
+	var out []corev1.Container
+	for _, c := range buildInitContainers(instance, nil, nil, nil) {
+		if c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			out = append(out, c)
+		}
+	}
+	return append(out, buildContainers(instance, "")...)
+}
+
 // buildMainContainer creates the main OpenClaw container
 func buildMainContainer(instance *openclawv1alpha1.OpenClawInstance, gatewayTokenSecretName string) corev1.Container {
 	container := corev1.Container{
-		Name:                     "openclaw",
+		Name:                     MainContainerName(instance),
 		Image:                    GetImage(instance),
 		ImagePullPolicy:          getPullPolicy(instance),
 		SecurityContext:          buildContainerSecurityContext(instance),
@@ -360,6 +636,17 @@ func buildMainContainer(instance *openclawv1alpha1.OpenClawInstance, gatewayToke
 		)
 	}
 
+	// Projected self-configure token with a custom audience. Mounted at the
+	// well-known path so in-cluster clients pick it up without extra config;
+	// the automount admission plugin skips containers that already mount it.
+	if usesProjectedSAToken(instance) {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "sa-token",
+			MountPath: ServiceAccountTokenMountPath,
+			ReadOnly:  true,
+		})
+	}
+
 	// Add extra volume mounts from spec
 	container.VolumeMounts = append(container.VolumeMounts, instance.Spec.ExtraVolumeMounts...)
 
@@ -373,20 +660,53 @@ func buildMainContainer(instance *openclawv1alpha1.OpenClawInstance, gatewayToke
 		})
 	}
 
+	if instance.Spec.Storage.Logs.Enabled {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "logs",
+			MountPath: LogsMountPath(instance),
+		})
+	}
+
 	// Mount the config volume read-only so the postStart hook can restore
 	// operator-managed config on every container start (init containers only
 	// run on pod creation, not on container restarts within the same pod).
-	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
-		Name:      "config",
-		MountPath: "/operator-config",
-		ReadOnly:  true,
-	})
+	// With a writable mount the ConfigMap moves to /operator-config-source and
+	// /operator-config is an emptyDir the postStart hook seeds from it.
+	if IsConfigMountWritable(instance) {
+		container.VolumeMounts = append(container.VolumeMounts,
+			corev1.VolumeMount{
+				Name:      "config",
+				MountPath: "/operator-config-source",
+				ReadOnly:  true,
+			},
+			corev1.VolumeMount{
+				Name:      "operator-config",
+				MountPath: "/operator-config",
+			},
+		)
+	} else {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "config",
+			MountPath: "/operator-config",
+			ReadOnly:  true,
+		})
+	}
+
+	// Run the user's wrapper script instead of the image entrypoint
+	if instance.Spec.EntrypointScript != "" {
+		container.Command = []string{EntrypointScriptMountPath + "/" + EntrypointScriptKey}
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "entrypoint",
+			MountPath: EntrypointScriptMountPath,
+			ReadOnly:  true,
+		})
+	}
 
 	// PostStart lifecycle hook: restore the operator-managed config file on
 	// every container start. This prevents crashloops when the agent modifies
 	// its own config and then crashes -- without this, the broken config
 	// persists because init containers don't re-run on container restarts.
-	if cmd := buildConfigRestoreCommand(instance); cmd != "" {
+	if cmd := buildPostStartCommand(instance); cmd != "" {
 		container.Lifecycle = &corev1.Lifecycle{
 			PostStart: &corev1.LifecycleHandler{
 				Exec: &corev1.ExecAction{
@@ -439,27 +759,46 @@ func buildMainEnv(instance *openclawv1alpha1.OpenClawInstance, gatewayTokenSecre
 		// pairing flow which requires device identity.
 		env = append(env,
 			corev1.EnvVar{
-				Name:  "OPENCLAW_CHROMIUM_CDP",
+				Name:  ChromiumCDPEnvName(instance),
 				Value: fmt.Sprintf("http://127.0.0.1:%d", ChromiumPort),
 			},
 		)
 	}
 
-	if instance.Spec.Ollama.Enabled {
+	if IsOllamaSidecarEnabled(instance) {
 		env = append(env, corev1.EnvVar{
 			Name:  "OLLAMA_HOST",
 			Value: fmt.Sprintf("http://localhost:%d", OllamaPort),
 		})
 	}
 
-	// Inject OPENCLAW_GATEWAY_TOKEN from Secret unless the user already set it in spec.env
-	if gatewayTokenSecretName != "" && !hasUserEnv(instance, "OPENCLAW_GATEWAY_TOKEN") {
+	// Basic auth reads the credentials from the user's Secret; otherwise inject
+	// OPENCLAW_GATEWAY_TOKEN. Either way, variables already set in spec.env win.
+	if IsGatewayBasicAuth(instance) {
+		for _, kv := range []struct{ name, key string }{
+			{"OPENCLAW_GATEWAY_USERNAME", "username"},
+			{"OPENCLAW_GATEWAY_PASSWORD", "password"},
+		} {
+			if hasUserEnv(instance, kv.name) {
+				continue
+			}
+			env = append(env, corev1.EnvVar{
+				Name: kv.name,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: instance.Spec.Gateway.BasicAuthSecret},
+						Key:                  kv.key,
+					},
+				},
+			})
+		}
+	} else if gatewayTokenSecretName != "" && !hasUserEnv(instance, "OPENCLAW_GATEWAY_TOKEN") {
 		env = append(env, corev1.EnvVar{
 			Name: "OPENCLAW_GATEWAY_TOKEN",
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: &corev1.SecretKeySelector{
 					LocalObjectReference: corev1.LocalObjectReference{Name: gatewayTokenSecretName},
-					Key:                  GatewayTokenSecretKey,
+					Key:                  GatewayTokenKey(instance),
 				},
 			},
 		})
@@ -531,6 +870,11 @@ func hasUserEnv(instance *openclawv1alpha1.OpenClawInstance, name string) bool {
 func buildInitContainers(instance *openclawv1alpha1.OpenClawInstance, externalWorkspaceFiles map[string]string, additionalExternalFiles map[string]map[string]string, skillPacks *ResolvedSkillPacks) []corev1.Container {
 	var initContainers []corev1.Container
 
+	// Chown the Chromium/Ollama volumes to their fsGroup before anything uses them
+	if chown := buildChownInitContainer(instance); chown != nil {
+		initContainers = append(initContainers, *chown)
+	}
+
 	// Config/workspace init container (only if there's something to do)
 	if script := BuildInitScript(instance, externalWorkspaceFiles, additionalExternalFiles, skillPacks); script != "" {
 		mounts := []corev1.VolumeMount{
@@ -564,7 +908,7 @@ func buildInitContainers(instance *openclawv1alpha1.OpenClawInstance, externalWo
 		// Merge and JSON5 modes use the OpenClaw image which needs writable rootfs and HOME env
 		readOnlyRoot := true
 		var initEnv []corev1.EnvVar
-		initPullPolicy := corev1.PullIfNotPresent
+		initPullPolicy := sidecarPullPolicy(instance)
 		if instance.Spec.Config.MergeMode == ConfigMergeModeMerge || instance.Spec.Config.Format == ConfigFormatJSON5 {
 			readOnlyRoot = false
 			initEnv = []corev1.EnvVar{
@@ -597,6 +941,17 @@ func buildInitContainers(instance *openclawv1alpha1.OpenClawInstance, externalWo
 		})
 	}
 
+	// Config validation init container (fails fast on a broken config file)
+	if IsConfigValidateOnStartEnabled(instance) {
+		initContainers = append(initContainers, buildValidateConfigInitContainer(instance))
+	}
+
+	// Maintenance init container (runs right after config init so migrations
+	// see the final config, before anything else touches the data volume)
+	if instance.Spec.Maintenance.Enabled {
+		initContainers = append(initContainers, buildMaintenanceInitContainer(instance))
+	}
+
 	// Tailscale binary init container (copies tailscale CLI to shared volume)
 	if instance.Spec.Tailscale.Enabled {
 		initContainers = append(initContainers, buildTailscaleBinInitContainer(instance))
@@ -616,8 +971,8 @@ func buildInitContainers(instance *openclawv1alpha1.OpenClawInstance, externalWo
 		initContainers = append(initContainers, buildPythonInitContainer(instance))
 	}
 
-	// Skills init container (only if skills are defined)
-	if skillsContainer := buildSkillsInitContainer(instance); skillsContainer != nil {
+	// Skills init container (only if skills are defined and not installed by a Job)
+	if skillsContainer := buildSkillsInitContainer(instance); skillsContainer != nil && !IsSkillsJobMode(instance) {
 		initContainers = append(initContainers, *skillsContainer)
 	}
 
@@ -627,10 +982,20 @@ func buildInitContainers(instance *openclawv1alpha1.OpenClawInstance, externalWo
 	}
 
 	// Ollama model-pulling init container (only if enabled and models are specified)
-	if instance.Spec.Ollama.Enabled && len(instance.Spec.Ollama.Models) > 0 {
+	if IsOllamaSidecarEnabled(instance) && len(instance.Spec.Ollama.Models) > 0 {
 		initContainers = append(initContainers, buildOllamaModelPullInitContainer(instance))
 	}
 
+	// Override the seccomp profile of the operator-managed init containers
+	if profile := instance.Spec.Security.InitSeccompProfile; profile != nil {
+		for i := range initContainers {
+			if initContainers[i].SecurityContext == nil {
+				initContainers[i].SecurityContext = &corev1.SecurityContext{}
+			}
+			initContainers[i].SecurityContext.SeccompProfile = profile.DeepCopy()
+		}
+	}
+
 	// Chromium native sidecar (K8s 1.28+): starts before main containers and
 	// stays running for the pod's lifetime. This guarantees the Chromium CDP
 	// endpoint is ready before OpenClaw boots and performs its one-time CDP
@@ -643,9 +1008,11 @@ func buildInitContainers(instance *openclawv1alpha1.OpenClawInstance, externalWo
 	// issues where browserless kills Chrome when the WebSocket client
 	// disconnects between tool calls (see #360).
 	if instance.Spec.Chromium.Enabled {
-		chromium := buildChromiumContainer(instance)
-		chromium.RestartPolicy = Ptr(corev1.ContainerRestartPolicyAlways)
-		initContainers = append(initContainers, chromium)
+		for i := range ChromiumReplicas(instance) {
+			chromium := buildChromiumContainer(instance, i)
+			chromium.RestartPolicy = Ptr(corev1.ContainerRestartPolicyAlways)
+			initContainers = append(initContainers, chromium)
+		}
 	}
 
 	// Custom init containers (user-defined, run after operator-managed ones)
@@ -660,40 +1027,84 @@ func shellQuote(s string) string {
 	return "'" + strings.ReplaceAll(s, "'", "'\\''") + "'"
 }
 
+// InitStepKind identifies what an InitStep does.
+type InitStepKind string
+
+const (
+	// InitStepCopyConfig overwrites the config file with the operator-managed one.
+	InitStepCopyConfig InitStepKind = "copy-config"
+	// InitStepMerge merges the operator config into the existing config file,
+	// deep or shallow per spec.config.mergeStrategy.
+	InitStepMerge InitStepKind = "merge"
+	// InitStepJSON5Convert converts a JSON5 config to JSON at the config path.
+	InitStepJSON5Convert InitStepKind = "json5-convert"
+	// InitStepMkdir creates a directory (idempotent).
+	InitStepMkdir InitStepKind = "mkdir"
+	// InitStepCopyFile seeds a workspace file unless it already exists.
+	InitStepCopyFile InitStepKind = "copy-file"
+)
+
+// InitStep is a single operation performed by the init-config container.
+// Source and Target are unquoted paths; Command is the rendered shell line.
+type InitStep struct {
+	Kind    InitStepKind
+	Source  string
+	Target  string
+	Command string
+}
+
 // BuildInitScript generates the shell script for the init container.
 // It handles config copy or merge, directory creation (idempotent),
 // workspace file seeding (only if not present), and skill pack file mapping.
 // Returns "" if there is nothing to do.
 func BuildInitScript(instance *openclawv1alpha1.OpenClawInstance, externalWorkspaceFiles map[string]string, additionalExternalFiles map[string]map[string]string, skillPacks *ResolvedSkillPacks) string {
-	var lines []string
+	steps := BuildInitSteps(instance, externalWorkspaceFiles, additionalExternalFiles, skillPacks)
+	if len(steps) == 0 {
+		return ""
+	}
+
+	lines := make([]string, len(steps))
+	for i, step := range steps {
+		lines[i] = step.Command
+	}
+	return strings.Join(lines, "\n")
+}
+
+// BuildInitSteps returns the ordered steps that BuildInitScript renders, so
+// callers can inspect individual operations without parsing the script.
+func BuildInitSteps(instance *openclawv1alpha1.OpenClawInstance, externalWorkspaceFiles map[string]string, additionalExternalFiles map[string]map[string]string, skillPacks *ResolvedSkillPacks) []InitStep {
+	var steps []InitStep
 
 	// 1. Config handling — overwrite or merge, with optional JSON5 conversion
 	if key := configMapKey(instance); key != "" {
+		dst := "/data/" + ConfigFileName(instance)
 		switch {
 		case instance.Spec.Config.MergeMode == ConfigMergeModeMerge:
-			// Deep-merge operator config with existing PVC config via Node.js.
+			// Merge operator config with existing PVC config via Node.js
+			// (deep or shallow per spec.config.mergeStrategy).
 			// Uses the OpenClaw image (has Node.js + sh); the jq distroless image
 			// cannot be used because it has no shell (#105).
 			// The config path is passed via env var to avoid shell/JS quoting issues.
-			lines = append(lines, fmt.Sprintf(
+			steps = append(steps, InitStep{Kind: InitStepMerge, Source: "/config/" + key, Target: dst, Command: fmt.Sprintf(
 				`__cfgpath=/config/%s node -e '`+
 					`const fs=require("fs");`+
-					`function dm(a,b){const r={...a};for(const k in b){r[k]=b[k]&&typeof b[k]==="object"&&!Array.isArray(b[k])&&r[k]&&typeof r[k]==="object"&&!Array.isArray(r[k])?dm(r[k],b[k]):b[k]}return r}`+
-					`const e="/data/openclaw.json",c=process.env.__cfgpath,t="/tmp/merged.json";`+
+					configMergeFunc(instance)+
+					`const e="%s",c=process.env.__cfgpath,t="/tmp/merged.json";`+
 					`const base=fs.existsSync(e)?JSON.parse(fs.readFileSync(e,"utf8")):{};`+
 					`const inc=JSON.parse(fs.readFileSync(c,"utf8"));`+
 					`fs.writeFileSync(t,JSON.stringify(dm(base,inc),null,2));`+
 					`fs.copyFileSync(t,e);`+
 					`'`,
-				shellQuote(key)))
+				shellQuote(key), dst)})
 		case instance.Spec.Config.Format == ConfigFormatJSON5:
 			// JSON5 overwrite — convert to standard JSON via npx json5
-			lines = append(lines, fmt.Sprintf(
-				"npx -y json5 /config/%s > /tmp/converted.json && mv /tmp/converted.json /data/openclaw.json",
-				shellQuote(key)))
+			steps = append(steps, InitStep{Kind: InitStepJSON5Convert, Source: "/config/" + key, Target: dst, Command: fmt.Sprintf(
+				"npx -y json5 /config/%s > /tmp/converted.json && mv /tmp/converted.json %s",
+				shellQuote(key), dst)})
 		default:
 			// Overwrite (default) — operator-managed config always wins
-			lines = append(lines, fmt.Sprintf("cp /config/%s /data/openclaw.json", shellQuote(key)))
+			steps = append(steps, InitStep{Kind: InitStepCopyConfig, Source: "/config/" + key, Target: dst,
+				Command: fmt.Sprintf("cp /config/%s %s", shellQuote(key), dst)})
 		}
 	}
 
@@ -706,14 +1117,14 @@ func BuildInitScript(instance *openclawv1alpha1.OpenClawInstance, externalWorksp
 		copy(dirs, ws.InitialDirectories)
 		sort.Strings(dirs)
 		for _, dir := range dirs {
-			lines = append(lines, fmt.Sprintf("mkdir -p /data/workspace/%s", shellQuote(dir)))
+			steps = append(steps, mkdirStep("/data/workspace/", dir))
 		}
 	}
 
 	// Skill pack directories
 	if skillPacks != nil {
 		for _, dir := range skillPacks.Directories {
-			lines = append(lines, fmt.Sprintf("mkdir -p /data/workspace/%s", shellQuote(dir)))
+			steps = append(steps, mkdirStep("/data/workspace/", dir))
 		}
 	}
 
@@ -740,7 +1151,7 @@ func BuildInitScript(instance *openclawv1alpha1.OpenClawInstance, externalWorksp
 		}
 
 		// Ensure the workspace directory exists (may not on first run with emptyDir)
-		lines = append(lines, "mkdir -p /data/workspace")
+		steps = append(steps, InitStep{Kind: InitStepMkdir, Target: "/data/workspace", Command: "mkdir -p /data/workspace"})
 		// Sort keys for deterministic output
 		sorted := make([]string, 0, len(allFiles))
 		for name := range allFiles {
@@ -748,8 +1159,7 @@ func BuildInitScript(instance *openclawv1alpha1.OpenClawInstance, externalWorksp
 		}
 		sort.Strings(sorted)
 		for _, name := range sorted {
-			q := shellQuote(name)
-			lines = append(lines, fmt.Sprintf("[ -f /data/workspace/%s ] || cp /workspace-init/%s /data/workspace/%s", q, q, q))
+			steps = append(steps, copyFileStep(name, "/data/workspace/", name))
 		}
 
 		// Skill pack files use mapped paths (ConfigMap key differs from workspace path)
@@ -761,8 +1171,7 @@ func BuildInitScript(instance *openclawv1alpha1.OpenClawInstance, externalWorksp
 			sort.Strings(mappedKeys)
 			for _, cmKey := range mappedKeys {
 				wsPath := skillPacks.PathMapping[cmKey]
-				lines = append(lines, fmt.Sprintf("[ -f /data/workspace/%s ] || cp /workspace-init/%s /data/workspace/%s",
-					shellQuote(wsPath), shellQuote(cmKey), shellQuote(wsPath)))
+				steps = append(steps, copyFileStep(cmKey, "/data/workspace/", wsPath))
 			}
 		}
 	}
@@ -778,14 +1187,18 @@ func BuildInitScript(instance *openclawv1alpha1.OpenClawInstance, externalWorksp
 			wsDir := fmt.Sprintf("workspace-%s", aw.Name)
 
 			// Create the workspace directory
-			lines = append(lines, fmt.Sprintf("mkdir -p /data/%s", shellQuote(wsDir)))
+			steps = append(steps, mkdirStep("/data/", wsDir))
 
 			// Create initialDirectories
 			dirs := make([]string, len(aw.InitialDirectories))
 			copy(dirs, aw.InitialDirectories)
 			sort.Strings(dirs)
 			for _, dir := range dirs {
-				lines = append(lines, fmt.Sprintf("mkdir -p /data/%s/%s", shellQuote(wsDir), shellQuote(dir)))
+				steps = append(steps, InitStep{
+					Kind:    InitStepMkdir,
+					Target:  "/data/" + wsDir + "/" + dir,
+					Command: fmt.Sprintf("mkdir -p /data/%s/%s", shellQuote(wsDir), shellQuote(dir)),
+				})
 			}
 
 			// Collect all file names for this workspace
@@ -812,19 +1225,41 @@ func BuildInitScript(instance *openclawv1alpha1.OpenClawInstance, externalWorksp
 			sort.Strings(sorted)
 			for _, name := range sorted {
 				cmKey := AdditionalWorkspaceCMKey(aw.Name, name)
-				lines = append(lines, fmt.Sprintf("[ -f /data/%s/%s ] || cp /workspace-init/%s /data/%s/%s",
-					shellQuote(wsDir), shellQuote(name),
-					shellQuote(cmKey),
-					shellQuote(wsDir), shellQuote(name)))
+				steps = append(steps, InitStep{
+					Kind:   InitStepCopyFile,
+					Source: "/workspace-init/" + cmKey,
+					Target: "/data/" + wsDir + "/" + name,
+					Command: fmt.Sprintf("[ -f /data/%s/%s ] || cp /workspace-init/%s /data/%s/%s",
+						shellQuote(wsDir), shellQuote(name),
+						shellQuote(cmKey),
+						shellQuote(wsDir), shellQuote(name)),
+				})
 			}
 		}
 	}
 
-	if len(lines) == 0 {
-		return ""
+	return steps
+}
+
+// mkdirStep creates an InitStepMkdir for dir under the unquoted base path.
+func mkdirStep(base, dir string) InitStep {
+	return InitStep{
+		Kind:    InitStepMkdir,
+		Target:  base + dir,
+		Command: fmt.Sprintf("mkdir -p %s%s", base, shellQuote(dir)),
 	}
+}
 
-	return strings.Join(lines, "\n")
+// copyFileStep creates an InitStepCopyFile that seeds base+target from the
+// workspace-init ConfigMap key unless the file already exists.
+func copyFileStep(key, base, target string) InitStep {
+	qt := shellQuote(target)
+	return InitStep{
+		Kind:    InitStepCopyFile,
+		Source:  "/workspace-init/" + key,
+		Target:  base + target,
+		Command: fmt.Sprintf("[ -f %s%s ] || cp /workspace-init/%s %s%s", base, qt, shellQuote(key), base, qt),
+	}
 }
 
 // clawHubSkillsSetup prepares a PVC-backed skills directory in the init
@@ -850,6 +1285,21 @@ const skillInstallWrapper = `_install_skill() {
   fi
 }`
 
+// SkillsManifestPath is where the skills container records the installed
+// skill entries (data volume root) so removed skills can be pruned.
+const SkillsManifestPath = "/home/openclaw/.openclaw/.skills-manifest"
+
+// skillUninstallFunc removes a single manifest entry: npm packages via
+// `npm uninstall -g`, ClawHub skills by deleting their PVC-backed directory.
+const skillUninstallFunc = `_uninstall_skill() {
+  case "$1" in
+    ""|.|..) return 0 ;;
+    npm:*) npm uninstall -g "${1#npm:}" || true ;;
+    *) rm -rf "/home/openclaw/.openclaw/skills/$1" ;;
+  esac
+  echo "Pruned skill $1"
+}`
+
 // normalizeClawHubSlug strips the @owner/ prefix from ClawHub skill identifiers.
 // ClawHub CLI expects bare skill names (e.g. "mcp-server-fetch"), but users and
 // documentation sometimes use "@owner/skill-name" format from the ClawHub website URL.
@@ -901,11 +1351,14 @@ func hasNpmSkills(skills []string) bool {
 // Each entry produces either a `clawhub install` (default) or `npm install`
 // (when prefixed with "npm:") command. Entries prefixed with "pack:" are
 // handled by workspace seeding and are excluded here.
-// Entries are sorted for determinism. Returns "" if no installable skills are defined.
+// Entries are sorted for determinism. Returns "" if no installable skills are
+// defined, unless skillsPrune is set: the prune step must still run to remove
+// the last skill taken out of spec.skills.
 func BuildSkillsScript(instance *openclawv1alpha1.OpenClawInstance) string {
 	// Filter out pack: entries — those are handled by workspace seeding, not npm/clawhub
 	skills := FilterNonPackSkills(instance.Spec.Skills)
-	if len(skills) == 0 {
+	prune := instance.Spec.SkillsPrune != nil && *instance.Spec.SkillsPrune
+	if len(skills) == 0 && !prune {
 		return ""
 	}
 
@@ -916,12 +1369,73 @@ func BuildSkillsScript(instance *openclawv1alpha1.OpenClawInstance) string {
 	if hasClawHubSkills(skills) {
 		lines = append(lines, clawHubSkillsSetup, skillInstallWrapper)
 	}
+
+	// Prune: uninstall manifest entries that are no longer desired. Entries
+	// are recorded in their installed form (normalized ClawHub slug or npm:pkg).
+	if prune {
+		desired := make([]string, len(skills))
+		for i, skill := range skills {
+			desired[i] = normalizeClawHubSlug(skill)
+		}
+		lines = append(lines,
+			skillUninstallFunc,
+			"_desired="+shellQuote(strings.Join(desired, "\n")),
+			fmt.Sprintf(`if [ -f %[1]s ]; then
+  while IFS= read -r _s; do
+    printf '%%s\n' "$_desired" | grep -qxF -- "$_s" || _uninstall_skill "$_s"
+  done < %[1]s
+fi`, SkillsManifestPath),
+		)
+	}
+
 	for _, skill := range skills {
 		lines = append(lines, parseSkillEntry(skill))
 	}
+
+	// Record what is installed only after every install succeeded (set -e)
+	if prune && len(skills) == 0 {
+		lines = append(lines, "rm -f "+SkillsManifestPath)
+	} else if prune {
+		lines = append(lines, fmt.Sprintf(`printf '%%s\n' "$_desired" > %s`, SkillsManifestPath))
+	}
 	return strings.Join(lines, "\n")
 }
 
+// buildCABundleVolume returns the "ca-bundle" volume for spec.security.caBundle,
+// or nil when no CA bundle is configured.
+func buildCABundleVolume(instance *openclawv1alpha1.OpenClawInstance) *corev1.Volume {
+	cab := instance.Spec.Security.CABundle
+	if cab == nil {
+		return nil
+	}
+	defaultMode := int32(0o644)
+	switch {
+	case cab.ConfigMapName != "":
+		return &corev1.Volume{
+			Name: "ca-bundle",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: cab.ConfigMapName,
+					},
+					DefaultMode: &defaultMode,
+				},
+			},
+		}
+	case cab.SecretName != "":
+		return &corev1.Volume{
+			Name: "ca-bundle",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  cab.SecretName,
+					DefaultMode: &defaultMode,
+				},
+			},
+		}
+	}
+	return nil
+}
+
 // buildSkillsInitContainer creates the init container that installs skills.
 // Supports both ClawHub skills (default) and npm packages (npm: prefix).
 // npm lifecycle scripts are disabled globally via NPM_CONFIG_IGNORE_SCRIPTS (#91).
@@ -999,6 +1513,86 @@ func buildSkillsInitContainer(instance *openclawv1alpha1.OpenClawInstance) *core
 	}
 }
 
+// IsConfigValidateOnStartEnabled returns true if spec.config.validateOnStart is set.
+func IsConfigValidateOnStartEnabled(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Config.ValidateOnStart != nil && *instance.Spec.Config.ValidateOnStart
+}
+
+// configValidateScript parses the config file given as the first argument and
+// exits non-zero with the parse error if it is not valid JSON. A missing file
+// is not an error: OpenClaw then starts with its built-in defaults.
+const configValidateScript = `const fs = require("fs"), p = process.argv[1];
+if (!fs.existsSync(p)) process.exit(0);
+try { JSON.parse(fs.readFileSync(p, "utf8")); } catch (e) { console.error(p + ": " + e.message); process.exit(1); }`
+
+// buildValidateConfigInitContainer creates the init-validate container that
+// runs after init-config and checks that the final config file (after merge
+// and JSON5 conversion) is valid JSON, so a bad config fails the pod before
+// OpenClaw starts instead of crash-looping it.
+func buildValidateConfigInitContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.Container {
+	return corev1.Container{
+		Name:                     "init-validate",
+		Image:                    GetImage(instance),
+		Command:                  []string{"node", "-e", configValidateScript, "/data/" + ConfigFileName(instance)},
+		ImagePullPolicy:          getPullPolicy(instance),
+		Env:                      []corev1.EnvVar{{Name: "HOME", Value: "/tmp"}},
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: Ptr(false),
+			ReadOnlyRootFilesystem:   Ptr(true),
+			RunAsNonRoot:             Ptr(podRunAsNonRoot(instance)),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "data", MountPath: "/data", ReadOnly: true},
+		},
+	}
+}
+
+// buildMaintenanceInitContainer creates the init-maintenance container that
+// runs the user's one-off command (e.g. a data migration) against the data
+// volume. It gets the instance env so migrations can reach external services.
+func buildMaintenanceInitContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.Container {
+	image := GetImage(instance)
+	if instance.Spec.Maintenance.Image != "" {
+		image = ApplyRegistryOverride(instance.Spec.Maintenance.Image, instance.Spec.Registry)
+	}
+
+	env := append([]corev1.EnvVar{{Name: "HOME", Value: "/tmp"}}, instance.Spec.Env...)
+
+	return corev1.Container{
+		Name:                     "init-maintenance",
+		Image:                    image,
+		Command:                  instance.Spec.Maintenance.Command,
+		ImagePullPolicy:          getPullPolicy(instance),
+		Env:                      env,
+		EnvFrom:                  instance.Spec.EnvFrom,
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: Ptr(false),
+			ReadOnlyRootFilesystem:   Ptr(true),
+			RunAsNonRoot:             Ptr(podRunAsNonRoot(instance)),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "data", MountPath: "/home/openclaw/.openclaw"},
+			{Name: "tmp", MountPath: "/tmp"},
+		},
+	}
+}
+
 // parsePluginEntry returns the shell command to install a single plugin entry.
 // Entries are npm package names. An optional "npm:" prefix is stripped.
 // Installs into the PVC-backed ~/.openclaw/ node_modules via `npm install`.
@@ -1175,7 +1769,7 @@ echo "uv $(/home/openclaw/.local/bin/uv --version) installed"`
 		Name:                     "init-uv",
 		Image:                    ApplyRegistryOverride(UvImage, instance.Spec.Registry),
 		Command:                  []string{"sh", "-c", script},
-		ImagePullPolicy:          corev1.PullIfNotPresent,
+		ImagePullPolicy:          sidecarPullPolicy(instance),
 		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 		Resources:                corev1.ResourceRequirements{},
@@ -1278,7 +1872,7 @@ uv --version`
 		Name:                     "init-python",
 		Image:                    ApplyRegistryOverride(UvImage, instance.Spec.Registry),
 		Command:                  []string{"sh", "-c", script},
-		ImagePullPolicy:          corev1.PullIfNotPresent,
+		ImagePullPolicy:          sidecarPullPolicy(instance),
 		Env:                      env,
 		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
@@ -1304,12 +1898,21 @@ func hasWorkspaceFiles(_ *openclawv1alpha1.OpenClawInstance, _ *ResolvedSkillPac
 }
 
 // configMapKey returns the ConfigMap key for the config file.
-// Always returns "openclaw.json" because the operator-managed ConfigMap always
+// Always returns ConfigFileName because the operator-managed ConfigMap always
 // uses this key, regardless of whether the user provided config via raw,
 // configMapRef, or none. The controller reads external CMs and writes the
-// enriched result into the operator-managed CM under "openclaw.json".
-func configMapKey(_ *openclawv1alpha1.OpenClawInstance) string {
-	return "openclaw.json"
+// enriched result into the operator-managed CM under that key.
+func configMapKey(instance *openclawv1alpha1.OpenClawInstance) string {
+	return ConfigFileName(instance)
+}
+
+// ConfigFileName returns the config file name (spec.config.fileName),
+// defaulting to "openclaw.json".
+func ConfigFileName(instance *openclawv1alpha1.OpenClawInstance) string {
+	if instance.Spec.Config.FileName != "" {
+		return instance.Spec.Config.FileName
+	}
+	return DefaultConfigFileName
 }
 
 // buildTailscaleContainer creates the Tailscale sidecar that runs tailscaled.
@@ -1324,7 +1927,7 @@ func buildTailscaleContainer(instance *openclawv1alpha1.OpenClawInstance) corev1
 	}
 
 	env := []corev1.EnvVar{
-		{Name: "TS_USERSPACE", Value: "true"},
+		{Name: "TS_USERSPACE", Value: strconv.FormatBool(IsTailscaleUserspace(instance))},
 		{Name: "TS_STATE_DIR", Value: TailscaleStatePath},
 		{Name: "TS_SOCKET", Value: TailscaleSocketPath},
 		{Name: "TS_SERVE_CONFIG", Value: "/etc/tailscale/serve/" + TailscaleServeConfigKey},
@@ -1353,43 +1956,100 @@ func buildTailscaleContainer(instance *openclawv1alpha1.OpenClawInstance) corev1
 		})
 	}
 
-	return corev1.Container{
-		Name:            "tailscale",
-		Image:           image,
-		ImagePullPolicy: corev1.PullIfNotPresent,
-		Env:             env,
-		VolumeMounts: []corev1.VolumeMount{
-			{
-				Name:      "tailscale-socket",
-				MountPath: TailscaleSocketDir,
-			},
-			{
-				Name:      "config",
-				MountPath: "/etc/tailscale/serve/" + TailscaleServeConfigKey,
-				SubPath:   TailscaleServeConfigKey,
-				ReadOnly:  true,
-			},
-			{
-				// State dir (/tmp/tailscale) is created by tailscaled under /tmp.
-				Name:      "tailscale-tmp",
-				MountPath: "/tmp",
-			},
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      "tailscale-socket",
+			MountPath: TailscaleSocketDir,
 		},
-		Resources: buildTailscaleResourceRequirements(instance),
-		SecurityContext: &corev1.SecurityContext{
-			AllowPrivilegeEscalation: Ptr(false),
-			ReadOnlyRootFilesystem:   Ptr(true),
-			RunAsNonRoot:             Ptr(podRunAsNonRoot(instance)),
-			Capabilities: &corev1.Capabilities{
-				Drop: []corev1.Capability{"ALL"},
-			},
-			SeccompProfile: &corev1.SeccompProfile{
-				Type: corev1.SeccompProfileTypeRuntimeDefault,
-			},
+		{
+			Name:      "config",
+			MountPath: "/etc/tailscale/serve/" + TailscaleServeConfigKey,
+			SubPath:   TailscaleServeConfigKey,
+			ReadOnly:  true,
 		},
-		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
-		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
-	}
+		{
+			// State dir (/tmp/tailscale) is created by tailscaled under /tmp.
+			Name:      "tailscale-tmp",
+			MountPath: "/tmp",
+		},
+	}
+
+	capabilities := &corev1.Capabilities{
+		Drop: []corev1.Capability{"ALL"},
+	}
+
+	securityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: Ptr(false),
+		ReadOnlyRootFilesystem:   Ptr(true),
+		RunAsNonRoot:             Ptr(podRunAsNonRoot(instance)),
+		Capabilities:             capabilities,
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+
+	// Kernel networking needs NET_ADMIN to configure the tailscale0
+	// interface and the host's TUN device to create it. Capabilities are
+	// only effective for root, so the sidecar runs as uid 0.
+	if !IsTailscaleUserspace(instance) {
+		capabilities.Add = []corev1.Capability{"NET_ADMIN"}
+		securityContext.RunAsUser = Ptr(int64(0))
+		securityContext.RunAsNonRoot = Ptr(false)
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "tailscale-tun",
+			MountPath: TailscaleTunDevicePath,
+		})
+	}
+
+	return corev1.Container{
+		Name:                     "tailscale",
+		Image:                    image,
+		ImagePullPolicy:          sidecarPullPolicy(instance),
+		Env:                      env,
+		VolumeMounts:             mounts,
+		Resources:                buildTailscaleResourceRequirements(instance),
+		ReadinessProbe:           buildTailscaleReadinessProbe(instance),
+		SecurityContext:          securityContext,
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+	}
+}
+
+// IsTailscaleUserspace returns true if tailscaled runs with userspace
+// networking (spec.tailscale.userspace, default true).
+func IsTailscaleUserspace(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Tailscale.Userspace == nil || *instance.Spec.Tailscale.Userspace
+}
+
+// buildTailscaleReadinessProbe creates the Tailscale sidecar readiness probe.
+// By default it checks that tailscaled has created its socket; probes.command
+// replaces the check. Returns nil when the probe is disabled.
+func buildTailscaleReadinessProbe(instance *openclawv1alpha1.OpenClawInstance) *corev1.Probe {
+	command := []string{"sh", "-c", "test -S " + TailscaleSocketPath}
+	var spec *openclawv1alpha1.ProbeSpec
+	if p := instance.Spec.Tailscale.Probes; p != nil {
+		spec = p.Readiness
+		if len(p.Command) > 0 {
+			command = append([]string(nil), p.Command...)
+		}
+	}
+	if spec != nil && spec.Enabled != nil && !*spec.Enabled {
+		return nil
+	}
+
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: command},
+		},
+		InitialDelaySeconds: 5,
+		PeriodSeconds:       10,
+		TimeoutSeconds:      3,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	}
+	applyProbeSpec(probe, spec)
+
+	return probe
 }
 
 // buildTailscaleResourceRequirements creates resource requirements for the Tailscale sidecar
@@ -1404,6 +2064,8 @@ func buildTailscaleResourceRequirements(instance *openclawv1alpha1.OpenClawInsta
 	req.Limits[corev1.ResourceCPU] = ParseQuantity(instance.Spec.Tailscale.Resources.Limits.CPU, "200m")
 	req.Limits[corev1.ResourceMemory] = ParseQuantity(instance.Spec.Tailscale.Resources.Limits.Memory, "256Mi")
 
+	applyGuaranteedResources(instance.Spec.Tailscale.Resources, &req)
+
 	return req
 }
 
@@ -1417,7 +2079,7 @@ func buildTailscaleBinInitContainer(instance *openclawv1alpha1.OpenClawInstance)
 	return corev1.Container{
 		Name:            "init-tailscale-bin",
 		Image:           image,
-		ImagePullPolicy: corev1.PullIfNotPresent,
+		ImagePullPolicy: sidecarPullPolicy(instance),
 		Command:         []string{"sh", "-c", "cp /usr/local/bin/tailscale " + TailscaleBinPath + "/tailscale"},
 		VolumeMounts: []corev1.VolumeMount{
 			{
@@ -1444,10 +2106,10 @@ func buildTailscaleBinInitContainer(instance *openclawv1alpha1.OpenClawInstance)
 // buildGatewayProxyContainer creates the nginx reverse proxy sidecar that
 // exposes the loopback-bound gateway and canvas ports for external access.
 func buildGatewayProxyContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.Container {
-	return corev1.Container{
+	container := corev1.Container{
 		Name:            "gateway-proxy",
 		Image:           ApplyRegistryOverride(DefaultGatewayProxyImage, instance.Spec.Registry),
-		ImagePullPolicy: corev1.PullIfNotPresent,
+		ImagePullPolicy: sidecarPullPolicy(instance),
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          "gw-proxy",
@@ -1497,6 +2159,31 @@ func buildGatewayProxyContainer(instance *openclawv1alpha1.OpenClawInstance) cor
 		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 	}
+
+	// Delay SIGTERM so the proxy keeps forwarding while the main container
+	// shuts down and in-flight connections drain.
+	drain := DefaultGatewayProxyDrainSeconds
+	if instance.Spec.Networking.GatewayProxy.DrainSeconds != nil {
+		drain = *instance.Spec.Networking.GatewayProxy.DrainSeconds
+	}
+	if drain > 0 {
+		container.Lifecycle = &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"sleep", fmt.Sprintf("%d", drain)},
+				},
+			},
+		}
+	}
+
+	// Extra nginx flags need the image's default command spelled out, since
+	// container args replace it
+	if extra := instance.Spec.Networking.GatewayProxy.ExtraArgs; len(extra) > 0 {
+		container.Args = append([]string{"nginx", "-g", "daemon off;"}, extra...)
+	}
+	container.Env = append(container.Env, instance.Spec.Networking.GatewayProxy.ExtraEnv...)
+
+	return container
 }
 
 // buildChromiumContainer creates the Chromium sidecar container.
@@ -1505,7 +2192,9 @@ func buildGatewayProxyContainer(instance *openclawv1alpha1.OpenClawInstance) cor
 // issues where browserless kills Chrome when the WebSocket client
 // disconnects between tool calls (see #360). Additional launch args
 // (anti-bot flags + user ExtraArgs) are passed as container args to run.sh.
-func buildChromiumContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.Container {
+// replica selects the sidecar's name, CDP port and volumes when
+// spec.chromium.replicas is above 1.
+func buildChromiumContainer(instance *openclawv1alpha1.OpenClawInstance, replica int) corev1.Container {
 	repo := instance.Spec.Chromium.Image.Repository
 	if repo == "" {
 		repo = DefaultChromiumImage
@@ -1536,7 +2225,7 @@ func buildChromiumContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.
 		tag = DefaultChromiumTag
 	}
 
-	image := repo + ":" + tag
+	image := repo + ":" + WithTagVariant(tag, instance.Spec.Chromium.Image.Variant)
 	if instance.Spec.Chromium.Image.Digest != "" {
 		image = repo + "@" + instance.Spec.Chromium.Image.Digest
 	}
@@ -1544,15 +2233,15 @@ func buildChromiumContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.
 
 	chromiumMounts := []corev1.VolumeMount{
 		{
-			Name:      "chromium-tmp",
+			Name:      chromiumVolumeName("chromium-tmp", replica),
 			MountPath: "/tmp",
 		},
 		{
-			Name:      "chromium-shm",
+			Name:      chromiumVolumeName("chromium-shm", replica),
 			MountPath: "/dev/shm",
 		},
 		{
-			Name:      "chromium-data",
+			Name:      chromiumVolumeName("chromium-data", replica),
 			MountPath: "/chromium-data",
 		},
 	}
@@ -1586,15 +2275,20 @@ func buildChromiumContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.
 	// upstream run.sh that causes word-splitting of args with spaces
 	// (e.g. --user-agent), leading to "Multiple targets are not supported".
 	// Custom images keep their own entrypoint. See #396.
+	port := ChromiumReplicaPort(replica)
+	portName := "cdp"
+	if replica > 0 {
+		portName = fmt.Sprintf("cdp-%d", replica)
+	}
 	var command []string
 	if repo == DefaultChromiumImage {
-		command = ChromiumEntrypointCommand
+		command = chromiumEntrypointCommand(port)
 	}
 
 	return corev1.Container{
-		Name:                     "chromium",
+		Name:                     ChromiumContainerName(instance, replica),
 		Image:                    image,
-		ImagePullPolicy:          corev1.PullIfNotPresent,
+		ImagePullPolicy:          sidecarPullPolicy(instance),
 		Command:                  command,
 		Args:                     ChromiumArgs(instance),
 		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
@@ -1603,7 +2297,8 @@ func buildChromiumContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.
 			AllowPrivilegeEscalation: Ptr(false),
 			ReadOnlyRootFilesystem:   Ptr(false), // Chromium needs writable dirs for profiles, cache, crash dumps
 			RunAsNonRoot:             Ptr(true),
-			RunAsUser:                Ptr(int64(65534)), // nobody - headless-shell has no pre-created users
+			RunAsUser:                Ptr(int64(ChromiumUID)), // nobody - headless-shell has no pre-created users
+			RunAsGroup:               instance.Spec.Chromium.FSGroup,
 			Capabilities: &corev1.Capabilities{
 				Drop: []corev1.Capability{"ALL"},
 			},
@@ -1613,8 +2308,8 @@ func buildChromiumContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.
 		},
 		Ports: []corev1.ContainerPort{
 			{
-				Name:          "cdp",
-				ContainerPort: ChromiumPort,
+				Name:          portName,
+				ContainerPort: port,
 				Protocol:      corev1.ProtocolTCP,
 			},
 		},
@@ -1627,7 +2322,7 @@ func buildChromiumContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.
 			ProbeHandler: corev1.ProbeHandler{
 				HTTPGet: &corev1.HTTPGetAction{
 					Path: "/json/version",
-					Port: intstr.FromInt32(ChromiumPort),
+					Port: intstr.FromInt32(port),
 				},
 			},
 			InitialDelaySeconds: 1,
@@ -1639,6 +2334,76 @@ func buildChromiumContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.
 	}
 }
 
+// chromiumVolumeName returns the name of a Chromium volume for sidecar i.
+// The first sidecar keeps the unsuffixed names.
+func chromiumVolumeName(base string, i int) string {
+	if i == 0 {
+		return base
+	}
+	return fmt.Sprintf("%s-%d", base, i)
+}
+
+// buildChownInitContainer creates the init-chown container that hands the
+// Chromium profile and Ollama model volumes to spec.chromium.fsGroup and
+// spec.ollama.fsGroup. Both sidecars run outside the pod fsGroup (Chromium as
+// 65534, Ollama as root), which CSI drivers without fsGroup support leave
+// unwritable. It runs as root with only CAP_CHOWN and CAP_DAC_READ_SEARCH.
+// Returns nil if neither fsGroup is set.
+func buildChownInitContainer(instance *openclawv1alpha1.OpenClawInstance) *corev1.Container {
+	var cmds []string
+	var mounts []corev1.VolumeMount
+	chown := func(volume string, uid int64, gid int64) {
+		path := "/chown/" + volume
+		cmds = append(cmds, fmt.Sprintf("chown -R %d:%d %s", uid, gid, path))
+		mounts = append(mounts, corev1.VolumeMount{Name: volume, MountPath: path})
+	}
+
+	if gid := instance.Spec.Chromium.FSGroup; instance.Spec.Chromium.Enabled && gid != nil {
+		for i := range ChromiumReplicas(instance) {
+			chown(chromiumVolumeName("chromium-data", i), ChromiumUID, *gid)
+		}
+	}
+	if gid := instance.Spec.Ollama.FSGroup; IsOllamaSidecarEnabled(instance) && gid != nil {
+		chown("ollama-models", 0, *gid)
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+
+	return &corev1.Container{
+		Name:                     "init-chown",
+		Image:                    GetImage(instance),
+		Command:                  []string{"sh", "-c", strings.Join(cmds, " && ")},
+		ImagePullPolicy:          getPullPolicy(instance),
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: Ptr(false),
+			ReadOnlyRootFilesystem:   Ptr(true),
+			RunAsNonRoot:             Ptr(false), // chown needs root
+			RunAsUser:                Ptr(int64(0)),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+				Add:  []corev1.Capability{"CHOWN", "DAC_READ_SEARCH"},
+			},
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			},
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("16Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+		},
+		VolumeMounts: mounts,
+	}
+}
+
 // buildOllamaContainer creates the Ollama sidecar container
 func buildOllamaContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.Container {
 	repo := instance.Spec.Ollama.Image.Repository
@@ -1651,7 +2416,7 @@ func buildOllamaContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.Co
 		tag = DefaultImageTag
 	}
 
-	image := repo + ":" + tag
+	image := repo + ":" + WithTagVariant(tag, instance.Spec.Ollama.Image.Variant)
 	if instance.Spec.Ollama.Image.Digest != "" {
 		image = repo + "@" + instance.Spec.Ollama.Image.Digest
 	}
@@ -1660,7 +2425,7 @@ func buildOllamaContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.Co
 	container := corev1.Container{
 		Name:                     "ollama",
 		Image:                    image,
-		ImagePullPolicy:          corev1.PullIfNotPresent,
+		ImagePullPolicy:          sidecarPullPolicy(instance),
 		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 		SecurityContext: &corev1.SecurityContext{
@@ -1668,6 +2433,7 @@ func buildOllamaContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.Co
 			ReadOnlyRootFilesystem:   Ptr(false), // Ollama needs writable dirs
 			RunAsNonRoot:             Ptr(false), // Ollama requires root
 			RunAsUser:                Ptr(int64(0)),
+			RunAsGroup:               instance.Spec.Ollama.FSGroup,
 			Capabilities: &corev1.Capabilities{
 				Drop: []corev1.Capability{"ALL"},
 			},
@@ -1706,7 +2472,7 @@ func buildWebTerminalContainer(instance *openclawv1alpha1.OpenClawInstance) core
 		tag = DefaultImageTag
 	}
 
-	image := repo + ":" + tag
+	image := repo + ":" + WithTagVariant(tag, instance.Spec.WebTerminal.Image.Variant)
 	if instance.Spec.WebTerminal.Image.Digest != "" {
 		image = repo + "@" + instance.Spec.WebTerminal.Image.Digest
 	}
@@ -1776,7 +2542,7 @@ func buildWebTerminalContainer(instance *openclawv1alpha1.OpenClawInstance) core
 		Name:                     "web-terminal",
 		Image:                    image,
 		Command:                  command,
-		ImagePullPolicy:          corev1.PullIfNotPresent,
+		ImagePullPolicy:          sidecarPullPolicy(instance),
 		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 		SecurityContext: &corev1.SecurityContext{
@@ -1819,6 +2585,8 @@ func buildWebTerminalResourceRequirements(instance *openclawv1alpha1.OpenClawIns
 	req.Limits[corev1.ResourceCPU] = ParseQuantity(instance.Spec.WebTerminal.Resources.Limits.CPU, "200m")
 	req.Limits[corev1.ResourceMemory] = ParseQuantity(instance.Spec.WebTerminal.Resources.Limits.Memory, "128Mi")
 
+	applyGuaranteedResources(instance.Spec.WebTerminal.Resources, &req)
+
 	return req
 }
 
@@ -1832,7 +2600,7 @@ func buildOTelCollectorContainer(instance *openclawv1alpha1.OpenClawInstance) co
 	return corev1.Container{
 		Name:                     "otel-collector",
 		Image:                    image,
-		ImagePullPolicy:          corev1.PullIfNotPresent,
+		ImagePullPolicy:          sidecarPullPolicy(instance),
 		Args:                     []string{"--config=/etc/otel-collector/config.yaml"},
 		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
@@ -1890,24 +2658,50 @@ func buildOllamaResourceRequirements(instance *openclawv1alpha1.OpenClawInstance
 	req.Limits[corev1.ResourceCPU] = ParseQuantity(instance.Spec.Ollama.Resources.Limits.CPU, "2000m")
 	req.Limits[corev1.ResourceMemory] = ParseQuantity(instance.Spec.Ollama.Resources.Limits.Memory, "4Gi")
 
+	applyGuaranteedResources(instance.Spec.Ollama.Resources, &req)
+
 	// GPU support
 	if instance.Spec.Ollama.GPU != nil && *instance.Spec.Ollama.GPU > 0 {
 		gpuQty := ParseQuantity(fmt.Sprintf("%d", *instance.Spec.Ollama.GPU), "0")
-		req.Requests[corev1.ResourceName("nvidia.com/gpu")] = gpuQty
-		req.Limits[corev1.ResourceName("nvidia.com/gpu")] = gpuQty
+		gpuResource := OllamaGPUResourceName(instance)
+		req.Requests[gpuResource] = gpuQty
+		req.Limits[gpuResource] = gpuQty
 	}
 
 	return req
 }
 
+// OllamaGPUResourceName returns the extended resource name used for Ollama GPU
+// requests, honoring spec.ollama.gpuResourceName (e.g. MIG profiles).
+func OllamaGPUResourceName(instance *openclawv1alpha1.OpenClawInstance) corev1.ResourceName {
+	if instance.Spec.Ollama.GPUResourceName != "" {
+		return corev1.ResourceName(instance.Spec.Ollama.GPUResourceName)
+	}
+	return DefaultGPUResourceName
+}
+
+// OllamaInitTimeoutSeconds returns the per-model pull timeout of init-ollama
+// (spec.ollama.initTimeoutSeconds), defaulting to one hour.
+func OllamaInitTimeoutSeconds(instance *openclawv1alpha1.OpenClawInstance) int32 {
+	if t := instance.Spec.Ollama.InitTimeoutSeconds; t != nil {
+		return *t
+	}
+	return 3600
+}
+
 // buildOllamaModelPullInitContainer creates the init container that pre-pulls Ollama models.
 func buildOllamaModelPullInitContainer(instance *openclawv1alpha1.OpenClawInstance) corev1.Container {
-	// Build the pull command: start server, pull each model, then stop server
+	// Build the pull command: start server, pull each model under a timeout,
+	// then stop server. Pull errors are tolerated, but a timeout (exit 124)
+	// fails the container so a hung download does not block the pod forever.
+	timeout := OllamaInitTimeoutSeconds(instance)
 	var pullCmds []string
 	for _, model := range instance.Spec.Ollama.Models {
-		pullCmds = append(pullCmds, fmt.Sprintf("ollama pull %s", shellQuote(model)))
+		pullCmds = append(pullCmds, fmt.Sprintf("timeout %d ollama pull %s", timeout, shellQuote(model)))
 	}
-	script := fmt.Sprintf("ollama serve & sleep 2 && %s; kill %%1 2>/dev/null; exit 0", strings.Join(pullCmds, " && "))
+	script := fmt.Sprintf("ollama serve & sleep 2 && %s; rc=$?; "+
+		"if [ $rc -eq 124 ]; then echo 'model pull timed out' >&2; kill %%1 2>/dev/null; exit 1; fi; "+
+		"kill %%1 2>/dev/null; exit 0", strings.Join(pullCmds, " && "))
 
 	repo := instance.Spec.Ollama.Image.Repository
 	if repo == "" {
@@ -1917,7 +2711,7 @@ func buildOllamaModelPullInitContainer(instance *openclawv1alpha1.OpenClawInstan
 	if tag == "" {
 		tag = DefaultImageTag
 	}
-	image := repo + ":" + tag
+	image := repo + ":" + WithTagVariant(tag, instance.Spec.Ollama.Image.Variant)
 	if instance.Spec.Ollama.Image.Digest != "" {
 		image = repo + "@" + instance.Spec.Ollama.Image.Digest
 	}
@@ -1927,7 +2721,7 @@ func buildOllamaModelPullInitContainer(instance *openclawv1alpha1.OpenClawInstan
 		Name:                     "init-ollama",
 		Image:                    image,
 		Command:                  []string{"sh", "-c", script},
-		ImagePullPolicy:          corev1.PullIfNotPresent,
+		ImagePullPolicy:          sidecarPullPolicy(instance),
 		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 		SecurityContext: &corev1.SecurityContext{
@@ -1935,6 +2729,7 @@ func buildOllamaModelPullInitContainer(instance *openclawv1alpha1.OpenClawInstan
 			ReadOnlyRootFilesystem:   Ptr(false), // Ollama needs writable dirs
 			RunAsNonRoot:             Ptr(false), // Ollama requires root
 			RunAsUser:                Ptr(int64(0)),
+			RunAsGroup:               instance.Spec.Ollama.FSGroup,
 			Capabilities: &corev1.Capabilities{
 				Drop: []corev1.Capability{"ALL"},
 			},
@@ -1958,7 +2753,7 @@ func buildVolumes(instance *openclawv1alpha1.OpenClawInstance, skillPacks *Resol
 
 	// Data volume (PVC or emptyDir)
 	switch {
-	case IsPersistenceEnabled(instance) && IsHPAEnabled(instance):
+	case UsesVolumeClaimTemplates(instance):
 		// VolumeClaimTemplates handle per-replica PVCs - the StatefulSet
 		// controller auto-creates a volume named "data" for each pod.
 	case IsPersistenceEnabled(instance):
@@ -1987,6 +2782,10 @@ func buildVolumes(instance *openclawv1alpha1.OpenClawInstance, skillPacks *Resol
 	// The controller enriches all config sources (raw, configMapRef, or
 	// empty default) and writes the result into this ConfigMap.
 	defaultMode := int32(0o644)
+	configMode := defaultMode
+	if instance.Spec.Config.FileMode != nil {
+		configMode = *instance.Spec.Config.FileMode
+	}
 	volumes = append(volumes, corev1.Volume{
 		Name: "config",
 		VolumeSource: corev1.VolumeSource{
@@ -1994,11 +2793,37 @@ func buildVolumes(instance *openclawv1alpha1.OpenClawInstance, skillPacks *Resol
 				LocalObjectReference: corev1.LocalObjectReference{
 					Name: ConfigMapName(instance),
 				},
-				DefaultMode: &defaultMode,
+				DefaultMode: &configMode,
 			},
 		},
 	})
 
+	// Executable projection of spec.entrypointScript from the config ConfigMap
+	if instance.Spec.EntrypointScript != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "entrypoint",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: ConfigMapName(instance),
+					},
+					Items:       []corev1.KeyToPath{{Key: EntrypointScriptKey, Path: EntrypointScriptKey}},
+					DefaultMode: Ptr(int32(0o755)),
+				},
+			},
+		})
+	}
+
+	// Writable copy of the config for spec.config.writableMount
+	if IsConfigMountWritable(instance) {
+		volumes = append(volumes, corev1.Volume{
+			Name: "operator-config",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
 	// Workspace init volume (ConfigMap with seed files)
 	if hasWorkspaceFiles(instance, skillPacks) {
 		volumes = append(volumes, corev1.Volume{
@@ -2014,8 +2839,9 @@ func buildVolumes(instance *openclawv1alpha1.OpenClawInstance, skillPacks *Resol
 		})
 	}
 
-	// Skills-tmp volume for skills init container
-	if len(instance.Spec.Skills) > 0 {
+	// Skills-tmp volume for skills init container (also emitted prune-only
+	// when skillsPrune is on and the last skill was removed)
+	if BuildSkillsScript(instance) != "" && !IsSkillsJobMode(instance) {
 		volumes = append(volumes, corev1.Volume{
 			Name: "skills-tmp",
 			VolumeSource: corev1.VolumeSource{
@@ -2082,6 +2908,11 @@ func buildVolumes(instance *openclawv1alpha1.OpenClawInstance, skillPacks *Resol
 		})
 	}
 
+	// Projected service account token for self-configure with a custom audience
+	if usesProjectedSAToken(instance) {
+		volumes = append(volumes, buildProjectedSATokenVolume(instance))
+	}
+
 	// Tailscale volumes (state lives under /tmp so no separate state volume)
 	if instance.Spec.Tailscale.Enabled {
 		volumes = append(volumes,
@@ -2104,45 +2935,89 @@ func buildVolumes(instance *openclawv1alpha1.OpenClawInstance, skillPacks *Resol
 				},
 			},
 		)
+		if !IsTailscaleUserspace(instance) {
+			volumes = append(volumes, corev1.Volume{
+				Name: "tailscale-tun",
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{
+						Path: TailscaleTunDevicePath,
+						Type: Ptr(corev1.HostPathCharDev),
+					},
+				},
+			})
+		}
 	}
 
-	// Chromium volumes
+	// Chromium volumes, one set per sidecar
 	if instance.Spec.Chromium.Enabled {
-		volumes = append(volumes,
-			corev1.Volume{
-				Name: "chromium-tmp",
-				VolumeSource: corev1.VolumeSource{
-					EmptyDir: &corev1.EmptyDirVolumeSource{},
+		var tmpSizeLimit *resource.Quantity
+		if limit := instance.Spec.Chromium.TmpSizeLimit; limit != "" {
+			if q, err := resource.ParseQuantity(limit); err == nil {
+				tmpSizeLimit = &q
+			}
+		}
+		for i := range ChromiumReplicas(instance) {
+			volumes = append(volumes,
+				corev1.Volume{
+					Name: chromiumVolumeName("chromium-tmp", i),
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{
+							SizeLimit: tmpSizeLimit,
+						},
+					},
 				},
-			},
-			corev1.Volume{
-				Name: "chromium-shm",
-				VolumeSource: corev1.VolumeSource{
-					EmptyDir: &corev1.EmptyDirVolumeSource{
-						Medium:    corev1.StorageMediumMemory,
-						SizeLimit: resource.NewQuantity(1024*1024*1024, resource.BinarySI), // 1Gi
+				corev1.Volume{
+					Name: chromiumVolumeName("chromium-shm", i),
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{
+							Medium:    corev1.StorageMediumMemory,
+							SizeLimit: resource.NewQuantity(1024*1024*1024, resource.BinarySI), // 1Gi
+						},
 					},
 				},
-			},
-		)
-
-		// Chromium browser profile data volume - persistent PVC or ephemeral emptyDir
-		if instance.Spec.Chromium.Persistence.Enabled {
-			claimName := ChromiumPVCName(instance)
-			if instance.Spec.Chromium.Persistence.ExistingClaim != "" {
-				claimName = instance.Spec.Chromium.Persistence.ExistingClaim
+			)
+
+			// Chromium browser profile data volume - persistent PVC or ephemeral
+			// emptyDir. Only the first sidecar persists its profile; Chrome locks
+			// the profile directory, so it cannot be shared.
+			if i == 0 && instance.Spec.Chromium.Persistence.Enabled {
+				claimName := ChromiumPVCName(instance)
+				if instance.Spec.Chromium.Persistence.ExistingClaim != "" {
+					claimName = instance.Spec.Chromium.Persistence.ExistingClaim
+				}
+				volumes = append(volumes, corev1.Volume{
+					Name: "chromium-data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: claimName,
+						},
+					},
+				})
+			} else {
+				volumes = append(volumes, corev1.Volume{
+					Name: chromiumVolumeName("chromium-data", i),
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				})
 			}
+		}
+	}
+
+	// Logs volume - persistent PVC when a size is set, otherwise emptyDir
+	if instance.Spec.Storage.Logs.Enabled {
+		if IsLogsPVCEnabled(instance) {
 			volumes = append(volumes, corev1.Volume{
-				Name: "chromium-data",
+				Name: "logs",
 				VolumeSource: corev1.VolumeSource{
 					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-						ClaimName: claimName,
+						ClaimName: LogsPVCName(instance),
 					},
 				},
 			})
 		} else {
 			volumes = append(volumes, corev1.Volume{
-				Name: "chromium-data",
+				Name: "logs",
 				VolumeSource: corev1.VolumeSource{
 					EmptyDir: &corev1.EmptyDirVolumeSource{},
 				},
@@ -2151,7 +3026,7 @@ func buildVolumes(instance *openclawv1alpha1.OpenClawInstance, skillPacks *Resol
 	}
 
 	// Ollama model cache volume
-	if instance.Spec.Ollama.Enabled {
+	if IsOllamaSidecarEnabled(instance) {
 		if instance.Spec.Ollama.Storage.ExistingClaim != "" {
 			volumes = append(volumes, corev1.Volume{
 				Name: "ollama-models",
@@ -2186,30 +3061,8 @@ func buildVolumes(instance *openclawv1alpha1.OpenClawInstance, skillPacks *Resol
 	}
 
 	// CA bundle volume
-	if cab := instance.Spec.Security.CABundle; cab != nil {
-		if cab.ConfigMapName != "" {
-			volumes = append(volumes, corev1.Volume{
-				Name: "ca-bundle",
-				VolumeSource: corev1.VolumeSource{
-					ConfigMap: &corev1.ConfigMapVolumeSource{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: cab.ConfigMapName,
-						},
-						DefaultMode: &defaultMode,
-					},
-				},
-			})
-		} else if cab.SecretName != "" {
-			volumes = append(volumes, corev1.Volume{
-				Name: "ca-bundle",
-				VolumeSource: corev1.VolumeSource{
-					Secret: &corev1.SecretVolumeSource{
-						SecretName:  cab.SecretName,
-						DefaultMode: &defaultMode,
-					},
-				},
-			})
-		}
+	if v := buildCABundleVolume(instance); v != nil {
+		volumes = append(volumes, *v)
 	}
 
 	// Custom sidecar volumes
@@ -2236,9 +3089,29 @@ func buildResourceRequirements(instance *openclawv1alpha1.OpenClawInstance) core
 	req.Limits[corev1.ResourceCPU] = ParseQuantity(instance.Spec.Resources.Limits.CPU, "2000m")
 	req.Limits[corev1.ResourceMemory] = ParseQuantity(instance.Spec.Resources.Limits.Memory, "4Gi")
 
+	applyGuaranteedResources(instance.Spec.Resources, &req)
+
 	return req
 }
 
+// applyGuaranteedResources sets limits equal to requests for spec.guaranteed,
+// so the container qualifies for the Guaranteed QoS class. A user-set limit
+// without a request wins over the default request; ValidateGuaranteedResources
+// rejects a request and limit that are both set but differ.
+func applyGuaranteedResources(spec openclawv1alpha1.ResourcesSpec, req *corev1.ResourceRequirements) {
+	if spec.Guaranteed == nil || !*spec.Guaranteed {
+		return
+	}
+	if spec.Requests.CPU == "" && spec.Limits.CPU != "" {
+		req.Requests[corev1.ResourceCPU] = req.Limits[corev1.ResourceCPU]
+	}
+	if spec.Requests.Memory == "" && spec.Limits.Memory != "" {
+		req.Requests[corev1.ResourceMemory] = req.Limits[corev1.ResourceMemory]
+	}
+	req.Limits[corev1.ResourceCPU] = req.Requests[corev1.ResourceCPU]
+	req.Limits[corev1.ResourceMemory] = req.Requests[corev1.ResourceMemory]
+}
+
 // buildChromiumResourceRequirements creates resource requirements for the Chromium container
 func buildChromiumResourceRequirements(instance *openclawv1alpha1.OpenClawInstance) corev1.ResourceRequirements {
 	req := corev1.ResourceRequirements{
@@ -2254,9 +3127,25 @@ func buildChromiumResourceRequirements(instance *openclawv1alpha1.OpenClawInstan
 	req.Limits[corev1.ResourceCPU] = ParseQuantity(instance.Spec.Chromium.Resources.Limits.CPU, "1000m")
 	req.Limits[corev1.ResourceMemory] = ParseQuantity(instance.Spec.Chromium.Resources.Limits.Memory, "2Gi")
 
+	applyGuaranteedResources(instance.Spec.Chromium.Resources, &req)
+
 	return req
 }
 
+// buildProbeHandler returns the handler shared by the main container probes:
+// an exec handler when spec.probes.execCommand is set, otherwise the HTTP GET
+// handler for path.
+func buildProbeHandler(path string, instance *openclawv1alpha1.OpenClawInstance) corev1.ProbeHandler {
+	if instance.Spec.Probes != nil && len(instance.Spec.Probes.ExecCommand) > 0 {
+		return corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: append([]string(nil), instance.Spec.Probes.ExecCommand...),
+			},
+		}
+	}
+	return buildHTTPProbeHandler(path, instance)
+}
+
 // buildHTTPProbeHandler returns an HTTP GET probe handler. When the gateway
 // proxy sidecar is enabled, probes target the proxy port (18790) which
 // forwards to the gateway on loopback. When disabled, probes hit the
@@ -2275,41 +3164,88 @@ func buildHTTPProbeHandler(path string, instance *openclawv1alpha1.OpenClawInsta
 	}
 }
 
-// buildLivenessProbe creates the liveness probe
-func buildLivenessProbe(instance *openclawv1alpha1.OpenClawInstance) *corev1.Probe {
-	var spec *openclawv1alpha1.ProbeSpec
-	if instance.Spec.Probes != nil {
-		spec = instance.Spec.Probes.Liveness
+// ProbeTiming holds the timing settings of a single probe.
+type ProbeTiming struct {
+	InitialDelaySeconds int32
+	PeriodSeconds       int32
+	TimeoutSeconds      int32
+	FailureThreshold    int32
+}
+
+// ProbeConfig holds the default timings of the main container probes.
+// Fields set in spec.probes override these.
+type ProbeConfig struct {
+	Liveness  ProbeTiming
+	Readiness ProbeTiming
+	Startup   ProbeTiming
+}
+
+// ProbeDefaults returns the default probe timings for the instance. When
+// Ollama pre-pulls models, the liveness probe gets a longer initial delay
+// (60s per model on top of the base 30s, capped at 300s) and a higher failure
+// threshold, so a slow model load in the shared pod does not get the main
+// container restarted.
+func ProbeDefaults(instance *openclawv1alpha1.OpenClawInstance) ProbeConfig {
+	cfg := ProbeConfig{
+		Liveness:  ProbeTiming{InitialDelaySeconds: 30, PeriodSeconds: 10, TimeoutSeconds: 5, FailureThreshold: 3},
+		Readiness: ProbeTiming{InitialDelaySeconds: 5, PeriodSeconds: 5, TimeoutSeconds: 3, FailureThreshold: 3},
+		Startup:   ProbeTiming{InitialDelaySeconds: 5, PeriodSeconds: 5, TimeoutSeconds: 3, FailureThreshold: 60}, // 60 * 5s = 300s startup time
 	}
+
+	if models := len(instance.Spec.Ollama.Models); IsOllamaSidecarEnabled(instance) && models > 0 {
+		cfg.Liveness.InitialDelaySeconds = min(cfg.Liveness.InitialDelaySeconds+60*int32(min(models, 5)), 300)
+		cfg.Liveness.FailureThreshold = 6
+	}
+
+	return cfg
+}
+
+// buildProbe creates a probe for path from the default timings, applying any
+// overrides from spec. Returns nil when spec disables the probe.
+func buildProbe(instance *openclawv1alpha1.OpenClawInstance, path string, timing ProbeTiming, spec *openclawv1alpha1.ProbeSpec) *corev1.Probe {
 	if spec != nil && spec.Enabled != nil && !*spec.Enabled {
 		return nil
 	}
 
 	probe := &corev1.Probe{
-		ProbeHandler:        buildHTTPProbeHandler("/healthz", instance),
-		InitialDelaySeconds: 30,
-		PeriodSeconds:       10,
-		TimeoutSeconds:      5,
+		ProbeHandler:        buildProbeHandler(path, instance),
+		InitialDelaySeconds: timing.InitialDelaySeconds,
+		PeriodSeconds:       timing.PeriodSeconds,
+		TimeoutSeconds:      timing.TimeoutSeconds,
 		SuccessThreshold:    1,
-		FailureThreshold:    3,
+		FailureThreshold:    timing.FailureThreshold,
 	}
+	applyProbeSpec(probe, spec)
 
-	if spec != nil {
-		if spec.InitialDelaySeconds != nil {
-			probe.InitialDelaySeconds = *spec.InitialDelaySeconds
-		}
-		if spec.PeriodSeconds != nil {
-			probe.PeriodSeconds = *spec.PeriodSeconds
-		}
-		if spec.TimeoutSeconds != nil {
-			probe.TimeoutSeconds = *spec.TimeoutSeconds
-		}
-		if spec.FailureThreshold != nil {
-			probe.FailureThreshold = *spec.FailureThreshold
-		}
+	return probe
+}
+
+// applyProbeSpec overrides the probe timings with the fields set in spec.
+func applyProbeSpec(probe *corev1.Probe, spec *openclawv1alpha1.ProbeSpec) {
+	if spec == nil {
+		return
+	}
+	if spec.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *spec.InitialDelaySeconds
+	}
+	if spec.PeriodSeconds != nil {
+		probe.PeriodSeconds = *spec.PeriodSeconds
+	}
+	if spec.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *spec.TimeoutSeconds
+	}
+	if spec.FailureThreshold != nil {
+		probe.FailureThreshold = *spec.FailureThreshold
 	}
+}
 
-	return probe
+// buildLivenessProbe creates the liveness probe
+func buildLivenessProbe(instance *openclawv1alpha1.OpenClawInstance) *corev1.Probe {
+	var spec *openclawv1alpha1.ProbeSpec
+	if instance.Spec.Probes != nil {
+		spec = instance.Spec.Probes.Liveness
+	}
+	return buildProbe(instance, "/healthz", ProbeDefaults(instance).Liveness, spec)
 }
 
 // buildReadinessProbe creates the readiness probe
@@ -2318,35 +3254,7 @@ func buildReadinessProbe(instance *openclawv1alpha1.OpenClawInstance) *corev1.Pr
 	if instance.Spec.Probes != nil {
 		spec = instance.Spec.Probes.Readiness
 	}
-	if spec != nil && spec.Enabled != nil && !*spec.Enabled {
-		return nil
-	}
-
-	probe := &corev1.Probe{
-		ProbeHandler:        buildHTTPProbeHandler("/readyz", instance),
-		InitialDelaySeconds: 5,
-		PeriodSeconds:       5,
-		TimeoutSeconds:      3,
-		SuccessThreshold:    1,
-		FailureThreshold:    3,
-	}
-
-	if spec != nil {
-		if spec.InitialDelaySeconds != nil {
-			probe.InitialDelaySeconds = *spec.InitialDelaySeconds
-		}
-		if spec.PeriodSeconds != nil {
-			probe.PeriodSeconds = *spec.PeriodSeconds
-		}
-		if spec.TimeoutSeconds != nil {
-			probe.TimeoutSeconds = *spec.TimeoutSeconds
-		}
-		if spec.FailureThreshold != nil {
-			probe.FailureThreshold = *spec.FailureThreshold
-		}
-	}
-
-	return probe
+	return buildProbe(instance, "/readyz", ProbeDefaults(instance).Readiness, spec)
 }
 
 // buildStartupProbe creates the startup probe
@@ -2355,35 +3263,48 @@ func buildStartupProbe(instance *openclawv1alpha1.OpenClawInstance) *corev1.Prob
 	if instance.Spec.Probes != nil {
 		spec = instance.Spec.Probes.Startup
 	}
-	if spec != nil && spec.Enabled != nil && !*spec.Enabled {
-		return nil
-	}
+	return buildProbe(instance, "/healthz", ProbeDefaults(instance).Startup, spec)
+}
 
-	probe := &corev1.Probe{
-		ProbeHandler:        buildHTTPProbeHandler("/healthz", instance),
-		InitialDelaySeconds: 5,
-		PeriodSeconds:       5,
-		TimeoutSeconds:      3,
-		SuccessThreshold:    1,
-		FailureThreshold:    60, // 60 * 5s = 300s startup time
+// deepMergeFunc is the JS deep-merge used by merge mode: nested objects are
+// merged recursively, arrays and scalars from the operator config win.
+const deepMergeFunc = `function dm(a,b){const r={...a};for(const k in b){r[k]=b[k]&&typeof b[k]==="object"&&!Array.isArray(b[k])&&r[k]&&typeof r[k]==="object"&&!Array.isArray(r[k])?dm(r[k],b[k]):b[k]}return r}`
+
+// shallowMergeFunc replaces top-level keys wholesale.
+const shallowMergeFunc = `function dm(a,b){return Object.assign({},a,b)}`
+
+// configMergeFunc returns the JS merge function ("dm") for the instance's
+// spec.config.mergeStrategy.
+func configMergeFunc(instance *openclawv1alpha1.OpenClawInstance) string {
+	if instance.Spec.Config.MergeStrategy == ConfigMergeStrategyShallow {
+		return shallowMergeFunc
 	}
+	return deepMergeFunc
+}
 
-	if spec != nil {
-		if spec.InitialDelaySeconds != nil {
-			probe.InitialDelaySeconds = *spec.InitialDelaySeconds
-		}
-		if spec.PeriodSeconds != nil {
-			probe.PeriodSeconds = *spec.PeriodSeconds
-		}
-		if spec.TimeoutSeconds != nil {
-			probe.TimeoutSeconds = *spec.TimeoutSeconds
-		}
-		if spec.FailureThreshold != nil {
-			probe.FailureThreshold = *spec.FailureThreshold
-		}
+// IsConfigMountWritable returns true if /operator-config is writable in the
+// main container (spec.config.writableMount).
+func IsConfigMountWritable(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return instance.Spec.Config.WritableMount != nil && *instance.Spec.Config.WritableMount
+}
+
+// buildPostStartCommand returns the main container's postStart command. With a
+// writable config mount it first seeds /operator-config from the ConfigMap
+// unless a copy (possibly edited by the agent) already exists, then restores
+// the config from it. A failed seed is not fatal (a failing postStart hook
+// kills the container); only the restore decides the hook's exit status.
+func buildPostStartCommand(instance *openclawv1alpha1.OpenClawInstance) string {
+	restore := buildConfigRestoreCommand(instance)
+	if !IsConfigMountWritable(instance) {
+		return restore
 	}
 
-	return probe
+	key := configMapKey(instance)
+	seed := fmt.Sprintf("[ -f /operator-config/%s ] || cp /operator-config-source/%s /operator-config/%s || true", key, key, key)
+	if restore == "" {
+		return seed
+	}
+	return seed + "; " + restore
 }
 
 // buildConfigRestoreCommand returns the shell command for the main container's
@@ -2398,16 +3319,16 @@ func buildConfigRestoreCommand(instance *openclawv1alpha1.OpenClawInstance) stri
 	}
 
 	src := "/operator-config/" + key
-	dst := "/home/openclaw/.openclaw/openclaw.json"
+	dst := "/home/openclaw/.openclaw/" + ConfigFileName(instance)
 
 	switch {
 	case instance.Spec.Config.MergeMode == ConfigMergeModeMerge:
-		// Deep-merge operator config into existing PVC config via Node.js.
+		// Merge operator config into existing PVC config via Node.js.
 		// Same logic as the init container merge, but with main container paths.
 		return fmt.Sprintf(
 			`node -e '`+
 				`const fs=require("fs");`+
-				`function dm(a,b){const r={...a};for(const k in b){r[k]=b[k]&&typeof b[k]==="object"&&!Array.isArray(b[k])&&r[k]&&typeof r[k]==="object"&&!Array.isArray(r[k])?dm(r[k],b[k]):b[k]}return r}`+
+				configMergeFunc(instance)+
 				`const e="%s",c="%s",t="/tmp/merged.json";`+
 				`const base=fs.existsSync(e)?JSON.parse(fs.readFileSync(e,"utf8")):{};`+
 				`const inc=JSON.parse(fs.readFileSync(c,"utf8"));`+
@@ -2433,15 +3354,39 @@ func getPullPolicy(instance *openclawv1alpha1.OpenClawInstance) corev1.PullPolic
 	return corev1.PullIfNotPresent
 }
 
-// calculateConfigHash computes a hash of the config, skills, plugins, and
-// runtime settings for rollout detection. Changes to any of these trigger a
-// pod restart. Workspace files are intentionally excluded because they are
+// sidecarPullPolicy returns the pull policy for operator-managed sidecar and
+// init containers. They default to IfNotPresent, but a Never policy on the
+// main image (preloaded images in kind/CI) applies to every container.
+func sidecarPullPolicy(instance *openclawv1alpha1.OpenClawInstance) corev1.PullPolicy {
+	if getPullPolicy(instance) == corev1.PullNever {
+		return corev1.PullNever
+	}
+	return corev1.PullIfNotPresent
+}
+
+// calculateConfigHash computes a hash of the config, skills, plugins,
+// maintenance command, and runtime settings for rollout detection. Changes to
+// any of these trigger a pod restart. Workspace files are intentionally excluded because they are
 // delivered via a projected ConfigMap volume that the kubelet updates in-place
-// without requiring a pod restart.
+// without requiring a pod restart. The ConfigMap-only inputs listed in
+// spec.configHashExclude are skipped as well.
 func calculateConfigHash(instance *openclawv1alpha1.OpenClawInstance, _ map[string]string, _ map[string]map[string]string) string {
 	h := sha256.New()
-	configData, _ := json.Marshal(instance.Spec.Config)
-	h.Write(configData)
+	hashed := func(input string) bool {
+		return !slices.Contains(instance.Spec.ConfigHashExclude, input)
+	}
+	if hashed("config") {
+		configData, _ := json.Marshal(instance.Spec.Config)
+		h.Write(configData)
+	}
+	if len(instance.Spec.Channels) > 0 && hashed("channels") {
+		channelsData, _ := json.Marshal(instance.Spec.Channels)
+		h.Write(channelsData)
+	}
+	if len(instance.Spec.FeatureFlags) > 0 && hashed("featureFlags") {
+		featuresData, _ := json.Marshal(instance.Spec.FeatureFlags)
+		h.Write(featuresData)
+	}
 	if len(instance.Spec.Skills) > 0 {
 		skillsData, _ := json.Marshal(instance.Spec.Skills)
 		h.Write(skillsData)
@@ -2454,6 +3399,10 @@ func calculateConfigHash(instance *openclawv1alpha1.OpenClawInstance, _ map[stri
 		icData, _ := json.Marshal(instance.Spec.InitContainers)
 		h.Write(icData)
 	}
+	if instance.Spec.Maintenance.Enabled {
+		mData, _ := json.Marshal(instance.Spec.Maintenance)
+		h.Write(mData)
+	}
 	if instance.Spec.RuntimeDeps.Pnpm || instance.Spec.RuntimeDeps.Python {
 		rdData, _ := json.Marshal(instance.Spec.RuntimeDeps)
 		h.Write(rdData)
@@ -2462,6 +3411,28 @@ func calculateConfigHash(instance *openclawv1alpha1.OpenClawInstance, _ map[stri
 		tsData, _ := json.Marshal(instance.Spec.Tailscale)
 		h.Write(tsData)
 	}
+	if len(instance.Spec.ExtraConfigMapData) > 0 && hashed("extraConfigMapData") {
+		// json.Marshal sorts map keys, so the hash is deterministic
+		extraData, _ := json.Marshal(instance.Spec.ExtraConfigMapData)
+		h.Write(extraData)
+	}
+	if instance.Spec.EntrypointScript != "" {
+		h.Write([]byte(instance.Spec.EntrypointScript))
+	}
+	// gateway.bind is rendered into openclaw.json, which the gateway only
+	// reads at startup
+	if instance.Spec.Gateway.Bind != "" {
+		h.Write([]byte("gateway.bind=" + instance.Spec.Gateway.Bind))
+	}
+	// tokenFromEnvOnly moves gateway.auth.token out of openclaw.json
+	if IsGatewayTokenFromEnvOnly(instance) {
+		h.Write([]byte("gateway.tokenFromEnvOnly"))
+	}
+	// Browser profiles are rendered into openclaw.json
+	if len(instance.Spec.Chromium.Profiles) > 0 {
+		profilesData, _ := json.Marshal(instance.Spec.Chromium.Profiles)
+		h.Write(profilesData)
+	}
 	return hex.EncodeToString(h.Sum(nil)[:8])
 }
 
@@ -2563,6 +3534,13 @@ func normalizeProbe(p *corev1.Probe) {
 	}
 }
 
+// UsesVolumeClaimTemplates returns true if the data volume comes from a
+// StatefulSet volumeClaimTemplate (one PVC per replica). That is the case
+// with persistence and HPA; existingClaim is ignored in this mode.
+func UsesVolumeClaimTemplates(instance *openclawv1alpha1.OpenClawInstance) bool {
+	return IsPersistenceEnabled(instance) && IsHPAEnabled(instance)
+}
+
 // statefulSetReplicas returns the replica count for the StatefulSet.
 // When suspended, replicas is explicitly set to 0.
 // When HPA is enabled, replicas is set to nil so the HPA manages scaling.
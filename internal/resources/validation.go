@@ -18,13 +18,23 @@ package resources
 
 import (
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
 	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	openclawv1alpha1 "github.com/openclawrocks/openclaw-operator/api/v1alpha1"
 )
 
-// ValidateWorkspaceFilename checks a single workspace filename.
+// ValidateWorkspaceFilename checks a single workspace filename. The instance's
+// config file name (spec.config.fileName, default openclaw.json) is reserved.
 // Exported so both the webhook and the controller can validate filenames
 // (e.g. keys from an external ConfigMap referenced by spec.workspace.configMapRef).
-func ValidateWorkspaceFilename(name string) error {
+func ValidateWorkspaceFilename(instance *openclawv1alpha1.OpenClawInstance, name string) error {
 	if name == "" {
 		return fmt.Errorf("filename must not be empty")
 	}
@@ -43,8 +53,8 @@ func ValidateWorkspaceFilename(name string) error {
 	if strings.HasPrefix(name, ".") {
 		return fmt.Errorf("filename must not start with '.'")
 	}
-	if name == "openclaw.json" {
-		return fmt.Errorf("filename 'openclaw.json' is reserved for config")
+	if configFile := ConfigFileName(instance); name == configFile {
+		return fmt.Errorf("filename '%s' is reserved for config", configFile)
 	}
 	return nil
 }
@@ -69,3 +79,262 @@ func ValidateWorkspaceDirectory(dir string) error {
 	}
 	return nil
 }
+
+// ValidateIngress checks the Ingress path types. Unknown values would otherwise
+// silently fall back to Prefix in the builder. It also requires a host for the
+// metrics Ingress when that Ingress is enabled.
+func ValidateIngress(instance *openclawv1alpha1.OpenClawInstance) error {
+	if IsMetricsIngressEnabled(instance) && instance.Spec.Observability.Metrics.Ingress.Host == "" {
+		return fmt.Errorf("observability.metrics.ingress.host is required when observability.metrics.ingress.enabled is true")
+	}
+	ing := instance.Spec.Networking.Ingress
+	if _, ok := ParseIngressPathType(ing.DefaultPathType); !ok {
+		return fmt.Errorf("networking.ingress.defaultPathType %q must be one of Prefix, Exact, ImplementationSpecific", ing.DefaultPathType)
+	}
+	for i, host := range ing.Hosts {
+		for j, p := range host.Paths {
+			if _, ok := ParseIngressPathType(p.PathType); !ok {
+				return fmt.Errorf("networking.ingress.hosts[%d].paths[%d].pathType %q must be one of Prefix, Exact, ImplementationSpecific", i, j, p.PathType)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateResources checks that every CPU and memory string of the main
+// container and the sidecars is a valid Kubernetes quantity (e.g. "500m",
+// "2Gi"). The builders fall back to defaults for invalid values, so without
+// this check a typo like "2GG" would silently deploy the default.
+func ValidateResources(instance *openclawv1alpha1.OpenClawInstance) error {
+	for _, r := range []struct {
+		path string
+		spec openclawv1alpha1.ResourcesSpec
+	}{
+		{"spec.resources", instance.Spec.Resources},
+		{"spec.chromium.resources", instance.Spec.Chromium.Resources},
+		{"spec.tailscale.resources", instance.Spec.Tailscale.Resources},
+		{"spec.ollama.resources", instance.Spec.Ollama.Resources},
+		{"spec.webTerminal.resources", instance.Spec.WebTerminal.Resources},
+		{"spec.namespace.resourceQuota", openclawv1alpha1.ResourcesSpec{
+			Requests: instance.Spec.Namespace.ResourceQuota.Requests,
+			Limits:   instance.Spec.Namespace.ResourceQuota.Limits,
+		}},
+	} {
+		for _, q := range []struct{ name, value string }{
+			{"requests.cpu", r.spec.Requests.CPU},
+			{"requests.memory", r.spec.Requests.Memory},
+			{"limits.cpu", r.spec.Limits.CPU},
+			{"limits.memory", r.spec.Limits.Memory},
+		} {
+			if q.value == "" {
+				continue
+			}
+			if _, err := resource.ParseQuantity(q.value); err != nil {
+				return fmt.Errorf("%s.%s %q is not a valid Kubernetes quantity: %w", r.path, q.name, q.value, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateGuaranteedResources checks that a spec with guaranteed set does not
+// request and limit the same resource with different values, since the
+// builder would otherwise have to silently pick one. path prefixes the error.
+func ValidateGuaranteedResources(path string, spec openclawv1alpha1.ResourcesSpec) error {
+	if spec.Guaranteed == nil || !*spec.Guaranteed {
+		return nil
+	}
+	for _, r := range []struct{ name, request, limit string }{
+		{"cpu", spec.Requests.CPU, spec.Limits.CPU},
+		{"memory", spec.Requests.Memory, spec.Limits.Memory},
+	} {
+		if r.request == "" || r.limit == "" {
+			continue
+		}
+		req, reqErr := resource.ParseQuantity(r.request)
+		lim, limErr := resource.ParseQuantity(r.limit)
+		if reqErr == nil && limErr == nil && req.Cmp(lim) != 0 {
+			return fmt.Errorf("%s.guaranteed requires requests.%s (%s) to equal limits.%s (%s)", path, r.name, r.request, r.name, r.limit)
+		}
+	}
+	return nil
+}
+
+// ValidateConfigOverlay checks that spec.config.activeOverlay, when set,
+// references an entry in spec.config.overlays.
+func ValidateConfigOverlay(instance *openclawv1alpha1.OpenClawInstance) error {
+	active := instance.Spec.Config.ActiveOverlay
+	if active == "" {
+		return nil
+	}
+	if _, ok := instance.Spec.Config.Overlays[active]; !ok {
+		return fmt.Errorf("config.activeOverlay %q does not match any entry in config.overlays", active)
+	}
+	return nil
+}
+
+// ValidatePorts detects port collisions that would produce a broken pod or
+// Service: the same container port declared by two containers in the pod
+// (main, operator sidecars, native sidecars and user sidecars share one
+// network namespace), duplicate ports or names in custom service ports, and
+// custom service ports targeting an operator sidecar port that the default
+// Service does not expose (e.g. Ollama).
+func ValidatePorts(instance *openclawv1alpha1.OpenClawInstance) error {
+	type portKey struct {
+		port     int32
+		protocol corev1.Protocol
+	}
+	type portOwner struct {
+		index int
+		name  string
+	}
+	owners := map[portKey]portOwner{}
+
+	// Owners are tracked by position so that two containers with the same
+	// name (e.g. a user sidecar named like an operator sidecar) still collide.
+	for i, c := range portContainers(instance) {
+		for _, p := range c.Ports {
+			protocol := p.Protocol
+			if protocol == "" {
+				protocol = corev1.ProtocolTCP
+			}
+			key := portKey{port: p.ContainerPort, protocol: protocol}
+			if owner, ok := owners[key]; ok && owner.index != i {
+				return fmt.Errorf("container port %d/%s is declared by both %q and %q", p.ContainerPort, protocol, owner.name, c.Name)
+			}
+			owners[key] = portOwner{index: i, name: c.Name}
+		}
+	}
+
+	exposed := map[portKey]bool{}
+	for _, p := range buildDefaultServicePorts(instance) {
+		exposed[portKey{port: p.TargetPort.IntVal, protocol: p.Protocol}] = true
+	}
+	sidecars := OperatorSidecarNames(instance)
+
+	seenPorts := map[portKey]string{}
+	seenNames := map[string]bool{}
+	for i, p := range instance.Spec.Networking.Service.Ports {
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		key := portKey{port: p.Port, protocol: protocol}
+		if prev, ok := seenPorts[key]; ok {
+			return fmt.Errorf("networking.service.ports[%d]: port %d/%s is already used by %q", i, p.Port, protocol, prev)
+		}
+		seenPorts[key] = p.Name
+
+		target := portKey{port: p.Port, protocol: protocol}
+		if p.TargetPort != nil {
+			target.port = *p.TargetPort
+		}
+		if owner, ok := owners[target]; ok && slices.Contains(sidecars, owner.name) && !exposed[target] {
+			return fmt.Errorf("networking.service.ports[%d]: targetPort %d/%s belongs to the %q sidecar, which is not exposed through the Service", i, target.port, protocol, owner.name)
+		}
+
+		if p.Name != "" {
+			if seenNames[p.Name] {
+				return fmt.Errorf("networking.service.ports[%d]: duplicate port name %q", i, p.Name)
+			}
+			seenNames[p.Name] = true
+		}
+	}
+	return nil
+}
+
+// prometheusDurationRe matches a Prometheus duration such as "5m" or "1h30m".
+var prometheusDurationRe = regexp.MustCompile(`^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$`)
+
+// ValidatePrometheusRule checks the alert "for" durations: defaultFor and
+// every alertFor value must be Prometheus durations, and alertFor keys must
+// name a built-in alert.
+func ValidatePrometheusRule(instance *openclawv1alpha1.OpenClawInstance) error {
+	pr := instance.Spec.Observability.Metrics.PrometheusRule
+	if pr == nil {
+		return nil
+	}
+	if pr.DefaultFor != "" && !prometheusDurationRe.MatchString(pr.DefaultFor) {
+		return fmt.Errorf("observability.metrics.prometheusRule.defaultFor %q is not a valid Prometheus duration", pr.DefaultFor)
+	}
+	known := BuiltinAlertNames()
+	for _, name := range slices.Sorted(maps.Keys(pr.AlertFor)) {
+		if !slices.Contains(known, name) {
+			return fmt.Errorf("observability.metrics.prometheusRule.alertFor: unknown alert %q (known: %s)", name, strings.Join(known, ", "))
+		}
+		if d := pr.AlertFor[name]; d == "" || !prometheusDurationRe.MatchString(d) {
+			return fmt.Errorf("observability.metrics.prometheusRule.alertFor[%s] %q is not a valid Prometheus duration", name, d)
+		}
+	}
+	return nil
+}
+
+// imageDigestRe matches an OCI digest such as "sha256:<hex>".
+var imageDigestRe = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-fA-F0-9]{32,}$`)
+
+// ValidateImage checks the main image spec for pull-policy pitfalls. With
+// pullPolicy Never the kubelet can only use a preloaded image, so a malformed
+// digest is an error, and a mutable "latest" tag produces a warning since the
+// node may hold an arbitrary build under that tag.
+func ValidateImage(instance *openclawv1alpha1.OpenClawInstance) ([]string, error) {
+	img := instance.Spec.Image
+	if img.PullPolicy != corev1.PullNever {
+		return nil, nil
+	}
+	if img.Digest != "" {
+		if !imageDigestRe.MatchString(img.Digest) {
+			return nil, fmt.Errorf("image.digest %q must be of the form <algorithm>:<hex> when image.pullPolicy is Never", img.Digest)
+		}
+		return nil, nil
+	}
+	var warnings []string
+	if GetImageTag(instance) == "latest" {
+		warnings = append(warnings, "image.pullPolicy Never with the \"latest\" tag uses whatever image the node preloaded - pin a version or digest for reproducible runs")
+	}
+	return warnings, nil
+}
+
+// ValidateExtraConfigMapData checks spec.extraConfigMapData keys: they must be
+// valid ConfigMap keys and must not shadow an operator-managed key.
+func ValidateExtraConfigMapData(instance *openclawv1alpha1.OpenClawInstance) error {
+	reserved := map[string]bool{
+		ConfigFileName(instance): true,
+		NginxConfigKey:           true,
+		TailscaleServeConfigKey:  true,
+		OTelCollectorConfigKey:   true,
+		EntrypointScriptKey:      true,
+	}
+	for key := range instance.Spec.ExtraConfigMapData {
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return fmt.Errorf("extraConfigMapData key %q is invalid: %s", key, strings.Join(errs, "; "))
+		}
+		if reserved[key] {
+			return fmt.Errorf("extraConfigMapData key %q is reserved for operator-managed data", key)
+		}
+	}
+	return nil
+}
+
+// ValidateStorage rejects an operator-managed ReadWriteOnce claim that
+// several replicas would share: a ReadWriteOnce volume only attaches to one
+// node at a time. With more than one replica the data volume always comes
+// from a volumeClaimTemplate (one PVC per replica), so only the Chromium
+// profile PVC, which the operator creates ReadWriteOnce and mounts in every
+// pod, can be shared. User-provided claims are not checked since their
+// access modes are not known from the spec.
+func ValidateStorage(instance *openclawv1alpha1.OpenClawInstance) error {
+	replicas := MaxReplicas(instance)
+	if replicas <= 1 {
+		return nil
+	}
+	if IsPersistenceEnabled(instance) && !UsesVolumeClaimTemplates(instance) &&
+		instance.Spec.Storage.Persistence.ExistingClaim == "" &&
+		!slices.Contains(instance.Spec.Storage.Persistence.AccessModes, corev1.ReadWriteMany) {
+		return fmt.Errorf("the data PVC is ReadWriteOnce and would be shared by up to %d replicas without volumeClaimTemplates", replicas)
+	}
+	chromium := instance.Spec.Chromium
+	if chromium.Enabled && chromium.Persistence.Enabled && chromium.Persistence.ExistingClaim == "" {
+		return fmt.Errorf("chromium.persistence creates a ReadWriteOnce PVC that would be shared by up to %d replicas; disable chromium.persistence or set chromium.persistence.existingClaim to a ReadWriteMany claim", replicas)
+	}
+	return nil
+}
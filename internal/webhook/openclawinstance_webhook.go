@@ -20,10 +20,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -104,6 +107,12 @@ func (v *OpenClawInstanceValidator) ValidateUpdate(ctx context.Context, oldObj,
 		return nil, fmt.Errorf("storage class is immutable after creation")
 	}
 
+	// Renaming generated objects would orphan the existing PVC, Secrets and Service
+	if oldInstance.Spec.NamePrefix != instance.Spec.NamePrefix ||
+		oldInstance.Spec.NameSuffix != instance.Spec.NameSuffix {
+		return nil, fmt.Errorf("namePrefix and nameSuffix are immutable after creation")
+	}
+
 	return v.validate(instance)
 }
 
@@ -147,6 +156,60 @@ func (v *OpenClawInstanceValidator) validate(instance *openclawv1alpha1.OpenClaw
 			!*instance.Spec.Networking.Ingress.Security.ForceHTTPS {
 			warnings = append(warnings, "Ingress forceHTTPS is disabled - consider enabling for security")
 		}
+
+		// Warn if pathRewrite has no effect for the ingress provider
+		if instance.Spec.Networking.Ingress.PathRewrite != "" &&
+			resources.DetectIngressProvider(instance.Spec.Networking.Ingress.ClassName) != resources.IngressProviderNginx {
+			warnings = append(warnings, "Ingress pathRewrite is only applied for nginx - use a StripPrefix Middleware for traefik")
+		}
+	}
+
+	// 4b. Validate ingress source ranges (CIDRs or bare IPs)
+	for i, r := range instance.Spec.Networking.Ingress.Security.AllowedSourceRanges {
+		if err := validateSourceRange(r); err != nil {
+			return nil, fmt.Errorf("networking.ingress.security.allowedSourceRanges[%d] %q: %w", i, r, err)
+		}
+	}
+
+	// 4c. Validate ingress path types and the metrics Ingress host
+	if err := resources.ValidateIngress(instance); err != nil {
+		return nil, err
+	}
+
+	// 4d. Reject container and service port collisions
+	if err := resources.ValidatePorts(instance); err != nil {
+		return nil, err
+	}
+
+	// 4e. Warn if the gateway proxy is enabled but the gateway does not bind to loopback
+	if resources.HasGatewayProxyBindMismatch(instance) {
+		warnings = append(warnings, fmt.Sprintf("gateway.bind is %q while the gateway proxy is enabled - the gateway is reachable without the proxy and may reject plaintext ws:// connections; use \"loopback\" or set spec.gateway.enabled=false", resources.GatewayBind(instance)))
+	}
+
+	// 4e2. Basic auth needs a Secret with the credentials
+	if resources.IsGatewayBasicAuth(instance) && instance.Spec.Gateway.BasicAuthSecret == "" {
+		return nil, fmt.Errorf("spec.gateway.basicAuthSecret is required when spec.gateway.authMode is %q", resources.GatewayAuthModeBasic)
+	}
+
+	// 4f. Validate the Tailscale serve port
+	if instance.Spec.Tailscale.Enabled {
+		if err := validateTailscaleServePort(instance); err != nil {
+			return nil, err
+		}
+		if !resources.IsTailscaleUserspace(instance) {
+			warnings = append(warnings, "tailscale.userspace is false - the tailscale sidecar runs as root (uid 0) with NET_ADMIN and the node's /dev/net/tun, which the namespace's pod security level must allow")
+		}
+	}
+
+	// 4f2. A Localhost seccomp profile needs the profile path
+	if p := instance.Spec.Security.InitSeccompProfile; p != nil &&
+		p.Type == corev1.SeccompProfileTypeLocalhost && (p.LocalhostProfile == nil || *p.LocalhostProfile == "") {
+		return nil, fmt.Errorf("security.initSeccompProfile.localhostProfile is required when type is Localhost")
+	}
+
+	// 4g. Warn if a fixed nodePort has no effect
+	if instance.Spec.Networking.Service.NodePort != nil && instance.Spec.Networking.Service.Type != corev1.ServiceTypeNodePort {
+		warnings = append(warnings, "networking.service.nodePort is only applied when networking.service.type is NodePort")
 	}
 
 	// 5. Warn if Chromium is enabled without digest pinning
@@ -154,14 +217,35 @@ func (v *OpenClawInstanceValidator) validate(instance *openclawv1alpha1.OpenClaw
 		if instance.Spec.Chromium.Image.Digest == "" {
 			warnings = append(warnings, "Chromium sidecar is enabled without image digest pinning - consider pinning to a specific digest for supply chain security")
 		}
+		// Only the default image's entrypoint is overridden to listen on the
+		// per-replica CDP port; a custom image would listen on 9222 in every
+		// sidecar and the containers would collide.
+		if resources.ChromiumReplicas(instance) > 1 && !resources.UsesDefaultChromiumImage(instance) {
+			return nil, fmt.Errorf("spec.chromium.replicas > 1 requires the default Chromium image (%s); custom images cannot be assigned per-replica CDP ports", resources.DefaultChromiumImage)
+		}
 	}
 
 	// 5b. Warn if Ollama is enabled
-	if instance.Spec.Ollama.Enabled {
+	if resources.IsOllamaSidecarEnabled(instance) {
 		if instance.Spec.Ollama.Image.Digest == "" {
 			warnings = append(warnings, "Ollama sidecar is enabled without image digest pinning - consider pinning to a specific digest for supply chain security")
 		}
 		warnings = append(warnings, "Ollama sidecar runs as root (UID 0) - required by the official Ollama image")
+		for _, cidr := range instance.Spec.Ollama.RegistryCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return nil, fmt.Errorf("spec.ollama.registryCIDRs entry %q: must be a valid CIDR", cidr)
+			}
+		}
+	}
+	if resources.IsOllamaExternal(instance) {
+		for _, cidr := range instance.Spec.Ollama.ExternalHostCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return nil, fmt.Errorf("spec.ollama.externalHostCIDRs entry %q: must be a valid CIDR", cidr)
+			}
+		}
+		if !slices.ContainsFunc(instance.Spec.Env, func(e corev1.EnvVar) bool { return e.Name == "OLLAMA_HOST" }) {
+			warnings = append(warnings, "spec.ollama.external is set but spec.env has no OLLAMA_HOST - OpenClaw will not know where the Ollama server is")
+		}
 	}
 
 	// 5c. Warn if WebTerminal is enabled without digest pinning
@@ -198,9 +282,16 @@ func (v *OpenClawInstanceValidator) validate(instance *openclawv1alpha1.OpenClaw
 		warnings = append(warnings, "Image tag \"latest\" is mutable and not recommended for production - consider pinning to a specific version or digest")
 	}
 
+	// 10b. Validate image pull policy Never constraints
+	imageWarnings, err := resources.ValidateImage(instance)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, imageWarnings...)
+
 	// 11. Validate workspace spec
 	if instance.Spec.Workspace != nil {
-		if err := validateWorkspaceSpec(instance.Spec.Workspace); err != nil {
+		if err := validateWorkspaceSpec(instance); err != nil {
 			return nil, err
 		}
 	}
@@ -210,6 +301,22 @@ func (v *OpenClawInstanceValidator) validate(instance *openclawv1alpha1.OpenClaw
 		return nil, err
 	}
 
+	// 11c. Guaranteed resources must not set a request and limit that differ
+	for _, r := range []struct {
+		path string
+		spec openclawv1alpha1.ResourcesSpec
+	}{
+		{"spec.resources", instance.Spec.Resources},
+		{"spec.chromium.resources", instance.Spec.Chromium.Resources},
+		{"spec.tailscale.resources", instance.Spec.Tailscale.Resources},
+		{"spec.ollama.resources", instance.Spec.Ollama.Resources},
+		{"spec.webTerminal.resources", instance.Spec.WebTerminal.Resources},
+	} {
+		if err := resources.ValidateGuaranteedResources(r.path, r.spec); err != nil {
+			return nil, err
+		}
+	}
+
 	// 12. Validate auto-update spec
 	if instance.Spec.AutoUpdate.CheckInterval != "" {
 		d, err := time.ParseDuration(instance.Spec.AutoUpdate.CheckInterval)
@@ -236,6 +343,26 @@ func (v *OpenClawInstanceValidator) validate(instance *openclawv1alpha1.OpenClaw
 		}
 	}
 
+	// 15a. Skills job mode installs into the standalone data PVC
+	if resources.IsSkillsJobMode(instance) {
+		if !resources.IsPersistenceEnabled(instance) {
+			return nil, fmt.Errorf("skillsInstallMode \"job\" requires storage.persistence to be enabled")
+		}
+		if resources.IsHPAEnabled(instance) {
+			return nil, fmt.Errorf("skillsInstallMode \"job\" is not supported with availability.autoScaling (per-replica PVCs)")
+		}
+	}
+
+	// 15a1. A shared data claim must be mountable by every replica
+	if err := resources.ValidateStorage(instance); err != nil {
+		return nil, err
+	}
+
+	// 15a2. A logs PVC is ReadWriteOnce and shared by every replica
+	if resources.IsLogsPVCEnabled(instance) && resources.IsHPAEnabled(instance) {
+		return nil, fmt.Errorf("storage.logs.size (logs PVC) is not supported with availability.autoScaling; leave size empty for an emptyDir logs volume")
+	}
+
 	// 15b. Validate plugin names
 	for i, plugin := range instance.Spec.Plugins {
 		if err := validatePluginName(plugin); err != nil {
@@ -264,6 +391,16 @@ func (v *OpenClawInstanceValidator) validate(instance *openclawv1alpha1.OpenClaw
 		return nil, err
 	}
 
+	// 19a. The main container name must not collide with a sidecar
+	if err := validateContainerName(instance); err != nil {
+		return nil, err
+	}
+
+	// 19b. The maintenance container needs a command to run
+	if instance.Spec.Maintenance.Enabled && len(instance.Spec.Maintenance.Command) == 0 {
+		return nil, fmt.Errorf("spec.maintenance.command is required when spec.maintenance.enabled is true")
+	}
+
 	// 20. Validate JSON5 config constraints
 	if instance.Spec.Config.Format == configFormatJSON5 {
 		if instance.Spec.Config.Raw != nil {
@@ -272,6 +409,9 @@ func (v *OpenClawInstanceValidator) validate(instance *openclawv1alpha1.OpenClaw
 		if instance.Spec.Config.MergeMode == "merge" {
 			return nil, fmt.Errorf("config.format \"json5\" is not compatible with mergeMode \"merge\"")
 		}
+		if len(instance.Spec.Config.ConfigMapRefs) > 0 {
+			return nil, fmt.Errorf("config.format \"json5\" is not compatible with configMapRefs — multiple sources must be valid JSON to be merged")
+		}
 	}
 
 	// 18. Validate auto-update healthCheckTimeout
@@ -288,23 +428,109 @@ func (v *OpenClawInstanceValidator) validate(instance *openclawv1alpha1.OpenClaw
 		}
 	}
 
+	// 20a. Validate the active config overlay exists
+	if err := resources.ValidateConfigOverlay(instance); err != nil {
+		return nil, err
+	}
+
+	// 20b. Validate extra ConfigMap data keys
+	if err := resources.ValidateExtraConfigMapData(instance); err != nil {
+		return nil, err
+	}
+
 	// 21. Reject suspended + HPA auto-scaling (mutually exclusive)
 	if instance.Spec.Suspended && resources.IsHPAEnabled(instance) {
 		return nil, fmt.Errorf("spec.suspended and spec.availability.autoScaling.enabled are mutually exclusive: disable auto-scaling before suspending")
 	}
 
+	// 22. Job and CronJob runs are single pods, so they cannot be auto-scaled
+	if resources.IsJobMode(instance) && resources.IsHPAEnabled(instance) {
+		return nil, fmt.Errorf("spec.mode=job and spec.availability.autoScaling.enabled are mutually exclusive")
+	}
+	if resources.IsScheduled(instance) && resources.IsHPAEnabled(instance) {
+		return nil, fmt.Errorf("spec.schedule and spec.availability.autoScaling.enabled are mutually exclusive")
+	}
+
+	// 22a. The schedule must be accepted by the CronJob API
+	if err := validateSchedule(instance.Spec.Schedule); err != nil {
+		return nil, err
+	}
+
+	// 23. The StatefulSet controller overrides the pod subdomain with its serviceName
+	if instance.Spec.Availability.Subdomain != "" && !resources.IsJobMode(instance) && !resources.IsScheduled(instance) {
+		warnings = append(warnings, fmt.Sprintf("spec.availability.subdomain has no effect on StatefulSet pods: their subdomain is always the Service %q", resources.ServiceName(instance)))
+	}
+
+	// 24. Prometheus rejects a scrape timeout longer than the interval. Only
+	// Go-parsable durations are compared; Prometheus-only units (d, w, y) are
+	// left to the Prometheus operator.
+	if sm := instance.Spec.Observability.Metrics.ServiceMonitor; sm != nil && sm.ScrapeTimeout != "" {
+		timeout, terr := time.ParseDuration(sm.ScrapeTimeout)
+		interval, ierr := time.ParseDuration(resources.ServiceMonitorInterval(instance))
+		if terr == nil && ierr == nil && timeout > interval {
+			return nil, fmt.Errorf("observability.metrics.serviceMonitor.scrapeTimeout (%s) must not exceed the interval (%s)", sm.ScrapeTimeout, resources.ServiceMonitorInterval(instance))
+		}
+	}
+
+	// 25. Validate the alert "for" durations
+	if err := resources.ValidatePrometheusRule(instance); err != nil {
+		return nil, err
+	}
+
 	return warnings, nil
 }
 
+// validateSourceRange loosely validates an allowlist entry. Both CIDR notation
+// and bare IPv4/IPv6 addresses are accepted, matching what nginx allows.
+func validateSourceRange(r string) error {
+	r = strings.TrimSpace(r)
+	if r == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	if _, _, err := net.ParseCIDR(r); err == nil {
+		return nil
+	}
+	if net.ParseIP(r) != nil {
+		return nil
+	}
+	return fmt.Errorf("must be a valid CIDR or IP address")
+}
+
+// validateTailscaleServePort checks spec.tailscale.servePort is a valid port
+// that does not collide with the canvas serve port and, in funnel mode, is one
+// of the ports Tailscale Funnel supports.
+func validateTailscaleServePort(instance *openclawv1alpha1.OpenClawInstance) error {
+	port := instance.Spec.Tailscale.ServePort
+	if port == nil {
+		return nil
+	}
+	if *port < 1 || *port > 65535 {
+		return fmt.Errorf("spec.tailscale.servePort %d must be between 1 and 65535", *port)
+	}
+	if resources.IsTailscaleServeCanvasEnabled(instance) && *port == resources.TailscaleCanvasServePort {
+		return fmt.Errorf("spec.tailscale.servePort %d is used for the canvas when spec.tailscale.serveCanvas is enabled", *port)
+	}
+	if instance.Spec.Tailscale.Mode == resources.TailscaleModeFunnel {
+		switch *port {
+		case 443, 8443, 10000:
+		default:
+			return fmt.Errorf("spec.tailscale.servePort %d is not supported in funnel mode: use 443, 8443 or 10000", *port)
+		}
+	}
+	return nil
+}
+
 // validateWorkspaceSpec validates workspace file and directory names.
-func validateWorkspaceSpec(ws *openclawv1alpha1.WorkspaceSpec) error {
+func validateWorkspaceSpec(instance *openclawv1alpha1.OpenClawInstance) error {
+	ws := instance.Spec.Workspace
+
 	// Validate configMapRef
 	if ws.ConfigMapRef != nil && ws.ConfigMapRef.Name == "" {
 		return fmt.Errorf("workspace configMapRef.name must not be empty")
 	}
 
 	for name := range ws.InitialFiles {
-		if err := resources.ValidateWorkspaceFilename(name); err != nil {
+		if err := resources.ValidateWorkspaceFilename(instance, name); err != nil {
 			return fmt.Errorf("workspace initialFiles key %q: %w", name, err)
 		}
 	}
@@ -332,7 +558,7 @@ func validateWorkspaceSpec(ws *openclawv1alpha1.WorkspaceSpec) error {
 			return fmt.Errorf("additionalWorkspaces[%d] %q configMapRef.name must not be empty", i, aw.Name)
 		}
 		for name := range aw.InitialFiles {
-			if err := resources.ValidateWorkspaceFilename(name); err != nil {
+			if err := resources.ValidateWorkspaceFilename(instance, name); err != nil {
 				return fmt.Errorf("additionalWorkspaces[%d] %q initialFiles key %q: %w", i, aw.Name, name, err)
 			}
 		}
@@ -363,34 +589,7 @@ func validateResourceQuantities(instance *openclawv1alpha1.OpenClawInstance) err
 	if err := check("spec.storage.persistence.size", instance.Spec.Storage.Persistence.Size); err != nil {
 		return err
 	}
-
-	// Main container resources
-	r := instance.Spec.Resources
-	if err := check("spec.resources.requests.cpu", r.Requests.CPU); err != nil {
-		return err
-	}
-	if err := check("spec.resources.requests.memory", r.Requests.Memory); err != nil {
-		return err
-	}
-	if err := check("spec.resources.limits.cpu", r.Limits.CPU); err != nil {
-		return err
-	}
-	if err := check("spec.resources.limits.memory", r.Limits.Memory); err != nil {
-		return err
-	}
-
-	// Chromium resources
-	cr := instance.Spec.Chromium.Resources
-	if err := check("spec.chromium.resources.requests.cpu", cr.Requests.CPU); err != nil {
-		return err
-	}
-	if err := check("spec.chromium.resources.requests.memory", cr.Requests.Memory); err != nil {
-		return err
-	}
-	if err := check("spec.chromium.resources.limits.cpu", cr.Limits.CPU); err != nil {
-		return err
-	}
-	if err := check("spec.chromium.resources.limits.memory", cr.Limits.Memory); err != nil {
+	if err := check("spec.storage.logs.size", instance.Spec.Storage.Logs.Size); err != nil {
 		return err
 	}
 
@@ -398,34 +597,7 @@ func validateResourceQuantities(instance *openclawv1alpha1.OpenClawInstance) err
 	if err := check("spec.chromium.persistence.size", instance.Spec.Chromium.Persistence.Size); err != nil {
 		return err
 	}
-
-	// Tailscale resources
-	tr := instance.Spec.Tailscale.Resources
-	if err := check("spec.tailscale.resources.requests.cpu", tr.Requests.CPU); err != nil {
-		return err
-	}
-	if err := check("spec.tailscale.resources.requests.memory", tr.Requests.Memory); err != nil {
-		return err
-	}
-	if err := check("spec.tailscale.resources.limits.cpu", tr.Limits.CPU); err != nil {
-		return err
-	}
-	if err := check("spec.tailscale.resources.limits.memory", tr.Limits.Memory); err != nil {
-		return err
-	}
-
-	// Ollama resources
-	or := instance.Spec.Ollama.Resources
-	if err := check("spec.ollama.resources.requests.cpu", or.Requests.CPU); err != nil {
-		return err
-	}
-	if err := check("spec.ollama.resources.requests.memory", or.Requests.Memory); err != nil {
-		return err
-	}
-	if err := check("spec.ollama.resources.limits.cpu", or.Limits.CPU); err != nil {
-		return err
-	}
-	if err := check("spec.ollama.resources.limits.memory", or.Limits.Memory); err != nil {
+	if err := check("spec.chromium.tmpSizeLimit", instance.Spec.Chromium.TmpSizeLimit); err != nil {
 		return err
 	}
 
@@ -434,22 +606,8 @@ func validateResourceQuantities(instance *openclawv1alpha1.OpenClawInstance) err
 		return err
 	}
 
-	// Web terminal resources
-	wr := instance.Spec.WebTerminal.Resources
-	if err := check("spec.webTerminal.resources.requests.cpu", wr.Requests.CPU); err != nil {
-		return err
-	}
-	if err := check("spec.webTerminal.resources.requests.memory", wr.Requests.Memory); err != nil {
-		return err
-	}
-	if err := check("spec.webTerminal.resources.limits.cpu", wr.Limits.CPU); err != nil {
-		return err
-	}
-	if err := check("spec.webTerminal.resources.limits.memory", wr.Limits.Memory); err != nil {
-		return err
-	}
-
-	return nil
+	// Compute resources (main container and sidecars)
+	return resources.ValidateResources(instance)
 }
 
 // validateWorkspaceFilename and validateWorkspaceDirectory are in
@@ -554,12 +712,15 @@ func validateConfigSchema(instance *openclawv1alpha1.OpenClawInstance) admission
 
 // reservedInitContainerNames are names used by operator-managed init containers.
 var reservedInitContainerNames = map[string]bool{
-	"init-config":  true,
-	"init-pnpm":    true,
-	"init-python":  true,
-	"init-skills":  true,
-	"init-plugins": true,
-	"init-ollama":  true,
+	"init-chown":       true,
+	"init-config":      true,
+	"init-maintenance": true,
+	"init-pnpm":        true,
+	"init-python":      true,
+	"init-skills":      true,
+	"init-validate":    true,
+	"init-plugins":     true,
+	"init-ollama":      true,
 }
 
 // validateInitContainers checks custom init container names.
@@ -576,6 +737,40 @@ func validateInitContainers(containers []corev1.Container) error {
 	return nil
 }
 
+// validateSchedule checks spec.schedule the way the CronJob API does: a
+// standard five-field cron expression or descriptor (e.g. "@hourly"), parsed
+// with the same library, and no TZ/CRON_TZ prefix.
+func validateSchedule(schedule string) error {
+	if schedule == "" {
+		return nil
+	}
+	if strings.Contains(schedule, "TZ") {
+		return fmt.Errorf("spec.schedule %q: TZ and CRON_TZ are not supported", schedule)
+	}
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return fmt.Errorf("spec.schedule %q is not a valid cron expression: %w", schedule, err)
+	}
+	return nil
+}
+
+// validateContainerName checks that spec.containerName does not reuse the name
+// of an operator-managed sidecar or of a user sidecar.
+func validateContainerName(instance *openclawv1alpha1.OpenClawInstance) error {
+	name := instance.Spec.ContainerName
+	if name == "" {
+		return nil
+	}
+	if slices.Contains(resources.OperatorSidecarNames(instance), name) {
+		return fmt.Errorf("spec.containerName %q is reserved for an operator-managed sidecar", name)
+	}
+	for i := range instance.Spec.Sidecars {
+		if instance.Spec.Sidecars[i].Name == name {
+			return fmt.Errorf("spec.containerName %q is already used by sidecars[%d]", name, i)
+		}
+	}
+	return nil
+}
+
 // OpenClawInstanceDefaulter sets defaults for OpenClawInstance resources
 type OpenClawInstanceDefaulter struct{}
 
@@ -590,7 +785,7 @@ func (d *OpenClawInstanceDefaulter) Default(ctx context.Context, obj runtime.Obj
 		instance.Spec.Image.Repository = "ghcr.io/openclaw/openclaw"
 	}
 	if instance.Spec.Image.Tag == "" && instance.Spec.Image.Digest == "" {
-		instance.Spec.Image.Tag = imageTagLatest
+		instance.Spec.Image.Tag = resources.DefaultOpenClawImageTag
 	}
 	if instance.Spec.Image.PullPolicy == "" {
 		instance.Spec.Image.PullPolicy = corev1.PullIfNotPresent
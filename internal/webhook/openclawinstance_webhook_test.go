@@ -257,6 +257,141 @@ func TestValidateCreate_NoWarnForceHTTPSWhenIngressDisabled(t *testing.T) {
 	}
 }
 
+func TestValidateCreate_WarnsIngressPathRewriteNonNginx(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Networking.Ingress.Enabled = true
+	instance.Spec.Networking.Ingress.ClassName = ptr("traefik")
+	instance.Spec.Networking.Ingress.PathRewrite = "/openclaw"
+
+	warnings, err := v.ValidateCreate(context.Background(), instance)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !containsWarning(warnings, "pathRewrite") {
+		t.Fatalf("expected pathRewrite warning for traefik, got: %v", warnings)
+	}
+
+	instance.Spec.Networking.Ingress.ClassName = ptr("nginx")
+	warnings, _ = v.ValidateCreate(context.Background(), instance)
+	if containsWarning(warnings, "pathRewrite") {
+		t.Fatalf("expected no pathRewrite warning for nginx, got: %v", warnings)
+	}
+}
+
+func TestValidateCreate_AllowedSourceRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		ranges  []string
+		wantErr bool
+	}{
+		{"ipv4 CIDR", []string{"10.0.0.0/8"}, false},
+		{"bare IPv4", []string{"203.0.113.7"}, false},
+		{"ipv6 CIDR", []string{"2001:db8::/32"}, false},
+		{"garbage", []string{"office"}, true},
+		{"bad prefix", []string{"10.0.0.0/33"}, true},
+		{"empty entry", []string{""}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &OpenClawInstanceValidator{}
+			instance := newTestInstance()
+			instance.Spec.Networking.Ingress.Security.AllowedSourceRanges = tt.ranges
+
+			_, err := v.ValidateCreate(context.Background(), instance)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected error for invalid source range")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "allowedSourceRanges[0]") {
+				t.Fatalf("error should reference the offending index, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCreate_RejectsUnknownIngressPathType(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Networking.Ingress.Hosts = []openclawv1alpha1.IngressHost{
+		{Host: "example.com", Paths: []openclawv1alpha1.IngressPath{{Path: "/", PathType: "Regex"}}},
+	}
+
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil {
+		t.Fatal("expected error for unknown ingress pathType")
+	}
+	if !strings.Contains(err.Error(), "pathType") {
+		t.Fatalf("error should mention pathType, got: %v", err)
+	}
+}
+
+func TestValidateCreate_SkillsJobModeRequiresPersistence(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+
+	instance := newTestInstance()
+	instance.Spec.Skills = []string{"weather"}
+	instance.Spec.SkillsInstallMode = "job"
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Fatalf("expected job mode with default persistence to pass, got: %v", err)
+	}
+
+	instance.Spec.Storage.Persistence.Enabled = ptr(false)
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "skillsInstallMode") {
+		t.Fatalf("expected skillsInstallMode error without persistence, got: %v", err)
+	}
+}
+
+func TestValidateCreate_RejectsSidecarPortCollision(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Sidecars = []corev1.Container{
+		{Name: "shadow", Image: "busybox:1.36", Ports: []corev1.ContainerPort{{ContainerPort: 11434}}},
+	}
+
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil {
+		t.Fatal("expected error for sidecar port colliding with ollama")
+	}
+	if !strings.Contains(err.Error(), "container port") {
+		t.Fatalf("error should mention the container port, got: %v", err)
+	}
+}
+
+func TestValidateCreate_WarnsLatestWithPullNever(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Image.Tag = "latest"
+	instance.Spec.Image.PullPolicy = corev1.PullNever
+
+	warnings, err := v.ValidateCreate(context.Background(), instance)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !containsWarning(warnings, "pullPolicy Never") {
+		t.Errorf("expected latest+Never warning, got: %v", warnings)
+	}
+}
+
+func TestValidateCreate_RejectsReservedExtraConfigMapKey(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.ExtraConfigMapData = map[string]string{"openclaw.json": "{}"}
+
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil {
+		t.Fatal("expected error for reserved extraConfigMapData key")
+	}
+	if !strings.Contains(err.Error(), "reserved") {
+		t.Fatalf("error should mention the reserved key, got: %v", err)
+	}
+}
+
 func TestValidateCreate_WarnsChromiumWithoutDigest(t *testing.T) {
 	v := &OpenClawInstanceValidator{}
 	instance := newTestInstance()
@@ -425,6 +560,28 @@ func TestValidateCreate_RejectsPartialResourceLimits_MissingMemory(t *testing.T)
 	}
 }
 
+func TestValidateCreate_GuaranteedResources(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Resources.Guaranteed = ptr(true)
+	instance.Spec.Resources.Requests = openclawv1alpha1.ResourceList{CPU: "2000m", Memory: "4Gi"}
+
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Fatalf("equal requests and limits should be accepted, got: %v", err)
+	}
+
+	instance.Spec.Resources.Requests.Memory = "2Gi"
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "spec.resources.guaranteed") {
+		t.Fatalf("expected guaranteed mismatch error, got: %v", err)
+	}
+
+	instance.Spec.Resources.Guaranteed = nil
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Fatalf("differing requests and limits are fine without guaranteed, got: %v", err)
+	}
+}
+
 func TestValidateCreate_WarnsLatestImageTag(t *testing.T) {
 	v := &OpenClawInstanceValidator{}
 	instance := newTestInstance()
@@ -602,6 +759,28 @@ func TestValidateUpdate_AllowsStorageClassWhenNewIsNil(t *testing.T) {
 	}
 }
 
+func TestValidateUpdate_ImmutableNamePrefixSuffix(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+
+	oldInstance := newTestInstance()
+	oldInstance.Spec.NamePrefix = "team-a-"
+
+	newInstance := newTestInstance()
+	newInstance.Spec.NamePrefix = "team-b-"
+
+	_, err := v.ValidateUpdate(context.Background(), oldInstance, newInstance)
+	if err == nil || !strings.Contains(err.Error(), "immutable") {
+		t.Fatalf("expected immutability error for namePrefix change, got: %v", err)
+	}
+
+	newInstance.Spec.NamePrefix = "team-a-"
+	newInstance.Spec.NameSuffix = "-blue"
+	_, err = v.ValidateUpdate(context.Background(), oldInstance, newInstance)
+	if err == nil || !strings.Contains(err.Error(), "immutable") {
+		t.Fatalf("expected immutability error for nameSuffix change, got: %v", err)
+	}
+}
+
 func TestValidateUpdate_AllowsOtherChanges(t *testing.T) {
 	v := &OpenClawInstanceValidator{}
 
@@ -843,6 +1022,14 @@ func TestValidateResourceQuantities(t *testing.T) {
 			wantErr: true,
 			errSub:  "spec.chromium.persistence.size",
 		},
+		{
+			name: "Invalid Chromium tmp size limit",
+			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
+				i.Spec.Chromium.TmpSizeLimit = "lots"
+			},
+			wantErr: true,
+			errSub:  "spec.chromium.tmpSizeLimit",
+		},
 		{
 			name: "Invalid Ollama storage size limit",
 			mutate: func(i *openclawv1alpha1.OpenClawInstance) {
@@ -1040,6 +1227,26 @@ func TestValidateCreate_WorkspaceFileReservedName(t *testing.T) {
 	}
 }
 
+func TestValidateCreate_WorkspaceFileReservedCustomConfigName(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Config.FileName = "agent.json"
+	instance.Spec.Workspace = &openclawv1alpha1.WorkspaceSpec{
+		InitialFiles: map[string]string{"agent.json": "content"},
+	}
+
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "'agent.json' is reserved") {
+		t.Fatalf("expected the custom config file name to be reserved, got: %v", err)
+	}
+
+	// Only the configured name is reserved
+	instance.Spec.Workspace.InitialFiles = map[string]string{"openclaw.json": "content"}
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Errorf("openclaw.json should be allowed with a custom config file name, got: %v", err)
+	}
+}
+
 func TestValidateCreate_WorkspaceDirDotDot(t *testing.T) {
 	v := &OpenClawInstanceValidator{}
 	instance := newTestInstance()
@@ -1350,6 +1557,46 @@ func TestValidateCreate_ConfigSchema_UnknownKey(t *testing.T) {
 // Custom init container validation tests
 // ---------------------------------------------------------------------------
 
+func TestValidateCreate_Schedule(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+
+	for _, schedule := range []string{"0 * * * *", "*/15 9-17 * * MON-FRI", "@daily"} {
+		instance := newTestInstance()
+		instance.Spec.Schedule = schedule
+		if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+			t.Errorf("schedule %q: unexpected error: %v", schedule, err)
+		}
+	}
+
+	for _, schedule := range []string{"every hour", "0 * * *", "61 * * * *", "CRON_TZ=UTC 0 * * * *"} {
+		instance := newTestInstance()
+		instance.Spec.Schedule = schedule
+		_, err := v.ValidateCreate(context.Background(), instance)
+		if err == nil || !strings.Contains(err.Error(), "spec.schedule") {
+			t.Errorf("schedule %q: expected a spec.schedule error, got: %v", schedule, err)
+		}
+	}
+}
+
+func TestValidateCreate_ContainerName(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+
+	instance := newTestInstance()
+	instance.Spec.ContainerName = "app"
+	instance.Spec.Sidecars = []corev1.Container{{Name: "exporter", Image: "exporter:1.0"}}
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"chromium", "ollama", "gateway-proxy", "tailscale", "otel-collector", "web-terminal", "exporter"} {
+		instance.Spec.ContainerName = name
+		_, err := v.ValidateCreate(context.Background(), instance)
+		if err == nil || !strings.Contains(err.Error(), "spec.containerName") {
+			t.Errorf("containerName %q: expected a collision error, got: %v", name, err)
+		}
+	}
+}
+
 func TestValidateCreate_InitContainers_Valid(t *testing.T) {
 	v := &OpenClawInstanceValidator{}
 	instance := newTestInstance()
@@ -1427,6 +1674,22 @@ func TestValidateCreate_InitContainers_ReservedName_InitPython(t *testing.T) {
 	}
 }
 
+func TestValidateCreate_MaintenanceRequiresCommand(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Maintenance.Enabled = true
+
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "spec.maintenance.command") {
+		t.Fatalf("expected missing maintenance command error, got: %v", err)
+	}
+
+	instance.Spec.Maintenance.Command = []string{"true"}
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestValidateCreate_InitContainers_EmptyName(t *testing.T) {
 	v := &OpenClawInstanceValidator{}
 	instance := newTestInstance()
@@ -1482,6 +1745,38 @@ func TestValidateCreate_JSON5_WithMerge(t *testing.T) {
 	}
 }
 
+func TestValidateCreate_JSON5_WithConfigMapRefs(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Config.Format = "json5"
+	instance.Spec.Config.ConfigMapRefs = []openclawv1alpha1.ConfigMapKeySelector{
+		{Name: "my-config", Key: "a.json5"},
+		{Name: "my-config", Key: "b.json5"},
+	}
+
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil {
+		t.Fatal("expected error for json5 with configMapRefs")
+	}
+	if !strings.Contains(err.Error(), "configMapRefs") {
+		t.Fatalf("error should mention configMapRefs, got: %v", err)
+	}
+}
+
+func TestValidateCreate_UnknownActiveOverlay(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Config.ActiveOverlay = "prod"
+
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil {
+		t.Fatal("expected error for unknown activeOverlay")
+	}
+	if !strings.Contains(err.Error(), "activeOverlay") {
+		t.Fatalf("error should mention activeOverlay, got: %v", err)
+	}
+}
+
 func TestValidateSkillName_NpmPrefix(t *testing.T) {
 	// Valid npm-prefixed skill should pass
 	if err := validateSkillName("npm:@openclaw/matrix"); err != nil {
@@ -1767,6 +2062,108 @@ func TestValidateCreate_SuspendedWithHPA(t *testing.T) {
 	}
 }
 
+func TestValidateCreate_JobModeWithHPA(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Mode = "job"
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Fatalf("unexpected error for job mode: %v", err)
+	}
+
+	instance.Spec.Availability.AutoScaling = &openclawv1alpha1.AutoScalingSpec{
+		Enabled: ptr(true),
+	}
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "spec.mode=job") {
+		t.Errorf("expected job mode + HPA error, got: %v", err)
+	}
+
+	instance.Spec.Mode = ""
+	instance.Spec.Schedule = "0 * * * *"
+	_, err = v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "spec.schedule") {
+		t.Errorf("expected schedule + HPA error, got: %v", err)
+	}
+}
+
+func TestValidateCreate_SubdomainWarning(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Availability.Subdomain = "agents"
+
+	warnings, err := v.ValidateCreate(context.Background(), instance)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !containsWarning(warnings, "spec.availability.subdomain") {
+		t.Errorf("expected subdomain warning in StatefulSet mode, got: %v", warnings)
+	}
+
+	instance.Spec.Mode = "job"
+	warnings, err = v.ValidateCreate(context.Background(), instance)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if containsWarning(warnings, "spec.availability.subdomain") {
+		t.Errorf("unexpected subdomain warning in job mode: %v", warnings)
+	}
+}
+
+func TestValidateCreate_ServiceMonitorScrapeTimeout(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Observability.Metrics.ServiceMonitor = &openclawv1alpha1.ServiceMonitorSpec{
+		Enabled:       ptr(true),
+		ScrapeTimeout: "10s",
+	}
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Fatalf("scrapeTimeout below the default interval should be valid, got: %v", err)
+	}
+
+	instance.Spec.Observability.Metrics.ServiceMonitor.ScrapeTimeout = "45s"
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "scrapeTimeout") {
+		t.Errorf("expected scrapeTimeout > interval error, got: %v", err)
+	}
+
+	instance.Spec.Observability.Metrics.ServiceMonitor.Interval = "1m"
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Errorf("scrapeTimeout below a custom interval should be valid, got: %v", err)
+	}
+}
+
+func TestValidateCreate_PrometheusRuleForDurations(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Observability.Metrics.PrometheusRule = &openclawv1alpha1.PrometheusRuleSpec{
+		Enabled:    ptr(true),
+		DefaultFor: "1h30m",
+		AlertFor:   map[string]string{"OpenClawPodOOMKilled": "0"},
+	}
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Fatalf("valid durations should be accepted, got: %v", err)
+	}
+
+	instance.Spec.Observability.Metrics.PrometheusRule.DefaultFor = "ten minutes"
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "defaultFor") {
+		t.Errorf("expected defaultFor error, got: %v", err)
+	}
+
+	instance.Spec.Observability.Metrics.PrometheusRule.DefaultFor = ""
+	instance.Spec.Observability.Metrics.PrometheusRule.AlertFor = map[string]string{"OpenClawPodOOMKilled": "5 m"}
+	_, err = v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "alertFor[OpenClawPodOOMKilled]") {
+		t.Errorf("expected alertFor duration error, got: %v", err)
+	}
+
+	instance.Spec.Observability.Metrics.PrometheusRule.AlertFor = map[string]string{"NoSuchAlert": "5m"}
+	_, err = v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "unknown alert") {
+		t.Errorf("expected unknown alert error, got: %v", err)
+	}
+}
+
 func TestValidateCreate_SuspendedWithoutHPA(t *testing.T) {
 	v := &OpenClawInstanceValidator{}
 	instance := newTestInstance()
@@ -1796,3 +2193,218 @@ func TestValidateUpdate_SuspendedWithHPA(t *testing.T) {
 		t.Errorf("error should mention mutual exclusivity, got: %s", err.Error())
 	}
 }
+
+func TestValidateCreate_GatewayBindWithProxy(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+
+	instance := newTestInstance()
+	instance.Spec.Gateway.Bind = "lan"
+	warnings, err := v.ValidateCreate(context.Background(), instance)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !containsWarning(warnings, `gateway.bind is "lan"`) {
+		t.Errorf("expected bind warning, got: %v", warnings)
+	}
+
+	instance = newTestInstance()
+	instance.Spec.Config.Raw = &openclawv1alpha1.RawConfig{
+		RawExtension: k8sruntime.RawExtension{Raw: []byte(`{"gateway":{"bind":"0.0.0.0"}}`)},
+	}
+	warnings, _ = v.ValidateCreate(context.Background(), instance)
+	if !containsWarning(warnings, `gateway.bind is "0.0.0.0"`) {
+		t.Errorf("expected bind warning for config override, got: %v", warnings)
+	}
+
+	instance = newTestInstance()
+	instance.Spec.Gateway.Bind = "loopback"
+	warnings, _ = v.ValidateCreate(context.Background(), instance)
+	if containsWarning(warnings, "gateway.bind") {
+		t.Errorf("loopback bind should not warn, got: %v", warnings)
+	}
+
+	instance = newTestInstance()
+	instance.Spec.Gateway.Enabled = ptr(false)
+	instance.Spec.Gateway.Bind = "0.0.0.0"
+	warnings, _ = v.ValidateCreate(context.Background(), instance)
+	if containsWarning(warnings, "gateway.bind") {
+		t.Errorf("non-loopback bind without proxy should not warn, got: %v", warnings)
+	}
+}
+
+func TestValidateCreate_LogsPVCWithHPA(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+	instance := newTestInstance()
+	instance.Spec.Storage.Logs = openclawv1alpha1.LogsStorageSpec{Enabled: true, Size: "5Gi"}
+	instance.Spec.Availability.AutoScaling = &openclawv1alpha1.AutoScalingSpec{
+		Enabled: ptr(true),
+	}
+
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "storage.logs.size") {
+		t.Fatalf("expected logs PVC + HPA error, got: %v", err)
+	}
+
+	// emptyDir logs are fine with HPA
+	instance.Spec.Storage.Logs.Size = ""
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Errorf("emptyDir logs with HPA should be valid, got: %v", err)
+	}
+
+	instance.Spec.Availability.AutoScaling = nil
+	instance.Spec.Storage.Logs.Size = "lots"
+	if _, err := v.ValidateCreate(context.Background(), instance); err == nil {
+		t.Error("expected error for invalid logs size")
+	}
+}
+
+func TestValidateCreate_GatewayBasicAuthRequiresSecret(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+
+	instance := newTestInstance()
+	instance.Spec.Gateway.AuthMode = "basic"
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "basicAuthSecret") {
+		t.Errorf("expected basicAuthSecret error, got: %v", err)
+	}
+
+	instance.Spec.Gateway.BasicAuthSecret = "gw-creds"
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Errorf("expected no error with basicAuthSecret set, got: %v", err)
+	}
+}
+
+func TestValidateCreate_InitSeccompProfileLocalhost(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+
+	instance := newTestInstance()
+	instance.Spec.Security.InitSeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost}
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "localhostProfile") {
+		t.Errorf("expected localhostProfile error, got: %v", err)
+	}
+
+	instance.Spec.Security.InitSeccompProfile.LocalhostProfile = ptr("profiles/init.json")
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Errorf("expected no error with localhostProfile set, got: %v", err)
+	}
+}
+
+func TestValidateCreate_TailscaleServePort(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+
+	instance := newTestInstance()
+	instance.Spec.Tailscale.Enabled = true
+	instance.Spec.Tailscale.ServePort = ptr(int32(8080))
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Errorf("serve mode should accept any valid port, got: %v", err)
+	}
+
+	instance.Spec.Tailscale.ServePort = ptr(int32(70000))
+	if _, err := v.ValidateCreate(context.Background(), instance); err == nil {
+		t.Error("expected error for out-of-range serve port")
+	}
+
+	instance.Spec.Tailscale.Mode = "funnel"
+	instance.Spec.Tailscale.ServePort = ptr(int32(8080))
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "funnel") {
+		t.Errorf("expected funnel port error, got: %v", err)
+	}
+
+	instance.Spec.Tailscale.ServePort = ptr(int32(10000))
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Errorf("funnel should accept port 10000, got: %v", err)
+	}
+}
+
+func TestValidateCreate_ChromiumReplicasCustomImage(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+
+	instance := newTestInstance()
+	instance.Spec.Chromium.Enabled = true
+	instance.Spec.Chromium.Replicas = ptr(int32(3))
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Fatalf("default image should support replicas, got: %v", err)
+	}
+
+	instance.Spec.Chromium.Image.Repository = "registry.example.com/my-chrome"
+	_, err := v.ValidateCreate(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "spec.chromium.replicas") {
+		t.Errorf("expected error for a custom image with replicas > 1, got: %v", err)
+	}
+
+	instance.Spec.Chromium.Replicas = ptr(int32(1))
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Errorf("custom image with a single sidecar should be allowed, got: %v", err)
+	}
+}
+
+func TestValidateCreate_TailscaleKernelModeWarning(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+
+	instance := newTestInstance()
+	instance.Spec.Tailscale.Enabled = true
+	warnings, err := v.ValidateCreate(context.Background(), instance)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if containsWarning(warnings, "tailscale.userspace") {
+		t.Errorf("no warning expected in userspace mode, got %v", warnings)
+	}
+
+	instance.Spec.Tailscale.Userspace = ptr(false)
+	warnings, err = v.ValidateCreate(context.Background(), instance)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !containsWarning(warnings, "runs as root") {
+		t.Errorf("expected a root warning for kernel networking, got %v", warnings)
+	}
+}
+
+func TestValidateCreate_OllamaRegistryCIDRs(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+
+	instance := newTestInstance()
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Ollama.RegistryCIDRs = []string{"104.21.0.0/16"}
+	if _, err := v.ValidateCreate(context.Background(), instance); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	instance.Spec.Ollama.RegistryCIDRs = []string{"registry.ollama.ai"}
+	if _, err := v.ValidateCreate(context.Background(), instance); err == nil {
+		t.Error("expected error for a DNS name in registryCIDRs")
+	}
+}
+
+func TestValidateCreate_OllamaExternal(t *testing.T) {
+	v := &OpenClawInstanceValidator{}
+
+	instance := newTestInstance()
+	instance.Spec.Ollama.Enabled = true
+	instance.Spec.Ollama.External = ptr(true)
+	instance.Spec.Ollama.ExternalHostCIDRs = []string{"10.20.0.0/16"}
+	warnings, err := v.ValidateCreate(context.Background(), instance)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !containsWarning(warnings, "OLLAMA_HOST") {
+		t.Errorf("expected a warning about missing OLLAMA_HOST, got %v", warnings)
+	}
+	if containsWarning(warnings, "runs as root") {
+		t.Error("no sidecar warnings expected in external mode")
+	}
+
+	instance.Spec.Env = []corev1.EnvVar{{Name: "OLLAMA_HOST", Value: "http://ollama.ai-system:11434"}}
+	warnings, _ = v.ValidateCreate(context.Background(), instance)
+	if containsWarning(warnings, "OLLAMA_HOST") {
+		t.Errorf("unexpected OLLAMA_HOST warning: %v", warnings)
+	}
+
+	instance.Spec.Ollama.ExternalHostCIDRs = []string{"ollama.ai-system"}
+	if _, err := v.ValidateCreate(context.Background(), instance); err == nil {
+		t.Error("expected error for a DNS name in externalHostCIDRs")
+	}
+}